@@ -0,0 +1,146 @@
+// Package backtest 는 과거 캔들을 한 봉씩 재생하며 Strategy 를 실행하고, 체결 결과로 수익률 보고서를 만듭니다.
+//
+// 캔들은 LoadCandles (API), LoadFile (CSV, JSON) 로 불러옵니다. Strategy 가 OnBar 에서 넣은 주문은 다음 봉부터
+// 체결되며 (시장가 주문은 다음 봉의 시가, 지정가 주문은 고가/저가가 주문 가격에 닿을 때), 체결 가격에는 Config 의
+// 수수료, 슬리피지 모델이 적용됩니다.
+//
+//	candles, _ := backtest.LoadCandles(ctx, client, gobithumb.NewMarket(gobithumb.BTC, gobithumb.KRW), gobithumb.Hour1)
+//	report, _ := backtest.Run(candles, strategy, backtest.Config{InitialCash: gobithumb.MustDecimal("1000000")})
+//	fmt.Println(report)
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// Strategy 는 봉이 하나 끝날 때마다 호출됩니다. broker 로 현재 잔고를 보고 주문을 넣을 수 있습니다.
+type Strategy interface {
+	OnBar(broker *Broker, bar b.OneCandleStick)
+}
+
+// StrategyFunc 는 함수를 Strategy 로 사용하게 해줍니다.
+type StrategyFunc func(broker *Broker, bar b.OneCandleStick)
+
+func (f StrategyFunc) OnBar(broker *Broker, bar b.OneCandleStick) {
+	f(broker, bar)
+}
+
+//==============================FEE, SLIPPAGE======================================
+
+// FeeModel 은 체결 한 건의 수수료를 quote currency 로 계산합니다.
+type FeeModel interface {
+	Fee(side b.OrderSide, units b.Decimal, price b.Decimal) b.Decimal
+}
+
+// PercentFee 는 체결 금액의 Rate 만큼 수수료를 냅니다. (e.g. 0.0025 = 0.25%)
+type PercentFee struct {
+	Rate b.Decimal
+}
+
+func (p PercentFee) Fee(side b.OrderSide, units b.Decimal, price b.Decimal) b.Decimal {
+	return units.Mul(price).Mul(p.Rate)
+}
+
+// SlippageModel 은 시장가 주문의 체결 가격을 정합니다. 지정가 주문에는 적용되지 않습니다.
+type SlippageModel interface {
+	Price(side b.OrderSide, price b.Decimal, bar b.OneCandleStick) b.Decimal
+}
+
+// NoSlippage 는 가격을 그대로 사용합니다.
+type NoSlippage struct{}
+
+func (NoSlippage) Price(side b.OrderSide, price b.Decimal, bar b.OneCandleStick) b.Decimal {
+	return price
+}
+
+// PercentSlippage 는 매수는 Rate 만큼 비싸게, 매도는 Rate 만큼 싸게 체결합니다. 체결 가격은 봉의 고가, 저가를 넘지 않습니다.
+type PercentSlippage struct {
+	Rate b.Decimal
+}
+
+func (p PercentSlippage) Price(side b.OrderSide, price b.Decimal, bar b.OneCandleStick) b.Decimal {
+	one := b.NewDecimalFromInt(1)
+	if side == b.Bid {
+		return b.MinDecimal(price.Mul(one.Add(p.Rate)), bar.HighPrice)
+	}
+	return b.MaxDecimal(price.Mul(one.Sub(p.Rate)), bar.LowPrice)
+}
+
+//==============================RUN======================================
+
+// DefaultFeeRate 는 Config.Fee 가 없을 때 사용하는 수수료율 (0.25%) 입니다.
+var DefaultFeeRate = b.MustDecimal("0.0025")
+
+// Config 는 백테스트 설정입니다.
+type Config struct {
+	// Market 은 보고서에 표시할 시장입니다.
+	Market b.Market
+	// InitialCash 는 시작 잔고 (quote currency) 입니다.
+	InitialCash b.Decimal
+	// Fee 가 nil 이면 PercentFee{DefaultFeeRate} 를 사용합니다.
+	Fee FeeModel
+	// Slippage 가 nil 이면 NoSlippage 를 사용합니다.
+	Slippage SlippageModel
+	// PeriodsPerYear 는 Sharpe ratio 를 연율화할 때 사용하는 1년의 봉 개수입니다. 0 이면 봉 간격으로 계산합니다.
+	PeriodsPerYear float64
+}
+
+// Run 은 candles 를 시간 순서로 재생하며 strategy 를 실행하고 결과 보고서를 반환합니다.
+// 각 봉에서는 이전 봉까지 넣은 주문을 체결한 뒤 종가로 평가금액을 기록하고, 그 다음에 strategy.OnBar 를 호출합니다.
+func Run(candles []b.OneCandleStick, strategy Strategy, config Config) (*Report, error) {
+
+	// parameter 정상 체크
+	if len(candles) == 0 {
+		return nil, fmt.Errorf("%w: 캔들이 없습니다.", b.ErrInvalidParameter)
+	}
+	if strategy == nil {
+		return nil, fmt.Errorf("%w: strategy 가 없습니다.", b.ErrInvalidParameter)
+	}
+	if config.InitialCash.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: 시작 잔고는 0보다 커야 합니다.", b.ErrInvalidParameter)
+	}
+	if config.Fee == nil {
+		config.Fee = PercentFee{Rate: DefaultFeeRate}
+	}
+	if config.Slippage == nil {
+		config.Slippage = NoSlippage{}
+	}
+
+	bars := make([]b.OneCandleStick, len(candles))
+	copy(bars, candles)
+	sortCandles(bars)
+	if config.PeriodsPerYear <= 0 {
+		config.PeriodsPerYear = periodsPerYear(bars)
+	}
+
+	broker := newBroker(config)
+	for index, bar := range bars {
+		broker.bars = bars[:index+1]
+		broker.fill(bar)
+		broker.mark(bar)
+		strategy.OnBar(broker, bar)
+	}
+	return newReport(config, broker), nil
+}
+
+// periodsPerYear 는 봉 간격의 중앙값으로 1년의 봉 개수를 계산합니다.
+func periodsPerYear(bars []b.OneCandleStick) float64 {
+	if len(bars) < 2 {
+		return 365
+	}
+	gaps := make([]time.Duration, 0, len(bars)-1)
+	for index := 1; index < len(bars); index++ {
+		if gap := bars[index].Time.Sub(bars[index-1].Time); gap > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	if len(gaps) == 0 {
+		return 365
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return float64(365*24*time.Hour) / float64(gaps[len(gaps)/2])
+}
@@ -0,0 +1,203 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+var start = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func at(hours int) time.Time {
+	return start.Add(time.Duration(hours) * time.Hour)
+}
+
+func equityOf(values ...float64) []EquityPoint {
+	result := make([]EquityPoint, len(values))
+	for index, data := range values {
+		result[index] = EquityPoint{Time: at(index), Equity: b.NewDecimalFromFloat(data)}
+	}
+	return result
+}
+
+func near(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestRoundTrips(t *testing.T) {
+	fills := []Fill{
+		{Time: at(0), Side: b.Bid, Units: b.MustDecimal("1"), Price: b.MustDecimal("100"), Fee: b.MustDecimal("0.25")},
+		{Time: at(1), Side: b.Bid, Units: b.MustDecimal("1"), Price: b.MustDecimal("110"), Fee: b.MustDecimal("0.275")},
+		{Time: at(2), Side: b.Ask, Units: b.MustDecimal("1.5"), Price: b.MustDecimal("120"), Fee: b.MustDecimal("0.45")},
+		{Time: at(3), Side: b.Ask, Units: b.MustDecimal("0.5"), Price: b.MustDecimal("90"), Fee: b.MustDecimal("0.1125")},
+	}
+	// 매도 1.5 는 첫 매수 1 과 두 번째 매수 0.5 로 나뉘고, 수수료는 수량 비율로 나눔
+	//   1   : 120 - 0.3 (0.45 * 1/1.5) - (100 + 0.25) = 19.45
+	//   0.5 : 60 - 0.15 (0.45 * 0.5/1.5) - (55 + 0.1375 (0.275 * 0.5/1)) = 4.7125
+	//   0.5 : 45 - 0.1125 - (55 + 0.1375 (남은 매수 수수료)) = -10.25
+	want := []struct {
+		entry, exit int
+		units, pnl  string
+		cost        float64
+	}{
+		{0, 2, "1", "19.45", 100.25},
+		{1, 2, "0.5", "4.7125", 55.1375},
+		{1, 3, "0.5", "-10.25", 55.1375},
+	}
+	trades := roundTrips(fills)
+	if len(trades) != len(want) {
+		t.Fatalf("trades = %d, want %d", len(trades), len(want))
+	}
+	for index, data := range want {
+		trade := trades[index]
+		if !trade.EntryTime.Equal(at(data.entry)) || !trade.ExitTime.Equal(at(data.exit)) || !trade.Units.Equal(b.MustDecimal(data.units)) {
+			t.Errorf("trade[%d] = %s ~ %s %s, want %d ~ %d %s", index, trade.EntryTime, trade.ExitTime, trade.Units, data.entry, data.exit, data.units)
+		}
+		if !trade.PnL.Equal(b.MustDecimal(data.pnl)) {
+			t.Errorf("trade[%d] PnL = %s, want %s", index, trade.PnL, data.pnl)
+		}
+		if wantReturn := b.MustDecimal(data.pnl).Float64() / data.cost; !near(trade.Return, wantReturn) {
+			t.Errorf("trade[%d] Return = %f, want %f", index, trade.Return, wantReturn)
+		}
+	}
+
+	// 보유 수량보다 많이 판 부분과 끝까지 보유한 수량은 왕복 거래가 아님
+	trades = roundTrips([]Fill{
+		{Time: at(0), Side: b.Ask, Units: b.MustDecimal("1"), Price: b.MustDecimal("100")},
+		{Time: at(1), Side: b.Bid, Units: b.MustDecimal("2"), Price: b.MustDecimal("100")},
+		{Time: at(2), Side: b.Ask, Units: b.MustDecimal("1"), Price: b.MustDecimal("105")},
+	})
+	if len(trades) != 1 || !trades[0].PnL.Equal(b.MustDecimal("5")) {
+		t.Errorf("trades = %+v, want one trade with PnL 5", trades)
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	tests := []struct {
+		equity []EquityPoint
+		want   float64
+	}{
+		{nil, 0},
+		{equityOf(100, 110, 120), 0},
+		{equityOf(100, 120, 90, 130, 65), 0.5},
+		{equityOf(100, 80, 90, 120, 102), 0.2},
+	}
+	for _, test := range tests {
+		if result := maxDrawdown(test.equity); !near(result, test.want) {
+			t.Errorf("maxDrawdown(%v) = %f, want %f", test.equity, result, test.want)
+		}
+	}
+}
+
+func TestSharpe(t *testing.T) {
+	// 수익률 0.1, -0.1, 0.1 : 평균 1/30, 표본 표준편차 sqrt(0.04/3), 연 4회로 연율화하면 1/sqrt(3)
+	if result := sharpe(equityOf(100, 110, 99, 108.9), 4); !near(result, 1/math.Sqrt(3)) {
+		t.Errorf("sharpe = %f, want %f", result, 1/math.Sqrt(3))
+	}
+	if result := sharpe(equityOf(100, 100, 100, 100), 365); result != 0 {
+		t.Errorf("sharpe of flat equity = %f, want 0", result)
+	}
+	if result := sharpe(equityOf(100, 110), 365); result != 0 {
+		t.Errorf("sharpe of 2 points = %f, want 0", result)
+	}
+}
+
+func TestFillPrice(t *testing.T) {
+	bar := b.OneCandleStick{Time: at(1), OpeningPrice: b.MustDecimal("100"), HighPrice: b.MustDecimal("110"), LowPrice: b.MustDecimal("90"), ClosingPrice: b.MustDecimal("105")}
+	tests := []struct {
+		name     string
+		slippage SlippageModel
+		side     b.OrderSide
+		price    string
+		want     string
+		filled   bool
+	}{
+		{"limit bid below open", NoSlippage{}, b.Bid, "95", "95", true},
+		{"limit bid above open fills at open", NoSlippage{}, b.Bid, "105", "100", true},
+		{"limit bid below low", NoSlippage{}, b.Bid, "85", "", false},
+		{"limit bid at low", NoSlippage{}, b.Bid, "90", "90", true},
+		{"limit ask above open", NoSlippage{}, b.Ask, "105", "105", true},
+		{"limit ask below open fills at open", NoSlippage{}, b.Ask, "95", "100", true},
+		{"limit ask above high", NoSlippage{}, b.Ask, "115", "", false},
+		{"market bid", NoSlippage{}, b.Bid, "0", "100", true},
+		{"market bid with slippage", PercentSlippage{Rate: b.MustDecimal("0.01")}, b.Bid, "0", "101", true},
+		{"market ask with slippage", PercentSlippage{Rate: b.MustDecimal("0.01")}, b.Ask, "0", "99", true},
+		{"slippage capped at high", PercentSlippage{Rate: b.MustDecimal("0.2")}, b.Bid, "0", "110", true},
+		{"limit ignores slippage", PercentSlippage{Rate: b.MustDecimal("0.01")}, b.Bid, "95", "95", true},
+	}
+	for _, test := range tests {
+		broker := newBroker(Config{Slippage: test.slippage})
+		order := Order{Side: test.side, Units: b.MustDecimal("1"), Price: b.MustDecimal(test.price)}
+		price, filled := broker.fillPrice(order, bar)
+		if filled != test.filled || (filled && !price.Equal(b.MustDecimal(test.want))) {
+			t.Errorf("%s: fillPrice = %s, %v, want %s, %v", test.name, price, filled, test.want, test.filled)
+		}
+	}
+}
+
+func TestAffordable(t *testing.T) {
+	tests := []struct {
+		cash  string
+		units string
+		price string
+		want  string
+	}{
+		{"1000", "5", "100", "5"},
+		// 10 개는 수수료 포함 1002.5 이므로 1000 / 1002.5 비율로 줄이고 소수점 8자리에서 내림
+		{"1000", "10", "100", "9.97506234"},
+		{"0", "1", "100", "0"},
+		{"0.0000001", "1", "100", "0"},
+	}
+	for _, test := range tests {
+		broker := newBroker(Config{InitialCash: b.MustDecimal(test.cash), Fee: PercentFee{Rate: b.MustDecimal("0.0025")}})
+		result := broker.affordable(b.MustDecimal(test.units), b.MustDecimal(test.price))
+		if !result.Equal(b.MustDecimal(test.want)) {
+			t.Errorf("affordable(%s @ %s with %s) = %s, want %s", test.units, test.price, test.cash, result, test.want)
+		}
+		cost := result.Mul(b.MustDecimal(test.price)).Mul(b.MustDecimal("1.0025"))
+		if cost.GreaterThan(b.MustDecimal(test.cash)) {
+			t.Errorf("affordable(%s @ %s with %s) costs %s", test.units, test.price, test.cash, cost)
+		}
+	}
+}
+
+func TestRunLimitAndPartialExit(t *testing.T) {
+	bars := []b.OneCandleStick{
+		{Time: at(0), OpeningPrice: b.MustDecimal("100"), HighPrice: b.MustDecimal("100"), LowPrice: b.MustDecimal("100"), ClosingPrice: b.MustDecimal("100")},
+		{Time: at(1), OpeningPrice: b.MustDecimal("100"), HighPrice: b.MustDecimal("102"), LowPrice: b.MustDecimal("94"), ClosingPrice: b.MustDecimal("96")},
+		{Time: at(2), OpeningPrice: b.MustDecimal("112"), HighPrice: b.MustDecimal("115"), LowPrice: b.MustDecimal("111"), ClosingPrice: b.MustDecimal("114")},
+	}
+	// 첫 봉에서 95 지정가 매수 2 개, 두 번째 봉에서 110 지정가 매도 3 개 (보유한 2 개만 체결)
+	strategy := StrategyFunc(func(broker *Broker, bar b.OneCandleStick) {
+		switch len(broker.Bars()) {
+		case 1:
+			broker.PlaceOrder(b.MustDecimal("2"), b.MustDecimal("95"), b.Bid)
+		case 2:
+			broker.PlaceOrder(b.MustDecimal("3"), b.MustDecimal("110"), b.Ask)
+		}
+	})
+	report, err := Run(bars, strategy, Config{InitialCash: b.MustDecimal("1000"), Fee: PercentFee{Rate: b.MustDecimal("0.01")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Fills) != 2 {
+		t.Fatalf("fills = %+v, want 2", report.Fills)
+	}
+	// 매수는 주문 가격 95, 매도는 시가 112 가 주문 가격보다 유리하므로 시가로 체결
+	if !report.Fills[0].Price.Equal(b.MustDecimal("95")) || !report.Fills[1].Price.Equal(b.MustDecimal("112")) || !report.Fills[1].Units.Equal(b.MustDecimal("2")) {
+		t.Errorf("fills = %+v", report.Fills)
+	}
+	// 1000 - 190 - 1.9 + 224 - 2.24 = 1029.86
+	if !report.Cash.Equal(b.MustDecimal("1029.86")) || report.Position.Sign() != 0 || !report.Fees.Equal(b.MustDecimal("4.14")) {
+		t.Errorf("cash = %s, position = %s, fees = %s", report.Cash, report.Position, report.Fees)
+	}
+	if len(report.Trades) != 1 || !report.Trades[0].PnL.Equal(b.MustDecimal("29.86")) || report.WinRate != 1 {
+		t.Errorf("trades = %+v, win rate = %f", report.Trades, report.WinRate)
+	}
+	// 두 번째 봉 종가 평가금액 808.1 + 2 * 96 = 1000.1, 최대 낙폭 없음
+	if !report.Equity[1].Equity.Equal(b.MustDecimal("1000.1")) || report.MaxDrawdown != 0 {
+		t.Errorf("equity = %+v, max drawdown = %f", report.Equity, report.MaxDrawdown)
+	}
+}
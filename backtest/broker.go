@@ -0,0 +1,200 @@
+package backtest
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// unitsPlaces 는 체결 수량의 소수점 자리수입니다.
+const unitsPlaces = 8
+
+// Order 는 아직 체결되지 않은 주문입니다. Price 가 0 이면 시장가 주문입니다.
+type Order struct {
+	ID    string
+	Time  time.Time
+	Side  b.OrderSide
+	Units b.Decimal
+	Price b.Decimal
+}
+
+// Fill 은 체결 한 건입니다. Fee 는 quote currency 로 냅니다.
+type Fill struct {
+	OrderID string
+	Time    time.Time
+	Side    b.OrderSide
+	Units   b.Decimal
+	Price   b.Decimal
+	Fee     b.Decimal
+}
+
+// Broker 는 백테스트 중의 가상 계좌입니다. Strategy.OnBar 안에서만 사용하세요.
+type Broker struct {
+	config Config
+
+	bars     []b.OneCandleStick
+	cash     b.Decimal
+	position b.Decimal
+	orders   []Order
+	fills    []Fill
+	equity   []EquityPoint
+	nextId   int
+}
+
+func newBroker(config Config) *Broker {
+	broker := Broker{}
+	broker.config = config
+	broker.cash = config.InitialCash
+	return &broker
+}
+
+// Time 은 현재 봉의 시각입니다.
+func (br *Broker) Time() time.Time {
+	return br.bars[len(br.bars)-1].Time
+}
+
+// Bars 는 첫 봉부터 현재 봉까지의 캔들입니다. 반환된 slice 를 수정하지 마세요.
+func (br *Broker) Bars() []b.OneCandleStick {
+	return br.bars
+}
+
+// Cash 는 주문에 사용할 수 있는 quote currency 잔고입니다.
+func (br *Broker) Cash() b.Decimal {
+	return br.cash
+}
+
+// Position 은 보유한 base currency 수량입니다.
+func (br *Broker) Position() b.Decimal {
+	return br.position
+}
+
+// Equity 는 현재 봉 종가로 평가한 총 자산입니다.
+func (br *Broker) Equity() b.Decimal {
+	return br.cash.Add(br.position.Mul(br.bars[len(br.bars)-1].ClosingPrice))
+}
+
+// OpenOrders 는 체결되지 않은 주문 목록입니다.
+func (br *Broker) OpenOrders() []Order {
+	result := make([]Order, len(br.orders))
+	copy(result, br.orders)
+	return result
+}
+
+// MarketBuy 는 다음 봉의 시가로 units 만큼 매수합니다.
+func (br *Broker) MarketBuy(units b.Decimal) (string, error) {
+	return br.PlaceOrder(units, b.Decimal{}, b.Bid)
+}
+
+// MarketSell 은 다음 봉의 시가로 units 만큼 매도합니다.
+func (br *Broker) MarketSell(units b.Decimal) (string, error) {
+	return br.PlaceOrder(units, b.Decimal{}, b.Ask)
+}
+
+// PlaceOrder 는 지정가 주문을 넣습니다. price 가 0 이면 시장가 주문입니다.
+// 체결 시점의 잔고가 부족하면 살 수 있는 (팔 수 있는) 만큼만 체결되고 나머지는 취소됩니다.
+func (br *Broker) PlaceOrder(units b.Decimal, price b.Decimal, side b.OrderSide) (string, error) {
+
+	// parameter 정상 체크
+	if !side.Valid() {
+		return "", fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", b.ErrInvalidParameter, side)
+	}
+	if units.Sign() <= 0 {
+		return "", fmt.Errorf("%w: 주문 수량은 0보다 커야 합니다.", b.ErrInvalidParameter)
+	}
+	if price.Sign() < 0 {
+		return "", fmt.Errorf("%w: 주문 가격은 0보다 작을 수 없습니다.", b.ErrInvalidParameter)
+	}
+
+	br.nextId++
+	order := Order{ID: strconv.Itoa(br.nextId), Time: br.Time(), Side: side, Units: units, Price: price}
+	br.orders = append(br.orders, order)
+	return order.ID, nil
+}
+
+// CancelOrder 는 체결되지 않은 주문을 취소합니다.
+func (br *Broker) CancelOrder(id string) error {
+	for index, data := range br.orders {
+		if data.ID == id {
+			br.orders = append(br.orders[:index], br.orders[index+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", b.ErrOrderNotFound, id)
+}
+
+// fill 은 bar 에서 체결되는 주문을 넣은 순서대로 처리합니다.
+func (br *Broker) fill(bar b.OneCandleStick) {
+	var remain []Order
+	for _, order := range br.orders {
+		price, ok := br.fillPrice(order, bar)
+		if !ok {
+			remain = append(remain, order)
+			continue
+		}
+		br.execute(order, price, bar.Time)
+	}
+	br.orders = remain
+}
+
+// fillPrice 는 order 가 bar 에서 체결되는 가격을 반환합니다.
+// 지정가 주문은 시가가 이미 주문 가격보다 유리하면 시가로, 아니면 주문 가격으로 체결됩니다.
+func (br *Broker) fillPrice(order Order, bar b.OneCandleStick) (b.Decimal, bool) {
+	if order.Price.IsZero() {
+		return br.config.Slippage.Price(order.Side, bar.OpeningPrice, bar), true
+	}
+	if order.Side == b.Bid {
+		if bar.LowPrice.GreaterThan(order.Price) {
+			return b.Decimal{}, false
+		}
+		return b.MinDecimal(order.Price, bar.OpeningPrice), true
+	}
+	if bar.HighPrice.LessThan(order.Price) {
+		return b.Decimal{}, false
+	}
+	return b.MaxDecimal(order.Price, bar.OpeningPrice), true
+}
+
+func (br *Broker) execute(order Order, price b.Decimal, at time.Time) {
+	units := order.Units
+	if order.Side == b.Ask {
+		units = b.MinDecimal(units, br.position)
+	} else {
+		units = br.affordable(units, price)
+	}
+	if units.Sign() <= 0 {
+		return
+	}
+
+	fee := br.config.Fee.Fee(order.Side, units, price)
+	total := units.Mul(price)
+	if order.Side == b.Bid {
+		br.cash = br.cash.Sub(total).Sub(fee)
+		br.position = br.position.Add(units)
+	} else {
+		br.cash = br.cash.Add(total).Sub(fee)
+		br.position = br.position.Sub(units)
+	}
+	br.fills = append(br.fills, Fill{OrderID: order.ID, Time: at, Side: order.Side, Units: units, Price: price, Fee: fee})
+}
+
+// affordable 은 수수료를 포함해 현재 잔고로 살 수 있도록 units 를 줄입니다.
+func (br *Broker) affordable(units b.Decimal, price b.Decimal) b.Decimal {
+	for try := 0; try < 3 && units.Sign() > 0; try++ {
+		cost := units.Mul(price).Add(br.config.Fee.Fee(b.Bid, units, price))
+		if cost.Cmp(br.cash) <= 0 {
+			return units
+		}
+		units = units.Mul(br.cash).Div(cost, unitsPlaces+8).Floor(unitsPlaces)
+	}
+	if units.Sign() > 0 && units.Mul(price).Add(br.config.Fee.Fee(b.Bid, units, price)).Cmp(br.cash) <= 0 {
+		return units
+	}
+	return b.Decimal{}
+}
+
+// mark 는 bar 종가로 평가금액을 기록합니다.
+func (br *Broker) mark(bar b.OneCandleStick) {
+	br.equity = append(br.equity, EquityPoint{Time: bar.Time, Equity: br.Equity()})
+}
@@ -0,0 +1,160 @@
+package backtest
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// 백테스트에 사용할 캔들을 API 나 파일에서 불러옵니다. 불러온 캔들은 항상 시간 순서로 정렬됩니다.
+
+// LoadCandles 는 source 의 GetCandleStick 으로 market 의 캔들을 불러옵니다.
+func LoadCandles(ctx context.Context, source b.MarketData, market b.Market, interval b.TimeInterval) ([]b.OneCandleStick, error) {
+	candles, err := source.GetCandleStickCtx(ctx, market.Base, market.Quote, interval)
+	if err != nil {
+		return nil, err
+	}
+	sortCandles(candles)
+	return candles, nil
+}
+
+// LoadFile 은 파일에서 캔들을 불러옵니다. 확장자가 .csv 이면 ReadCSV 형식, 아니면 candlestick API 응답 (JSON) 형식으로 읽습니다.
+func LoadFile(path string) ([]b.OneCandleStick, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ReadCSV(file)
+	}
+	return ReadJSON(file)
+}
+
+// ReadJSON 은 candlestick API 응답 ({"status":"0000","data":[[time, open, close, high, low, volume], ...]}) 을 읽습니다.
+func ReadJSON(reader io.Reader) ([]b.OneCandleStick, error) {
+	var raw b.RawCandleStick
+	if err := json.NewDecoder(reader).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	candles := make([]b.OneCandleStick, 0, len(raw.Data))
+	for _, data := range raw.Data {
+		if len(data) < 6 {
+			continue
+		}
+		fields := make([]string, 6)
+		for index := range fields {
+			fields[index] = fmt.Sprint(data[index])
+			if value, ok := data[index].(float64); ok {
+				fields[index] = strconv.FormatFloat(value, 'f', -1, 64)
+			}
+		}
+		candle, err := parseCandle(fields)
+		if err != nil {
+			return nil, err
+		}
+		candles = append(candles, candle)
+	}
+	sortCandles(candles)
+	return candles, nil
+}
+
+// ReadCSV 는 time, open, close, high, low, volume 순서의 CSV 를 읽습니다. (API 와 같은 순서)
+// time 은 unix millisecond 또는 RFC3339 이며, 첫 줄이 숫자로 시작하지 않으면 header 로 보고 건너뜁니다.
+func ReadCSV(reader io.Reader) ([]b.OneCandleStick, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	candles := make([]b.OneCandleStick, 0, len(records))
+	for index, record := range records {
+		if index == 0 && len(record) > 0 && !startsWithDigit(record[0]) {
+			continue
+		}
+		if len(record) < 6 {
+			return nil, fmt.Errorf("%w: %d 번째 줄의 값이 6개보다 적습니다.", b.ErrInvalidParameter, index+1)
+		}
+		candle, err := parseCandle(record)
+		if err != nil {
+			return nil, fmt.Errorf("%d 번째 줄: %w", index+1, err)
+		}
+		candles = append(candles, candle)
+	}
+	sortCandles(candles)
+	return candles, nil
+}
+
+// WriteCSV 는 ReadCSV 로 다시 읽을 수 있는 형식으로 캔들을 저장합니다.
+func WriteCSV(writer io.Writer, candles []b.OneCandleStick) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write([]string{"time", "open", "close", "high", "low", "volume"}); err != nil {
+		return err
+	}
+	for _, data := range candles {
+		record := []string{
+			strconv.FormatInt(data.Time.UnixNano()/int64(time.Millisecond), 10),
+			data.OpeningPrice.String(),
+			data.ClosingPrice.String(),
+			data.HighPrice.String(),
+			data.LowPrice.String(),
+			data.UnitsTraded.String(),
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func parseCandle(fields []string) (b.OneCandleStick, error) {
+	candle := b.OneCandleStick{}
+
+	rawTime := strings.TrimSpace(fields[0])
+	if milli, err := strconv.ParseInt(rawTime, 10, 64); err == nil {
+		candle.Time = time.Unix(0, milli*int64(time.Millisecond))
+	} else if parsed, err := time.Parse(time.RFC3339, rawTime); err == nil {
+		candle.Time = parsed
+	} else {
+		return b.OneCandleStick{}, fmt.Errorf("%w: 시간 형식이 잘못되었습니다. (%q)", b.ErrInvalidParameter, rawTime)
+	}
+
+	values := make([]b.Decimal, 5)
+	for index := range values {
+		value, err := b.ParseDecimal(strings.TrimSpace(fields[index+1]))
+		if err != nil {
+			return b.OneCandleStick{}, fmt.Errorf("%w: 숫자 형식이 잘못되었습니다. (%q)", b.ErrInvalidParameter, fields[index+1])
+		}
+		values[index] = value
+	}
+	candle.OpeningPrice = values[0]
+	candle.ClosingPrice = values[1]
+	candle.HighPrice = values[2]
+	candle.LowPrice = values[3]
+	candle.UnitsTraded = values[4]
+	return candle, nil
+}
+
+func startsWithDigit(raw string) bool {
+	raw = strings.TrimSpace(raw)
+	return raw != "" && raw[0] >= '0' && raw[0] <= '9'
+}
+
+func sortCandles(candles []b.OneCandleStick) {
+	sort.SliceStable(candles, func(i, j int) bool { return candles[i].Time.Before(candles[j].Time) })
+}
@@ -0,0 +1,192 @@
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// EquityPoint 는 봉 하나의 종가 기준 총 자산입니다.
+type EquityPoint struct {
+	Time   time.Time
+	Equity b.Decimal
+}
+
+// Trade 는 매수 후 매도까지의 왕복 거래 하나입니다. 매수 체결은 먼저 산 것부터 (FIFO) 매도에 대응됩니다.
+// PnL 은 양쪽 수수료를 뺀 손익이고, Return 은 수수료를 포함한 매수 금액 대비 PnL 입니다.
+type Trade struct {
+	EntryTime  time.Time
+	ExitTime   time.Time
+	Units      b.Decimal
+	EntryPrice b.Decimal
+	ExitPrice  b.Decimal
+	PnL        b.Decimal
+	Return     float64
+}
+
+// Report 는 백테스트 결과입니다. 비율은 모두 소수입니다. (e.g. 0.1 = 10%)
+type Report struct {
+	Market      b.Market
+	Start       time.Time
+	End         time.Time
+	InitialCash b.Decimal
+	FinalEquity b.Decimal
+	Cash        b.Decimal
+	Position    b.Decimal
+	Fees        b.Decimal
+
+	Equity []EquityPoint
+	Fills  []Fill
+	Trades []Trade
+
+	TotalReturn float64
+	MaxDrawdown float64
+	Sharpe      float64
+	WinRate     float64
+}
+
+func newReport(config Config, broker *Broker) *Report {
+	report := Report{}
+	report.Market = config.Market
+	report.Start = broker.equity[0].Time
+	report.End = broker.equity[len(broker.equity)-1].Time
+	report.InitialCash = config.InitialCash
+	report.FinalEquity = broker.equity[len(broker.equity)-1].Equity
+	report.Cash = broker.cash
+	report.Position = broker.position
+	report.Equity = broker.equity
+	report.Fills = broker.fills
+	report.Trades = roundTrips(broker.fills)
+
+	for _, data := range report.Fills {
+		report.Fees = report.Fees.Add(data.Fee)
+	}
+	report.TotalReturn = report.FinalEquity.Float64()/report.InitialCash.Float64() - 1
+	report.MaxDrawdown = maxDrawdown(report.Equity)
+	report.Sharpe = sharpe(report.Equity, config.PeriodsPerYear)
+	if len(report.Trades) > 0 {
+		wins := 0
+		for _, data := range report.Trades {
+			if data.PnL.Sign() > 0 {
+				wins++
+			}
+		}
+		report.WinRate = float64(wins) / float64(len(report.Trades))
+	}
+	return &report
+}
+
+// lot 은 아직 매도되지 않은 매수 체결입니다.
+type lot struct {
+	time  time.Time
+	units b.Decimal
+	price b.Decimal
+	fee   b.Decimal
+}
+
+// roundTrips 는 체결 내역을 FIFO 로 짝지어 왕복 거래 목록을 만듭니다. 끝까지 보유한 수량은 포함하지 않습니다.
+func roundTrips(fills []Fill) []Trade {
+	var lots []lot
+	var result []Trade
+	for _, data := range fills {
+		if data.Side == b.Bid {
+			lots = append(lots, lot{time: data.Time, units: data.Units, price: data.Price, fee: data.Fee})
+			continue
+		}
+
+		remain := data.Units
+		for remain.Sign() > 0 && len(lots) > 0 {
+			entry := &lots[0]
+			units := b.MinDecimal(remain, entry.units)
+
+			// 수수료는 체결 수량 비율로 나눔
+			entryFee := entry.fee.Mul(units).Div(entry.units, 8)
+			exitFee := data.Fee.Mul(units).Div(data.Units, 8)
+			cost := entry.price.Mul(units).Add(entryFee)
+			pnl := data.Price.Mul(units).Sub(exitFee).Sub(cost)
+
+			trade := Trade{EntryTime: entry.time, ExitTime: data.Time, Units: units, EntryPrice: entry.price, ExitPrice: data.Price, PnL: pnl}
+			if cost.Sign() > 0 {
+				trade.Return = pnl.Float64() / cost.Float64()
+			}
+			result = append(result, trade)
+
+			entry.fee = entry.fee.Sub(entryFee)
+			entry.units = entry.units.Sub(units)
+			remain = remain.Sub(units)
+			if entry.units.Sign() <= 0 {
+				lots = lots[1:]
+			}
+		}
+	}
+	return result
+}
+
+// maxDrawdown 은 직전 최고 평가금액 대비 가장 크게 떨어진 비율을 반환합니다.
+func maxDrawdown(equity []EquityPoint) float64 {
+	peak, result := 0.0, 0.0
+	for _, data := range equity {
+		value := data.Equity.Float64()
+		if value > peak {
+			peak = value
+		}
+		if peak > 0 && (peak-value)/peak > result {
+			result = (peak - value) / peak
+		}
+	}
+	return result
+}
+
+// sharpe 는 봉 단위 수익률의 평균 / 표준편차를 periodsPerYear 로 연율화합니다. (무위험 수익률 0)
+func sharpe(equity []EquityPoint, periodsPerYear float64) float64 {
+	if len(equity) < 3 {
+		return 0
+	}
+	returns := make([]float64, 0, len(equity)-1)
+	for index := 1; index < len(equity); index++ {
+		previous := equity[index-1].Equity.Float64()
+		if previous <= 0 {
+			continue
+		}
+		returns = append(returns, equity[index].Equity.Float64()/previous-1)
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+
+	mean := 0.0
+	for _, data := range returns {
+		mean += data
+	}
+	mean /= float64(len(returns))
+	variance := 0.0
+	for _, data := range returns {
+		variance += (data - mean) * (data - mean)
+	}
+	variance /= float64(len(returns) - 1)
+	if variance == 0 {
+		return 0
+	}
+	return mean / math.Sqrt(variance) * math.Sqrt(periodsPerYear)
+}
+
+// String 은 보고서 요약을 반환합니다.
+func (r *Report) String() string {
+	var builder strings.Builder
+	timeForm := "2006-01-02 15:04"
+	if r.Market.Valid() {
+		fmt.Fprintf(&builder, "Market        %s\n", r.Market)
+	}
+	fmt.Fprintf(&builder, "Period        %s ~ %s (%d bars)\n", r.Start.Format(timeForm), r.End.Format(timeForm), len(r.Equity))
+	fmt.Fprintf(&builder, "Initial cash  %s\n", r.InitialCash)
+	fmt.Fprintf(&builder, "Final equity  %s\n", r.FinalEquity.Round(2))
+	fmt.Fprintf(&builder, "Total return  %.2f%%\n", r.TotalReturn*100)
+	fmt.Fprintf(&builder, "Max drawdown  %.2f%%\n", r.MaxDrawdown*100)
+	fmt.Fprintf(&builder, "Sharpe        %.2f\n", r.Sharpe)
+	fmt.Fprintf(&builder, "Trades        %d (win rate %.2f%%)\n", len(r.Trades), r.WinRate*100)
+	fmt.Fprintf(&builder, "Fees          %s\n", r.Fees.Round(2))
+	return builder.String()
+}
@@ -0,0 +1,279 @@
+// Package bithumbmock 는 gobithumb.Exchange 의 mock 구현입니다.
+//
+// 각 method 는 같은 이름에 Func 가 붙은 field 를 호출합니다. field 가 nil 이면 zero value 와 ErrNotMocked 를 반환합니다.
+// 모든 호출은 기록되므로, Calls 로 어떤 인자로 호출되었는지 확인할 수 있습니다.
+//
+//	mock := &bithumbmock.Exchange{}
+//	mock.PlaceOrderCtxFunc = func(ctx context.Context, oc, pc gobithumb.Currency, amount, price gobithumb.Decimal, side gobithumb.OrderSide) (string, error) {
+//		return "C0000000000000000001", nil
+//	}
+//	strategy := NewStrategy(mock)
+package bithumbmock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+var ErrNotMocked = errors.New("bithumbmock: method not mocked")
+
+// Call 은 mock 에 들어온 호출 하나입니다. Args 에는 ctx 를 뺀 인자가 순서대로 들어있습니다.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+type Exchange struct {
+	GetTradableCoinListCtxFunc   func(ctx context.Context) ([]b.Currency, error)
+	GetTradableMarketsCtxFunc    func(ctx context.Context, paymentCurrency b.Currency) ([]b.Market, error)
+	GetTickerCtxFunc             func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) (map[b.Currency]b.Ticker, time.Time, error)
+	GetOrderbookCtxFunc          func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) (map[b.Currency]b.Orderbook, time.Time, error)
+	GetTransactionHistoryCtxFunc func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) ([]b.OneTransaction, error)
+	GetAssetsStatusCtxFunc       func(ctx context.Context, orderCurrency b.Currency) (bool, bool, error)
+	GetBTCICtxFunc               func(ctx context.Context) (b.BTCI, time.Time, error)
+	GetCandleStickCtxFunc        func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, chartInterval b.TimeInterval) ([]b.OneCandleStick, error)
+
+	GetAccountCtxFunc       func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) (b.Account, error)
+	GetBalanceCtxFunc       func(ctx context.Context, orderCurrency b.Currency) (map[b.Currency]*b.Balance, error)
+	GetMarketBalanceCtxFunc func(ctx context.Context, market b.Market) (b.Balance, b.Balance, error)
+	GetUserTickerCtxFunc    func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) (b.UserTicker, error)
+	GetOrderCtxFunc         func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, count int, date ...time.Time) ([]b.Order, error)
+	GetOrderDetailCtxFunc   func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, orderId string) (b.OrderDetail, error)
+	GetTransactionsCtxFunc  func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, search b.SearchType, offsetCount ...int) ([]b.Transaction, error)
+
+	PlaceOrderCtxFunc  func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, amount b.Decimal, price b.Decimal, side b.OrderSide) (string, error)
+	CancelOrderCtxFunc func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, orderId string, side b.OrderSide) error
+	MarketBuyCtxFunc   func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, amount b.Decimal) (string, error)
+	MarketSellCtxFunc  func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, amount b.Decimal) (string, error)
+	StopLimitCtxFunc   func(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, watchPrice b.Decimal, price b.Decimal, amount b.Decimal, side b.OrderSide) (string, error)
+
+	GetWalletAddressCtxFunc func(ctx context.Context, orderCurrency b.Currency) (string, error)
+	WithDrawCoinCtxFunc     func(ctx context.Context, orderCurrency b.Currency, amount b.Decimal, address string, destination ...interface{}) error
+	WithdrawKRWCtxFunc      func(ctx context.Context, account string, price int) error
+
+	mutex sync.Mutex
+	calls []Call
+}
+
+var _ b.Exchange = (*Exchange)(nil)
+
+func (m *Exchange) record(method string, args ...interface{}) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+// Calls 는 method 로 들어온 호출을 순서대로 반환합니다. method 가 비어있으면 모든 호출을 반환합니다.
+func (m *Exchange) Calls(method string) []Call {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var result []Call
+	for _, call := range m.calls {
+		if method == "" || call.Method == method {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+func (m *Exchange) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.calls = nil
+}
+
+//==============================MARKET DATA======================================
+
+func (m *Exchange) GetTradableCoinListCtx(ctx context.Context) ([]b.Currency, error) {
+	m.record("GetTradableCoinListCtx")
+	if m.GetTradableCoinListCtxFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetTradableCoinListCtxFunc(ctx)
+}
+
+func (m *Exchange) GetTradableMarketsCtx(ctx context.Context, paymentCurrency b.Currency) ([]b.Market, error) {
+	m.record("GetTradableMarketsCtx", paymentCurrency)
+	if m.GetTradableMarketsCtxFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetTradableMarketsCtxFunc(ctx, paymentCurrency)
+}
+
+func (m *Exchange) GetTickerCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) (map[b.Currency]b.Ticker, time.Time, error) {
+	m.record("GetTickerCtx", orderCurrency, paymentCurrency)
+	if m.GetTickerCtxFunc == nil {
+		return nil, time.Time{}, ErrNotMocked
+	}
+	return m.GetTickerCtxFunc(ctx, orderCurrency, paymentCurrency)
+}
+
+func (m *Exchange) GetOrderbookCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) (map[b.Currency]b.Orderbook, time.Time, error) {
+	m.record("GetOrderbookCtx", orderCurrency, paymentCurrency)
+	if m.GetOrderbookCtxFunc == nil {
+		return nil, time.Time{}, ErrNotMocked
+	}
+	return m.GetOrderbookCtxFunc(ctx, orderCurrency, paymentCurrency)
+}
+
+func (m *Exchange) GetTransactionHistoryCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) ([]b.OneTransaction, error) {
+	m.record("GetTransactionHistoryCtx", orderCurrency, paymentCurrency)
+	if m.GetTransactionHistoryCtxFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetTransactionHistoryCtxFunc(ctx, orderCurrency, paymentCurrency)
+}
+
+func (m *Exchange) GetAssetsStatusCtx(ctx context.Context, orderCurrency b.Currency) (bool, bool, error) {
+	m.record("GetAssetsStatusCtx", orderCurrency)
+	if m.GetAssetsStatusCtxFunc == nil {
+		return false, false, ErrNotMocked
+	}
+	return m.GetAssetsStatusCtxFunc(ctx, orderCurrency)
+}
+
+func (m *Exchange) GetBTCICtx(ctx context.Context) (b.BTCI, time.Time, error) {
+	m.record("GetBTCICtx")
+	if m.GetBTCICtxFunc == nil {
+		return b.BTCI{}, time.Time{}, ErrNotMocked
+	}
+	return m.GetBTCICtxFunc(ctx)
+}
+
+func (m *Exchange) GetCandleStickCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, chartInterval b.TimeInterval) ([]b.OneCandleStick, error) {
+	m.record("GetCandleStickCtx", orderCurrency, paymentCurrency, chartInterval)
+	if m.GetCandleStickCtxFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetCandleStickCtxFunc(ctx, orderCurrency, paymentCurrency, chartInterval)
+}
+
+//==============================ACCOUNT INFO======================================
+
+func (m *Exchange) GetAccountCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) (b.Account, error) {
+	m.record("GetAccountCtx", orderCurrency, paymentCurrency)
+	if m.GetAccountCtxFunc == nil {
+		return b.Account{}, ErrNotMocked
+	}
+	return m.GetAccountCtxFunc(ctx, orderCurrency, paymentCurrency)
+}
+
+func (m *Exchange) GetBalanceCtx(ctx context.Context, orderCurrency b.Currency) (map[b.Currency]*b.Balance, error) {
+	m.record("GetBalanceCtx", orderCurrency)
+	if m.GetBalanceCtxFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetBalanceCtxFunc(ctx, orderCurrency)
+}
+
+func (m *Exchange) GetMarketBalanceCtx(ctx context.Context, market b.Market) (b.Balance, b.Balance, error) {
+	m.record("GetMarketBalanceCtx", market)
+	if m.GetMarketBalanceCtxFunc == nil {
+		return b.Balance{}, b.Balance{}, ErrNotMocked
+	}
+	return m.GetMarketBalanceCtxFunc(ctx, market)
+}
+
+func (m *Exchange) GetUserTickerCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency) (b.UserTicker, error) {
+	m.record("GetUserTickerCtx", orderCurrency, paymentCurrency)
+	if m.GetUserTickerCtxFunc == nil {
+		return b.UserTicker{}, ErrNotMocked
+	}
+	return m.GetUserTickerCtxFunc(ctx, orderCurrency, paymentCurrency)
+}
+
+func (m *Exchange) GetOrderCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, count int, date ...time.Time) ([]b.Order, error) {
+	m.record("GetOrderCtx", orderCurrency, paymentCurrency, count, date)
+	if m.GetOrderCtxFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetOrderCtxFunc(ctx, orderCurrency, paymentCurrency, count, date...)
+}
+
+func (m *Exchange) GetOrderDetailCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, orderId string) (b.OrderDetail, error) {
+	m.record("GetOrderDetailCtx", orderCurrency, paymentCurrency, orderId)
+	if m.GetOrderDetailCtxFunc == nil {
+		return b.OrderDetail{}, ErrNotMocked
+	}
+	return m.GetOrderDetailCtxFunc(ctx, orderCurrency, paymentCurrency, orderId)
+}
+
+func (m *Exchange) GetTransactionsCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, search b.SearchType, offsetCount ...int) ([]b.Transaction, error) {
+	m.record("GetTransactionsCtx", orderCurrency, paymentCurrency, search, offsetCount)
+	if m.GetTransactionsCtxFunc == nil {
+		return nil, ErrNotMocked
+	}
+	return m.GetTransactionsCtxFunc(ctx, orderCurrency, paymentCurrency, search, offsetCount...)
+}
+
+//==============================TRADING======================================
+
+func (m *Exchange) PlaceOrderCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, amount b.Decimal, price b.Decimal, side b.OrderSide) (string, error) {
+	m.record("PlaceOrderCtx", orderCurrency, paymentCurrency, amount, price, side)
+	if m.PlaceOrderCtxFunc == nil {
+		return "", ErrNotMocked
+	}
+	return m.PlaceOrderCtxFunc(ctx, orderCurrency, paymentCurrency, amount, price, side)
+}
+
+func (m *Exchange) CancelOrderCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, orderId string, side b.OrderSide) error {
+	m.record("CancelOrderCtx", orderCurrency, paymentCurrency, orderId, side)
+	if m.CancelOrderCtxFunc == nil {
+		return ErrNotMocked
+	}
+	return m.CancelOrderCtxFunc(ctx, orderCurrency, paymentCurrency, orderId, side)
+}
+
+func (m *Exchange) MarketBuyCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, amount b.Decimal) (string, error) {
+	m.record("MarketBuyCtx", orderCurrency, paymentCurrency, amount)
+	if m.MarketBuyCtxFunc == nil {
+		return "", ErrNotMocked
+	}
+	return m.MarketBuyCtxFunc(ctx, orderCurrency, paymentCurrency, amount)
+}
+
+func (m *Exchange) MarketSellCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, amount b.Decimal) (string, error) {
+	m.record("MarketSellCtx", orderCurrency, paymentCurrency, amount)
+	if m.MarketSellCtxFunc == nil {
+		return "", ErrNotMocked
+	}
+	return m.MarketSellCtxFunc(ctx, orderCurrency, paymentCurrency, amount)
+}
+
+func (m *Exchange) StopLimitCtx(ctx context.Context, orderCurrency b.Currency, paymentCurrency b.Currency, watchPrice b.Decimal, price b.Decimal, amount b.Decimal, side b.OrderSide) (string, error) {
+	m.record("StopLimitCtx", orderCurrency, paymentCurrency, watchPrice, price, amount, side)
+	if m.StopLimitCtxFunc == nil {
+		return "", ErrNotMocked
+	}
+	return m.StopLimitCtxFunc(ctx, orderCurrency, paymentCurrency, watchPrice, price, amount, side)
+}
+
+//==============================WALLET======================================
+
+func (m *Exchange) GetWalletAddressCtx(ctx context.Context, orderCurrency b.Currency) (string, error) {
+	m.record("GetWalletAddressCtx", orderCurrency)
+	if m.GetWalletAddressCtxFunc == nil {
+		return "", ErrNotMocked
+	}
+	return m.GetWalletAddressCtxFunc(ctx, orderCurrency)
+}
+
+func (m *Exchange) WithDrawCoinCtx(ctx context.Context, orderCurrency b.Currency, amount b.Decimal, address string, destination ...interface{}) error {
+	m.record("WithDrawCoinCtx", orderCurrency, amount, address, destination)
+	if m.WithDrawCoinCtxFunc == nil {
+		return ErrNotMocked
+	}
+	return m.WithDrawCoinCtxFunc(ctx, orderCurrency, amount, address, destination...)
+}
+
+func (m *Exchange) WithdrawKRWCtx(ctx context.Context, account string, price int) error {
+	m.record("WithdrawKRWCtx", account, price)
+	if m.WithdrawKRWCtxFunc == nil {
+		return ErrNotMocked
+	}
+	return m.WithdrawKRWCtxFunc(ctx, account, price)
+}
@@ -0,0 +1,198 @@
+package bithumbtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+	"github.com/lutergs/gobithumb/bithumbtest"
+)
+
+const (
+	connectKey = "connect key"
+	secretKey  = "secret key"
+)
+
+func newClient(t *testing.T) (*bithumbtest.Server, *b.BithumbRequester) {
+	t.Helper()
+	server := bithumbtest.NewServer(connectKey, secretKey)
+	t.Cleanup(server.Close)
+	client := b.NewBithumb(connectKey, secretKey, b.WithBaseURL(server.URL), b.WithRetryPolicy(b.RetryPolicy{MaxAttempts: 1}))
+	return server, client
+}
+
+func TestGetTicker(t *testing.T) {
+	server, client := newClient(t)
+	server.SetLastPrice(b.BTC, b.KRW, b.MustDecimal("50000000"))
+
+	tickers, date, err := client.GetTicker(b.BTC, b.KRW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if date.IsZero() {
+		t.Error("ticker date is zero")
+	}
+	ticker, ok := tickers[b.BTC]
+	if !ok {
+		t.Fatalf("ticker of btc not found: %v", tickers)
+	}
+	if !ticker.ClosingPrice.Equal(b.MustDecimal("50000000")) {
+		t.Errorf("ClosingPrice = %s, want 50000000", ticker.ClosingPrice)
+	}
+}
+
+func TestPlaceOrderAndGetOrderDetail(t *testing.T) {
+	server, client := newClient(t)
+	server.SetBalance(b.KRW, b.MustDecimal("1000000"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Ask, b.MustDecimal("50000000"), b.MustDecimal("0.01"))
+
+	// 호가와 만나는 주문은 바로 체결
+	filledId, err := client.PlaceOrder(b.BTC, b.KRW, b.MustDecimal("0.01"), b.MustDecimal("50000000"), b.Bid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail, err := client.GetOrderDetail(b.BTC, b.KRW, filledId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.OrderStatus != b.OrderCompleted || detail.Type != b.Bid {
+		t.Errorf("detail = %s %s, want completed bid", detail.OrderStatus, detail.Type)
+	}
+	filled := b.Decimal{}
+	for _, data := range detail.Contract {
+		filled = filled.Add(data.Units)
+		if !data.Price.Equal(b.MustDecimal("50000000")) {
+			t.Errorf("contract price = %s, want 50000000", data.Price)
+		}
+	}
+	if !filled.Equal(b.MustDecimal("0.01")) {
+		t.Errorf("filled = %s, want 0.01", filled)
+	}
+	if total, _ := server.Balance(b.BTC); !total.Equal(b.MustDecimal("0.01")) {
+		t.Errorf("btc balance = %s, want 0.01", total)
+	}
+
+	// 호가가 없는 가격의 주문은 대기하며, 주문 금액만큼 잔고가 묶임
+	pendingId, err := client.PlaceOrder(b.BTC, b.KRW, b.MustDecimal("0.005"), b.MustDecimal("40000000"), b.Bid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	detail, err = client.GetOrderDetail(b.BTC, b.KRW, pendingId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.OrderStatus != b.OrderPending || len(detail.Contract) != 0 {
+		t.Errorf("detail = %s with %d contracts, want pending without contracts", detail.OrderStatus, len(detail.Contract))
+	}
+	if !detail.OrderPrice.Equal(b.MustDecimal("40000000")) || !detail.OrderQty.Equal(b.MustDecimal("0.005")) {
+		t.Errorf("detail = %s x %s, want 40000000 x 0.005", detail.OrderPrice, detail.OrderQty)
+	}
+	if _, inUse := server.Balance(b.KRW); inUse.Sign() <= 0 {
+		t.Errorf("krw in use = %s, want locked balance", inUse)
+	}
+}
+
+func TestInjectedErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		injection bithumbtest.Injection
+		want      error
+	}{
+		{"insufficient balance", bithumbtest.Injection{Status: "5600", Message: "주문가능한 수량이 부족합니다."}, b.ErrInsufficientBalance},
+		{"invalid api key", bithumbtest.Injection{Status: "5300", Message: "Invalid Apikey"}, b.ErrInvalidAPIKey},
+		{"invalid parameter", bithumbtest.Injection{Status: "5500", Message: "Invalid Parameter"}, b.ErrInvalidParameter},
+		{"rate limited", bithumbtest.Injection{HTTPStatus: http.StatusTooManyRequests}, b.ErrRateLimited},
+		{"maintenance", bithumbtest.Injection{HTTPStatus: http.StatusServiceUnavailable}, b.ErrMaintenance},
+		{"temporary", bithumbtest.Injection{Status: "5900", Message: "Unknown Error"}, b.ErrTemporary},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, client := newClient(t)
+			server.SetBalance(b.KRW, b.MustDecimal("1000000"))
+			test.injection.Endpoint = "/trade/place"
+			test.injection.Times = 1
+			server.Inject(test.injection)
+
+			_, err := client.PlaceOrder(b.BTC, b.KRW, b.MustDecimal("0.001"), b.MustDecimal("50000000"), b.Bid)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("error = %v, want %v", err, test.want)
+			}
+			var apiErr *b.APIError
+			if !errors.As(err, &apiErr) || apiErr.Endpoint != "/trade/place" {
+				t.Errorf("error = %#v, want *APIError of /trade/place", err)
+			}
+
+			// Times 만큼만 오류를 내고 이후에는 정상 처리
+			if _, err := client.PlaceOrder(b.BTC, b.KRW, b.MustDecimal("0.001"), b.MustDecimal("50000000"), b.Bid); err != nil {
+				t.Errorf("after injection: %v", err)
+			}
+		})
+	}
+}
+
+func TestOrderNotFound(t *testing.T) {
+	_, client := newClient(t)
+
+	_, err := client.GetOrderDetail(b.BTC, b.KRW, "C0000000000000000")
+	if !errors.Is(err, b.ErrOrderNotFound) {
+		t.Errorf("GetOrderDetail error = %v, want ErrOrderNotFound", err)
+	}
+	err = client.CancelOrder(b.BTC, b.KRW, "C0000000000000000", b.Bid)
+	if !errors.Is(err, b.ErrOrderNotFound) {
+		t.Errorf("CancelOrder error = %v, want ErrOrderNotFound", err)
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	server, client := newClient(t)
+	server.SetLastPrice(b.BTC, b.KRW, b.MustDecimal("50000000"))
+	server.Inject(bithumbtest.Injection{Endpoint: "/public/ticker", Disconnect: true, Times: 1})
+
+	_, _, err := client.GetTicker(b.BTC, b.KRW)
+	var requestErr *b.RequestError
+	if !errors.As(err, &requestErr) {
+		t.Fatalf("error = %v, want *RequestError", err)
+	}
+	var apiErr *b.APIError
+	if errors.As(err, &apiErr) {
+		t.Errorf("error = %v, want no *APIError", err)
+	}
+}
+
+func TestRetryContextCancel(t *testing.T) {
+	server := bithumbtest.NewServer(connectKey, secretKey)
+	t.Cleanup(server.Close)
+	server.Inject(bithumbtest.Injection{Endpoint: "/public/ticker", HTTPStatus: http.StatusServiceUnavailable})
+	client := b.NewBithumb(connectKey, secretKey, b.WithBaseURL(server.URL), b.WithLogger(b.NopLogger),
+		b.WithRetryPolicy(b.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second}))
+
+	// 재시도를 기다리는 중에 ctx 가 끝나면 마지막 오류 대신 ctx 의 오류를 반환
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, _, err := client.GetTickerCtx(ctx, b.BTC, b.KRW)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	var requestErr *b.RequestError
+	if !errors.As(err, &requestErr) || requestErr.Endpoint != "/public/ticker" {
+		t.Errorf("error = %#v, want *RequestError of /public/ticker", err)
+	}
+	if requests := server.Requests("/public/ticker"); requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestNilHTTPClient(t *testing.T) {
+	server := bithumbtest.NewServer(connectKey, secretKey)
+	t.Cleanup(server.Close)
+	server.SetLastPrice(b.BTC, b.KRW, b.MustDecimal("50000000"))
+
+	// nil 은 무시하고 기본 http.Client 를 사용
+	client := b.NewBithumb(connectKey, secretKey, b.WithBaseURL(server.URL), b.WithHTTPClient(nil))
+	if _, _, err := client.GetTicker(b.BTC, b.KRW); err != nil {
+		t.Fatal(err)
+	}
+}
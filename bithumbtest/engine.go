@@ -0,0 +1,281 @@
+package bithumbtest
+
+import (
+	"sort"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// 가짜 서버의 주문 체결 엔진입니다. 가격 우선, 시간 우선으로 체결하며 체결 가격은 먼저 들어와 있던 주문의 가격입니다.
+
+type contract struct {
+	date  time.Time
+	price b.Decimal
+	units b.Decimal
+	fee   b.Decimal
+	total b.Decimal
+}
+
+type order struct {
+	id              string
+	user            bool
+	orderCurrency   b.Currency
+	paymentCurrency b.Currency
+	side            b.OrderSide
+	price           b.Decimal
+	watchPrice      b.Decimal
+	units           b.Decimal
+	remaining       b.Decimal
+	locked          b.Decimal // 아직 묶여있는 잔고 (bid : payment currency, ask : order currency)
+	created         time.Time
+	status          b.OrderStatus
+	cancelDate      time.Time
+	cancelType      b.CancelType
+	contracts       []contract
+}
+
+type trade struct {
+	date  time.Time
+	side  b.OrderSide // taker 의 방향
+	price b.Decimal
+	units b.Decimal
+	total b.Decimal
+}
+
+type userTransaction struct {
+	search          string
+	date            time.Time
+	orderCurrency   b.Currency
+	paymentCurrency b.Currency
+	units           b.Decimal
+	price           b.Decimal
+	amount          b.Decimal
+	fee             b.Decimal
+	feeCurrency     b.Currency
+	orderBalance    b.Decimal
+	paymentBalance  b.Decimal
+}
+
+type balance struct {
+	total b.Decimal
+	inUse b.Decimal
+}
+
+func (bl *balance) available() b.Decimal {
+	return bl.total.Sub(bl.inUse)
+}
+
+type book struct {
+	orderCurrency   b.Currency
+	paymentCurrency b.Currency
+
+	bids  []*order // 가격 내림차순, 같은 가격은 먼저 들어온 순서
+	asks  []*order // 가격 오름차순, 같은 가격은 먼저 들어온 순서
+	stops []*order
+
+	lastPrice b.Decimal
+	trades    []trade
+	candles   map[b.TimeInterval][]b.OneCandleStick
+}
+
+func newBook(orderCurrency b.Currency, paymentCurrency b.Currency) *book {
+	return &book{orderCurrency: orderCurrency, paymentCurrency: paymentCurrency, candles: make(map[b.TimeInterval][]b.OneCandleStick)}
+}
+
+func (bk *book) rest(o *order) {
+	if o.side == b.Bid {
+		index := sort.Search(len(bk.bids), func(i int) bool { return bk.bids[i].price.LessThan(o.price) })
+		bk.bids = append(bk.bids, nil)
+		copy(bk.bids[index+1:], bk.bids[index:])
+		bk.bids[index] = o
+	} else {
+		index := sort.Search(len(bk.asks), func(i int) bool { return bk.asks[i].price.GreaterThan(o.price) })
+		bk.asks = append(bk.asks, nil)
+		copy(bk.asks[index+1:], bk.asks[index:])
+		bk.asks[index] = o
+	}
+}
+
+func (bk *book) remove(o *order) {
+	levels := &bk.asks
+	if o.side == b.Bid {
+		levels = &bk.bids
+	}
+	for index, data := range *levels {
+		if data == o {
+			*levels = append((*levels)[:index], (*levels)[index+1:]...)
+			return
+		}
+	}
+	for index, data := range bk.stops {
+		if data == o {
+			bk.stops = append(bk.stops[:index], bk.stops[index+1:]...)
+			return
+		}
+	}
+}
+
+// levelQuantity 는 한 가격대의 남은 수량 합계입니다.
+func (bk *book) levelQuantity(side b.OrderSide, price b.Decimal) b.Decimal {
+	levels := bk.asks
+	if side == b.Bid {
+		levels = bk.bids
+	}
+	total := b.Decimal{}
+	for _, data := range levels {
+		if data.price.Equal(price) {
+			total = total.Add(data.remaining)
+		}
+	}
+	return total
+}
+
+// aggregate 는 같은 가격의 주문을 합쳐 호가 목록을 만듭니다.
+func aggregate(levels []*order) []b.Bidask {
+	var result []b.Bidask
+	for _, data := range levels {
+		if len(result) > 0 && result[len(result)-1].Price.Equal(data.price) {
+			result[len(result)-1].Quantity = result[len(result)-1].Quantity.Add(data.remaining)
+			continue
+		}
+		result = append(result, b.Bidask{Price: data.price, Quantity: data.remaining})
+	}
+	return result
+}
+
+// fill 은 한 번의 체결을 기록하고 양쪽 주문의 잔고를 정리합니다.
+func (s *Server) fill(bk *book, taker *order, maker *order, units b.Decimal, now time.Time) {
+	price := maker.price
+	total := price.Mul(units)
+
+	for _, data := range []*order{taker, maker} {
+		data.remaining = data.remaining.Sub(units)
+		fee := b.Decimal{}
+		if data.user {
+			fee = total.Mul(s.tradeFee)
+			s.settle(data, price, units, total, fee, now)
+		}
+		data.contracts = append(data.contracts, contract{date: now, price: price, units: units, fee: fee, total: total})
+		if data.remaining.Sign() <= 0 {
+			data.status = b.OrderCompleted
+		}
+	}
+
+	bk.lastPrice = price
+	bk.trades = append(bk.trades, trade{date: now, side: taker.side, price: price, units: units, total: total})
+	s.publishTrade(bk, taker.side, price, units, total, now)
+}
+
+// settle 은 사용자 주문의 체결 결과를 잔고에 반영합니다. 수수료는 payment currency 로 받습니다.
+func (s *Server) settle(o *order, price b.Decimal, units b.Decimal, total b.Decimal, fee b.Decimal, now time.Time) {
+	coin := s.balanceOf(o.orderCurrency)
+	payment := s.balanceOf(o.paymentCurrency)
+
+	search := "1"
+	if o.side == b.Bid {
+		release := total.Add(fee)
+		if o.price.Sign() > 0 {
+			release = o.price.Mul(units).Mul(s.tradeFee.Add(b.NewDecimalFromInt(1)))
+		}
+		release = b.MinDecimal(release, o.locked)
+		o.locked = o.locked.Sub(release)
+		payment.inUse = payment.inUse.Sub(release)
+		payment.total = payment.total.Sub(total).Sub(fee)
+		coin.total = coin.total.Add(units)
+	} else {
+		search = "2"
+		release := b.MinDecimal(units, o.locked)
+		o.locked = o.locked.Sub(release)
+		coin.inUse = coin.inUse.Sub(release)
+		coin.total = coin.total.Sub(units)
+		payment.total = payment.total.Add(total).Sub(fee)
+	}
+
+	s.transactions = append(s.transactions, userTransaction{
+		search:          search,
+		date:            now,
+		orderCurrency:   o.orderCurrency,
+		paymentCurrency: o.paymentCurrency,
+		units:           units,
+		price:           price,
+		amount:          total,
+		fee:             fee,
+		feeCurrency:     o.paymentCurrency,
+		orderBalance:    coin.total,
+		paymentBalance:  payment.total,
+	})
+}
+
+// match 는 taker 주문을 반대편 호가와 체결합니다. 지정가 주문이면 가격 조건을 지키고,
+// 시장가 주문(price 가 0)이면 수량이 모두 체결되거나 호가가 없어질 때까지 체결합니다.
+func (s *Server) match(bk *book, taker *order, now time.Time) {
+	for taker.remaining.Sign() > 0 {
+		var maker *order
+		if taker.side == b.Bid {
+			if len(bk.asks) == 0 || (taker.price.Sign() > 0 && bk.asks[0].price.GreaterThan(taker.price)) {
+				break
+			}
+			maker = bk.asks[0]
+		} else {
+			if len(bk.bids) == 0 || (taker.price.Sign() > 0 && bk.bids[0].price.LessThan(taker.price)) {
+				break
+			}
+			maker = bk.bids[0]
+		}
+
+		units := b.MinDecimal(taker.remaining, maker.remaining)
+		s.fill(bk, taker, maker, units, now)
+		if maker.remaining.Sign() <= 0 {
+			bk.remove(maker)
+		}
+		s.publishDepth(bk, maker.side, maker.price, now)
+	}
+	s.triggerStops(bk, now)
+}
+
+// triggerStops 는 마지막 체결가가 감시가격에 도달한 stop limit 주문을 지정가 주문으로 바꿉니다.
+func (s *Server) triggerStops(bk *book, now time.Time) {
+	if bk.lastPrice.IsZero() {
+		return
+	}
+	for index := 0; index < len(bk.stops); index++ {
+		stop := bk.stops[index]
+		reached := (stop.side == b.Ask && bk.lastPrice.Cmp(stop.watchPrice) <= 0) ||
+			(stop.side == b.Bid && bk.lastPrice.Cmp(stop.watchPrice) >= 0)
+		if !reached {
+			continue
+		}
+		bk.stops = append(bk.stops[:index], bk.stops[index+1:]...)
+		s.submit(bk, stop, now)
+		index = -1 // 체결로 가격이 바뀌었을 수 있으므로 처음부터 다시 확인
+	}
+}
+
+// submit 은 지정가 주문을 체결시키고 남은 수량을 호가에 올립니다.
+func (s *Server) submit(bk *book, o *order, now time.Time) {
+	s.match(bk, o, now)
+	if o.remaining.Sign() > 0 {
+		bk.rest(o)
+		s.publishDepth(bk, o.side, o.price, now)
+	}
+}
+
+// cancel 은 주문을 취소하고 묶여있던 잔고를 돌려줍니다.
+func (s *Server) cancel(bk *book, o *order, now time.Time) {
+	bk.remove(o)
+	if o.user && o.locked.Sign() > 0 {
+		if o.side == b.Bid {
+			payment := s.balanceOf(o.paymentCurrency)
+			payment.inUse = payment.inUse.Sub(o.locked)
+		} else {
+			coin := s.balanceOf(o.orderCurrency)
+			coin.inUse = coin.inUse.Sub(o.locked)
+		}
+		o.locked = b.Decimal{}
+	}
+	o.status = b.OrderCancel
+	o.cancelDate = now
+	o.cancelType = b.CancelByUser
+	s.publishDepth(bk, o.side, o.price, now)
+}
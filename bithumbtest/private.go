@@ -0,0 +1,426 @@
+package bithumbtest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+func (s *Server) servePrivate(request *http.Request) (map[string]interface{}, error) {
+	body, err := s.authenticate(request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch request.URL.Path {
+	case "/info/account":
+		return s.infoAccount(body)
+	case "/info/balance":
+		return s.infoBalance(body)
+	case "/info/wallet_address":
+		return map[string]interface{}{"data": map[string]interface{}{
+			"currency":       strings.ToUpper(body.Get("currency")),
+			"wallet_address": "bithumbtest-" + strings.ToLower(body.Get("currency")),
+		}}, nil
+	case "/info/ticker":
+		return s.infoTicker(body)
+	case "/info/orders":
+		return s.infoOrders(body)
+	case "/info/order_detail":
+		return s.infoOrderDetail(body)
+	case "/info/user_transactions":
+		return s.infoUserTransactions(body)
+	case "/trade/place":
+		return s.tradePlace(body)
+	case "/trade/cancel":
+		return s.tradeCancel(body)
+	case "/trade/market_buy":
+		return s.tradeMarket(body, b.Bid)
+	case "/trade/market_sell":
+		return s.tradeMarket(body, b.Ask)
+	case "/trade/stop_limit":
+		return s.tradeStopLimit(body)
+	case "/trade/btc_withdrawal":
+		return s.tradeWithdrawal(b.Currency(body.Get("order_currency")), body.Get("units"))
+	case "/trade/krw_withdrawal":
+		return s.tradeWithdrawal(b.KRW, body.Get("price"))
+	}
+	return nil, newStatusError("5100", "Bad Request")
+}
+
+type params interface {
+	Get(key string) string
+}
+
+func currencies(body params) (b.Currency, b.Currency) {
+	paymentCurrency := b.Currency(strings.ToLower(body.Get("payment_currency")))
+	if paymentCurrency == "" {
+		paymentCurrency = b.KRW
+	}
+	return b.Currency(strings.ToLower(body.Get("order_currency"))), paymentCurrency
+}
+
+func decimalParam(body params, key string) (b.Decimal, error) {
+	result, err := b.ParseDecimal(body.Get(key))
+	if err != nil || result.Sign() <= 0 {
+		return b.Decimal{}, newStatusError("5500", "Invalid Parameter")
+	}
+	return result, nil
+}
+
+//==============================INFO======================================
+
+func (s *Server) infoAccount(body params) (map[string]interface{}, error) {
+	orderCurrency, _ := currencies(body)
+	return map[string]interface{}{"data": map[string]interface{}{
+		"created":          milliString(s.created),
+		"account_id":       s.accountId,
+		"order_currency":   strings.ToUpper(string(orderCurrency)),
+		"payment_currency": strings.ToUpper(body.Get("payment_currency")),
+		"trade_fee":        s.tradeFee.String(),
+		"balance":          s.balanceOf(orderCurrency).available().String(),
+	}}, nil
+}
+
+func (s *Server) infoBalance(body params) (map[string]interface{}, error) {
+	currency := b.Currency(strings.ToLower(body.Get("currency")))
+	if currency == "" {
+		currency = b.BTC
+	}
+
+	data := make(map[string]interface{})
+	addBalance := func(coin b.Currency) {
+		bl := s.balanceOf(coin)
+		data["total_"+string(coin)] = bl.total.String()
+		data["in_use_"+string(coin)] = bl.inUse.String()
+		data["available_"+string(coin)] = bl.available().String()
+		if coin != b.KRW {
+			data["xcoin_last_"+string(coin)] = s.bookOf(coin, b.KRW).lastPrice.String()
+		}
+	}
+
+	if currency == b.ALL {
+		for coin := range s.balances {
+			addBalance(coin)
+		}
+		for _, bk := range s.books {
+			addBalance(bk.orderCurrency)
+		}
+	} else {
+		addBalance(currency)
+	}
+	addBalance(b.KRW)
+	return map[string]interface{}{"data": data}, nil
+}
+
+func (s *Server) infoTicker(body params) (map[string]interface{}, error) {
+	orderCurrency, paymentCurrency := currencies(body)
+	ticker := rawTicker(s.bookOf(orderCurrency, paymentCurrency))
+	return map[string]interface{}{"data": map[string]interface{}{
+		"order_currency":    strings.ToUpper(string(orderCurrency)),
+		"payment_currency":  strings.ToUpper(string(paymentCurrency)),
+		"opening_price":     ticker["opening_price"],
+		"closing_price":     ticker["closing_price"],
+		"min_price":         ticker["min_price"],
+		"max_price":         ticker["max_price"],
+		"average_price":     ticker["closing_price"],
+		"units_traded":      ticker["units_traded"],
+		"volume_1day":       ticker["units_traded_24H"],
+		"volume_7day":       ticker["units_traded_24H"],
+		"fluctate_24H":      ticker["fluctate_24H"],
+		"fluctate_rate_24H": ticker["fluctate_rate_24H"],
+	}}, nil
+}
+
+func (s *Server) infoOrders(body params) (map[string]interface{}, error) {
+	orderCurrency, paymentCurrency := currencies(body)
+	count, err := strconv.Atoi(body.Get("count"))
+	if err != nil || count < 1 {
+		count = 100
+	}
+	var after time.Time
+	if rawAfter := body.Get("after"); rawAfter != "" {
+		milli, _ := strconv.ParseInt(rawAfter, 10, 64)
+		after = time.Unix(0, milli*int64(time.Millisecond))
+	}
+
+	var data []interface{}
+	for _, o := range s.sortedOrders() {
+		if o.status != b.OrderPending || o.orderCurrency != orderCurrency || o.paymentCurrency != paymentCurrency || o.created.Before(after) {
+			continue
+		}
+		if body.Get("order_id") != "" && body.Get("order_id") != o.id {
+			continue
+		}
+		if body.Get("type") != "" && b.OrderSide(body.Get("type")) != o.side {
+			continue
+		}
+		data = append(data, map[string]interface{}{
+			"order_currency":   strings.ToUpper(string(o.orderCurrency)),
+			"payment_currency": strings.ToUpper(string(o.paymentCurrency)),
+			"order_id":         o.id,
+			"order_date":       microString(o.created),
+			"type":             string(o.side),
+			"watch_price":      o.watchPrice.String(),
+			"units":            o.units.String(),
+			"units_remaining":  o.remaining.String(),
+			"price":            o.price.String(),
+		})
+		if len(data) >= count {
+			break
+		}
+	}
+
+	// 실제 서버처럼, 진행중인 주문이 없으면 오류를 반환
+	if len(data) == 0 {
+		return nil, newStatusError("5600", "거래 진행중인 내역이 존재하지 않습니다.")
+	}
+	return map[string]interface{}{"data": data}, nil
+}
+
+func (s *Server) sortedOrders() []*order {
+	result := make([]*order, 0, len(s.orders))
+	for _, o := range s.orders {
+		result = append(result, o)
+	}
+	// id 는 생성 순서대로 증가함
+	sort.Slice(result, func(i, j int) bool { return result[i].id < result[j].id })
+	return result
+}
+
+func (s *Server) infoOrderDetail(body params) (map[string]interface{}, error) {
+	o, ok := s.orders[body.Get("order_id")]
+	if !ok {
+		return nil, newStatusError("5600", "거래 체결내역이 존재하지 않습니다.")
+	}
+
+	contracts := make([]interface{}, len(o.contracts))
+	for index, data := range o.contracts {
+		contracts[index] = map[string]interface{}{
+			"transaction_date": microString(data.date),
+			"price":            data.price.String(),
+			"units":            data.units.String(),
+			"fee_currency":     strings.ToUpper(string(o.paymentCurrency)),
+			"fee":              data.fee.String(),
+			"total":            data.total.String(),
+		}
+	}
+	cancelDate := ""
+	if !o.cancelDate.IsZero() {
+		cancelDate = microString(o.cancelDate)
+	}
+	return map[string]interface{}{"data": map[string]interface{}{
+		"order_date":       microString(o.created),
+		"type":             string(o.side),
+		"order_status":     string(o.status),
+		"order_currency":   strings.ToUpper(string(o.orderCurrency)),
+		"payment_currency": strings.ToUpper(string(o.paymentCurrency)),
+		"watch_price":      o.watchPrice.String(),
+		"order_price":      o.price.String(),
+		"order_qty":        o.units.String(),
+		"cancel_date":      cancelDate,
+		"cancel_type":      string(o.cancelType),
+		"contract":         contracts,
+	}}, nil
+}
+
+func (s *Server) infoUserTransactions(body params) (map[string]interface{}, error) {
+	orderCurrency, paymentCurrency := currencies(body)
+	search := body.Get("searchGb")
+	offset, _ := strconv.Atoi(body.Get("offset"))
+	count, err := strconv.Atoi(body.Get("count"))
+	if err != nil || count < 1 {
+		count = 20
+	}
+
+	// 최신 거래부터 반환
+	var data []interface{}
+	for index := len(s.transactions) - 1; index >= 0; index-- {
+		tr := s.transactions[index]
+		if tr.orderCurrency != orderCurrency || tr.paymentCurrency != paymentCurrency || (search != "" && search != "0" && search != tr.search) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		data = append(data, map[string]interface{}{
+			"search":           tr.search,
+			"transfer_date":    microString(tr.date),
+			"order_currency":   strings.ToUpper(string(tr.orderCurrency)),
+			"payment_currency": strings.ToUpper(string(tr.paymentCurrency)),
+			"units":            tr.units.String(),
+			"price":            tr.price.String(),
+			"amount":           tr.amount.String(),
+			"fee_currency":     strings.ToUpper(string(tr.feeCurrency)),
+			"fee":              tr.fee.String(),
+			"order_balance":    tr.orderBalance.String(),
+			"payment_balance":  tr.paymentBalance.String(),
+		})
+		if len(data) >= count {
+			break
+		}
+	}
+	if data == nil {
+		data = []interface{}{}
+	}
+	return map[string]interface{}{"data": data}, nil
+}
+
+//==============================TRADE======================================
+
+// lock 은 주문에 필요한 잔고를 묶습니다. 사용 가능한 잔고가 부족하면 실제 서버와 같은 오류를 반환합니다.
+func (s *Server) lock(o *order, amount b.Decimal) error {
+	currency := o.orderCurrency
+	message := "주문량이 사용가능 " + strings.ToUpper(string(currency)) + "을 초과하였습니다."
+	if o.side == b.Bid {
+		currency = o.paymentCurrency
+		message = "매수금액이 사용가능 " + strings.ToUpper(string(currency)) + " 를 초과하였습니다."
+	}
+	bl := s.balanceOf(currency)
+	if bl.available().LessThan(amount) {
+		return newStatusError("5600", message)
+	}
+	bl.inUse = bl.inUse.Add(amount)
+	o.locked = amount
+	return nil
+}
+
+func (s *Server) bidLockAmount(price b.Decimal, units b.Decimal) b.Decimal {
+	return price.Mul(units).Mul(s.tradeFee.Add(b.NewDecimalFromInt(1)))
+}
+
+func (s *Server) placeOrder(body params, watchPrice b.Decimal) (map[string]interface{}, error) {
+	orderCurrency, paymentCurrency := currencies(body)
+	side := b.OrderSide(body.Get("type"))
+	if !side.Valid() {
+		return nil, newStatusError("5500", "Invalid Parameter")
+	}
+	units, err := decimalParam(body, "units")
+	if err != nil {
+		return nil, err
+	}
+	price, err := decimalParam(body, "price")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bk := s.bookOf(orderCurrency, paymentCurrency)
+	o := s.newOrder(true, orderCurrency, paymentCurrency, side, price, units, now)
+	o.watchPrice = watchPrice
+
+	lockAmount := units
+	if side == b.Bid {
+		lockAmount = s.bidLockAmount(price, units)
+	}
+	if err := s.lock(o, lockAmount); err != nil {
+		delete(s.orders, o.id)
+		return nil, err
+	}
+
+	if watchPrice.Sign() > 0 {
+		bk.stops = append(bk.stops, o)
+		s.triggerStops(bk, now)
+	} else {
+		s.submit(bk, o, now)
+	}
+	return map[string]interface{}{"order_id": o.id}, nil
+}
+
+func (s *Server) tradePlace(body params) (map[string]interface{}, error) {
+	return s.placeOrder(body, b.Decimal{})
+}
+
+func (s *Server) tradeStopLimit(body params) (map[string]interface{}, error) {
+	watchPrice, err := decimalParam(body, "watch_price")
+	if err != nil {
+		return nil, err
+	}
+	return s.placeOrder(body, watchPrice)
+}
+
+func (s *Server) tradeCancel(body params) (map[string]interface{}, error) {
+	o, ok := s.orders[body.Get("order_id")]
+	if !ok || o.status != b.OrderPending {
+		return nil, newStatusError("5600", "취소할 수 있는 주문이 존재하지 않습니다.")
+	}
+	orderCurrency, paymentCurrency := currencies(body)
+	if o.side != b.OrderSide(body.Get("type")) || o.orderCurrency != orderCurrency || o.paymentCurrency != paymentCurrency {
+		return nil, newStatusError("5600", "취소할 수 있는 주문이 존재하지 않습니다.")
+	}
+	s.cancel(s.bookOf(o.orderCurrency, o.paymentCurrency), o, time.Now())
+	return map[string]interface{}{}, nil
+}
+
+func (s *Server) tradeMarket(body params, side b.OrderSide) (map[string]interface{}, error) {
+	orderCurrency, paymentCurrency := currencies(body)
+	units, err := decimalParam(body, "units")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bk := s.bookOf(orderCurrency, paymentCurrency)
+	levels := bk.asks
+	if side == b.Ask {
+		levels = bk.bids
+	}
+	if len(levels) == 0 {
+		return nil, newStatusError("5600", "주문 가능한 호가가 존재하지 않습니다.")
+	}
+
+	o := s.newOrder(true, orderCurrency, paymentCurrency, side, b.Decimal{}, units, now)
+
+	// 시장가 매수는 호가를 따라가며 필요한 금액을 미리 계산해 묶어둠
+	lockAmount := units
+	if side == b.Bid {
+		lockAmount = b.Decimal{}
+		left := units
+		for _, data := range levels {
+			filled := b.MinDecimal(left, data.remaining)
+			lockAmount = lockAmount.Add(s.bidLockAmount(data.price, filled))
+			left = left.Sub(filled)
+			if left.Sign() <= 0 {
+				break
+			}
+		}
+	}
+	if err := s.lock(o, lockAmount); err != nil {
+		delete(s.orders, o.id)
+		return nil, err
+	}
+
+	s.match(bk, o, now)
+
+	// 체결되지 않은 수량은 취소 처리
+	if o.remaining.Sign() > 0 {
+		s.cancel(bk, o, now)
+	} else if o.locked.Sign() > 0 {
+		s.cancel(bk, o, now)
+		o.status = b.OrderCompleted
+		o.cancelDate = time.Time{}
+		o.cancelType = b.CancelNone
+	}
+	return map[string]interface{}{"order_id": o.id}, nil
+}
+
+func (s *Server) tradeWithdrawal(currency b.Currency, rawAmount string) (map[string]interface{}, error) {
+	amount, err := b.ParseDecimal(rawAmount)
+	if err != nil || amount.Sign() <= 0 {
+		return nil, newStatusError("5500", "Invalid Parameter")
+	}
+	bl := s.balanceOf(currency)
+	if bl.available().LessThan(amount) {
+		return nil, newStatusError("5600", "출금 가능 금액을 초과하였습니다.")
+	}
+	bl.total = bl.total.Sub(amount)
+	return map[string]interface{}{}, nil
+}
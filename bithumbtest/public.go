@@ -0,0 +1,199 @@
+package bithumbtest
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+const trTimeForm = "2006-01-02 15:04:05"
+
+func (s *Server) servePublic(request *http.Request) (map[string]interface{}, error) {
+	parts := strings.SplitN(strings.TrimPrefix(request.URL.Path, "/public/"), "/", 2)
+	path := ""
+	if len(parts) == 2 {
+		path = parts[1]
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch parts[0] {
+	case "ticker":
+		return s.publicTicker(path)
+	case "orderbook":
+		return s.publicOrderbook(path)
+	case "transaction_history":
+		return s.publicTransactionHistory(path)
+	case "assetsstatus":
+		return map[string]interface{}{"data": map[string]interface{}{"deposit_status": 1, "withdrawal_status": 1}}, nil
+	case "btci":
+		index := map[string]interface{}{"market_index": "1000.00", "rate": "0.00", "width": "0.00"}
+		return map[string]interface{}{"data": map[string]interface{}{"date": milliString(time.Now()), "btai": index, "btmi": index}}, nil
+	case "candlestick":
+		return s.publicCandlestick(path)
+	}
+	return nil, newStatusError("5100", "Bad Request")
+}
+
+// splitPath 는 "BTC_KRW" 형태의 경로를 통화쌍으로 나눕니다.
+func splitPath(path string) (b.Currency, b.Currency, error) {
+	parts := strings.Split(strings.ToLower(path), "_")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", newStatusError("5500", "Invalid Parameter")
+	}
+	return b.Currency(parts[0]), b.Currency(parts[1]), nil
+}
+
+// marketsOf 는 paymentCurrency 로 거래되는 시장 목록을 이름 순으로 반환합니다.
+func (s *Server) marketsOf(paymentCurrency b.Currency) []*book {
+	var result []*book
+	for _, data := range s.books {
+		if data.paymentCurrency == paymentCurrency {
+			result = append(result, data)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].orderCurrency < result[j].orderCurrency })
+	return result
+}
+
+func (s *Server) publicTicker(path string) (map[string]interface{}, error) {
+	orderCurrency, paymentCurrency, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if orderCurrency != b.ALL {
+		key := marketKey(orderCurrency, paymentCurrency)
+		if _, ok := s.books[key]; !ok {
+			return nil, newStatusError("5500", "Invalid Parameter")
+		}
+		data := rawTicker(s.books[key])
+		data["date"] = milliString(now)
+		return map[string]interface{}{"data": data}, nil
+	}
+
+	data := map[string]interface{}{"date": milliString(now)}
+	for _, bk := range s.marketsOf(paymentCurrency) {
+		data[strings.ToUpper(string(bk.orderCurrency))] = rawTicker(bk)
+	}
+	return map[string]interface{}{"data": data}, nil
+}
+
+func rawTicker(bk *book) map[string]interface{} {
+	opening, closing, low, high := bk.lastPrice, bk.lastPrice, bk.lastPrice, bk.lastPrice
+	unitsTraded, tradeValue := b.Decimal{}, b.Decimal{}
+	if len(bk.trades) > 0 {
+		opening, low, high = bk.trades[0].price, bk.trades[0].price, bk.trades[0].price
+	}
+	for _, data := range bk.trades {
+		low = b.MinDecimal(low, data.price)
+		high = b.MaxDecimal(high, data.price)
+		unitsTraded = unitsTraded.Add(data.units)
+		tradeValue = tradeValue.Add(data.total)
+	}
+	fluctate := closing.Sub(opening)
+	fluctateRate := fluctate.Mul(b.NewDecimalFromInt(100)).Div(opening, 2)
+
+	return map[string]interface{}{
+		"opening_price":       opening.String(),
+		"closing_price":       closing.String(),
+		"min_price":           low.String(),
+		"max_price":           high.String(),
+		"units_traded":        unitsTraded.String(),
+		"acc_trade_value":     tradeValue.String(),
+		"prev_closing_price":  opening.String(),
+		"units_traded_24H":    unitsTraded.String(),
+		"acc_trade_value_24H": tradeValue.String(),
+		"fluctate_24H":        fluctate.String(),
+		"fluctate_rate_24H":   fluctateRate.String(),
+	}
+}
+
+func (s *Server) publicOrderbook(path string) (map[string]interface{}, error) {
+	orderCurrency, paymentCurrency, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if orderCurrency != b.ALL {
+		data := rawOrderbook(s.bookOf(orderCurrency, paymentCurrency))
+		data["timestamp"] = milliString(now)
+		data["payment_currency"] = strings.ToUpper(string(paymentCurrency))
+		return map[string]interface{}{"data": data}, nil
+	}
+
+	data := map[string]interface{}{"timestamp": milliString(now), "payment_currency": strings.ToUpper(string(paymentCurrency))}
+	for _, bk := range s.marketsOf(paymentCurrency) {
+		data[strings.ToUpper(string(bk.orderCurrency))] = rawOrderbook(bk)
+	}
+	return map[string]interface{}{"data": data}, nil
+}
+
+func rawOrderbook(bk *book) map[string]interface{} {
+	rawLevels := func(levels []b.Bidask) []interface{} {
+		result := make([]interface{}, len(levels))
+		for index, data := range levels {
+			result[index] = map[string]interface{}{"price": data.Price.String(), "quantity": data.Quantity.String()}
+		}
+		return result
+	}
+	return map[string]interface{}{
+		"order_currency": strings.ToUpper(string(bk.orderCurrency)),
+		"bids":           rawLevels(aggregate(bk.bids)),
+		"asks":           rawLevels(aggregate(bk.asks)),
+	}
+}
+
+func (s *Server) publicTransactionHistory(path string) (map[string]interface{}, error) {
+	orderCurrency, paymentCurrency, err := splitPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	trades := s.bookOf(orderCurrency, paymentCurrency).trades
+	if len(trades) > 20 {
+		trades = trades[len(trades)-20:]
+	}
+	data := make([]interface{}, len(trades))
+	for index, tr := range trades {
+		data[index] = map[string]interface{}{
+			"transaction_date": tr.date.In(kst).Format(trTimeForm),
+			"type":             string(tr.side),
+			"units_traded":     tr.units.String(),
+			"price":            tr.price.String(),
+			"total":            tr.total.String(),
+		}
+	}
+	return map[string]interface{}{"data": data}, nil
+}
+
+func (s *Server) publicCandlestick(path string) (map[string]interface{}, error) {
+	parts := strings.SplitN(path, "/", 2)
+	if len(parts) != 2 {
+		return nil, newStatusError("5500", "Invalid Parameter")
+	}
+	orderCurrency, paymentCurrency, err := splitPath(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	candles := s.bookOf(orderCurrency, paymentCurrency).candles[b.TimeInterval(parts[1])]
+	data := make([]interface{}, len(candles))
+	for index, candle := range candles {
+		data[index] = []interface{}{
+			candle.Time.UnixNano() / int64(time.Millisecond),
+			candle.OpeningPrice.String(),
+			candle.ClosingPrice.String(),
+			candle.HighPrice.String(),
+			candle.LowPrice.String(),
+			candle.UnitsTraded.String(),
+		}
+	}
+	return map[string]interface{}{"data": data}, nil
+}
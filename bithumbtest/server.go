@@ -0,0 +1,383 @@
+// Package bithumbtest 는 네트워크 없이 gobithumb 를 테스트하기 위한 가짜 Bithumb 서버입니다.
+//
+// httptest 서버 위에서 /public/*, /info/*, /trade/* API 와 API 2.0 (/v1/*) 을 흉내내며, private API 는
+// 실제 서버와 같은 방식(HMAC-SHA512, JWT)으로 서명을 검증합니다. 주문은 메모리 안의 체결 엔진에서 처리되고, Inject 로
+// 원하는 오류를 만들어낼 수 있습니다.
+//
+//	server := bithumbtest.NewServer("connect key", "secret key")
+//	defer server.Close()
+//	server.SetBalance(gobithumb.KRW, gobithumb.MustDecimal("1000000"))
+//	server.AddLiquidity(gobithumb.BTC, gobithumb.KRW, gobithumb.Ask, gobithumb.MustDecimal("50000000"), gobithumb.MustDecimal("1"))
+//
+//	client := gobithumb.NewBithumb("connect key", "secret key", gobithumb.WithBaseURL(server.URL))
+package bithumbtest
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// Injection 은 가짜 서버가 돌려줄 오류입니다.
+type Injection struct {
+	Endpoint   string        // 오류를 낼 endpoint (e.g. "/trade/place"), 비어있으면 모든 요청
+	HTTPStatus int           // 0 이면 200 (/v1/* 이면 400)
+	Status     string        // Bithumb status code (e.g. "5600"), /v1/* 이면 error.name (e.g. "insufficient_funds_bid")
+	Message    string        // status 와 함께 보낼 message
+	Times      int           // 오류를 낼 횟수, 0 이면 ClearInjections 전까지 계속
+	Delay      time.Duration // 응답 전에 기다릴 시간
+	Disconnect bool          // 응답하지 않고 연결을 끊음
+}
+
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mutex sync.Mutex
+
+	connectKey string
+	secretKey  string
+	accountId  string
+	created    time.Time
+	tradeFee   b.Decimal
+
+	balances     map[b.Currency]*balance
+	books        map[string]*book
+	orders       map[string]*order
+	transactions []userTransaction
+	injections   []*Injection
+	requests     map[string]int
+	nextOrderId  int64
+
+	streams map[*streamConn]struct{}
+}
+
+func NewServer(connectKey string, secretKey string) *Server {
+
+	server := Server{}
+
+	server.connectKey = connectKey
+	server.secretKey = secretKey
+	server.accountId = "bithumbtest"
+	server.created = time.Now()
+	server.tradeFee = b.MustDecimal("0.0025")
+
+	server.balances = make(map[b.Currency]*balance)
+	server.books = make(map[string]*book)
+	server.orders = make(map[string]*order)
+	server.requests = make(map[string]int)
+	server.streams = make(map[*streamConn]struct{})
+
+	server.server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	server.URL = server.server.URL
+
+	return &server
+}
+
+func (s *Server) Close() {
+	s.mutex.Lock()
+	for stream := range s.streams {
+		stream.close()
+	}
+	s.mutex.Unlock()
+	s.server.Close()
+}
+
+// StreamURL 은 gobithumb.WithStreamURL 에 넘길 WebSocket 주소입니다.
+func (s *Server) StreamURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http") + "/pub/ws"
+}
+
+func (s *Server) SetTradeFee(fee b.Decimal) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tradeFee = fee
+}
+
+// SetBalance 는 계정의 잔고를 바꿉니다. 주문에 묶여있는 잔고는 그대로 둡니다.
+func (s *Server) SetBalance(currency b.Currency, total b.Decimal) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.balanceOf(currency).total = total
+}
+
+// Balance 는 계정의 전체 잔고와 주문에 묶여있는 잔고를 반환합니다.
+func (s *Server) Balance(currency b.Currency) (total b.Decimal, inUse b.Decimal) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data := s.balanceOf(currency)
+	return data.total, data.inUse
+}
+
+// SetLastPrice 는 체결 없이 마지막 체결가를 바꿉니다. 감시가격에 도달한 stop limit 주문은 실행됩니다.
+func (s *Server) SetLastPrice(orderCurrency b.Currency, paymentCurrency b.Currency, price b.Decimal) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	bk := s.bookOf(orderCurrency, paymentCurrency)
+	bk.lastPrice = price
+	s.triggerStops(bk, time.Now())
+}
+
+// AddLiquidity 는 계정과 관계없는 지정가 주문을 호가에 올립니다. 올린 주문의 id 를 반환합니다.
+// 이미 반대편에 체결 가능한 주문이 있으면 바로 체결됩니다.
+func (s *Server) AddLiquidity(orderCurrency b.Currency, paymentCurrency b.Currency, side b.OrderSide, price b.Decimal, units b.Decimal) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := time.Now()
+	bk := s.bookOf(orderCurrency, paymentCurrency)
+	o := s.newOrder(false, orderCurrency, paymentCurrency, side, price, units, now)
+	s.submit(bk, o, now)
+	return o.id
+}
+
+// Trade 는 계정과 관계없는 시장가 주문으로 체결을 만듭니다. (e.g. 다른 사용자의 매수로 사용자의 매도 주문이 체결되는 상황)
+func (s *Server) Trade(orderCurrency b.Currency, paymentCurrency b.Currency, side b.OrderSide, units b.Decimal) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	now := time.Now()
+	bk := s.bookOf(orderCurrency, paymentCurrency)
+	o := s.newOrder(false, orderCurrency, paymentCurrency, side, b.Decimal{}, units, now)
+	s.match(bk, o, now)
+}
+
+// SetCandles 는 /public/candlestick 이 돌려줄 봉 데이터를 지정합니다.
+func (s *Server) SetCandles(orderCurrency b.Currency, paymentCurrency b.Currency, interval b.TimeInterval, candles []b.OneCandleStick) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.bookOf(orderCurrency, paymentCurrency).candles[interval] = append([]b.OneCandleStick(nil), candles...)
+}
+
+func (s *Server) Inject(injection Injection) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.injections = append(s.injections, &injection)
+}
+
+func (s *Server) ClearInjections() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.injections = nil
+}
+
+// Requests 는 endpoint 로 들어온 요청 수를 반환합니다.
+func (s *Server) Requests(endpoint string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requests[endpoint]
+}
+
+func (s *Server) balanceOf(currency b.Currency) *balance {
+	currency = b.Currency(strings.ToLower(string(currency)))
+	if _, ok := s.balances[currency]; !ok {
+		s.balances[currency] = &balance{}
+	}
+	return s.balances[currency]
+}
+
+func marketKey(orderCurrency b.Currency, paymentCurrency b.Currency) string {
+	return strings.ToLower(string(orderCurrency) + "_" + string(paymentCurrency))
+}
+
+func (s *Server) bookOf(orderCurrency b.Currency, paymentCurrency b.Currency) *book {
+	orderCurrency = b.Currency(strings.ToLower(string(orderCurrency)))
+	paymentCurrency = b.Currency(strings.ToLower(string(paymentCurrency)))
+	key := marketKey(orderCurrency, paymentCurrency)
+	if _, ok := s.books[key]; !ok {
+		s.books[key] = newBook(orderCurrency, paymentCurrency)
+	}
+	return s.books[key]
+}
+
+func (s *Server) newOrder(user bool, orderCurrency b.Currency, paymentCurrency b.Currency, side b.OrderSide, price b.Decimal, units b.Decimal, now time.Time) *order {
+	s.nextOrderId++
+	o := &order{
+		id:              fmt.Sprintf("C%019d", s.nextOrderId),
+		user:            user,
+		orderCurrency:   b.Currency(strings.ToLower(string(orderCurrency))),
+		paymentCurrency: b.Currency(strings.ToLower(string(paymentCurrency))),
+		side:            side,
+		price:           price,
+		units:           units,
+		remaining:       units,
+		created:         now,
+		status:          b.OrderPending,
+	}
+	if user {
+		s.orders[o.id] = o
+	}
+	return o
+}
+
+//==============================HTTP HANDLING======================================
+
+type statusError struct {
+	httpStatus int
+	status     string
+	message    string
+}
+
+func (e *statusError) Error() string {
+	return e.status + " " + e.message
+}
+
+func newStatusError(status string, message string) *statusError {
+	return &statusError{httpStatus: http.StatusOK, status: status, message: message}
+}
+
+func (s *Server) serveHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Path == "/pub/ws" {
+		s.serveStream(writer, request)
+		return
+	}
+
+	endpoint := request.URL.Path
+	if strings.HasPrefix(endpoint, "/public/") {
+		parts := strings.SplitN(endpoint, "/", 4)
+		endpoint = "/public/" + parts[2]
+	}
+	if strings.HasPrefix(endpoint, "/v1/candles/") {
+		endpoint = "/v1/candles"
+	}
+	v2 := strings.HasPrefix(endpoint, "/v1/")
+
+	s.mutex.Lock()
+	s.requests[endpoint]++
+	injection := s.takeInjection(endpoint)
+	s.mutex.Unlock()
+
+	if injection != nil {
+		time.Sleep(injection.Delay)
+		if injection.Disconnect {
+			if hijacker, ok := writer.(http.Hijacker); ok {
+				if conn, _, err := hijacker.Hijack(); err == nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+		if injection.Status != "" || injection.HTTPStatus != 0 {
+			httpStatus := injection.HTTPStatus
+			if v2 {
+				if httpStatus == 0 {
+					httpStatus = http.StatusBadRequest
+				}
+				writeV2Error(writer, &v2Error{httpStatus: httpStatus, name: injection.Status, message: injection.Message})
+				return
+			}
+			if httpStatus == 0 {
+				httpStatus = http.StatusOK
+			}
+			writeError(writer, &statusError{httpStatus: httpStatus, status: injection.Status, message: injection.Message})
+			return
+		}
+	}
+
+	if v2 {
+		s.serveV2(writer, request)
+		return
+	}
+
+	var result map[string]interface{}
+	var err error
+	if strings.HasPrefix(request.URL.Path, "/public/") {
+		result, err = s.servePublic(request)
+	} else {
+		result, err = s.servePrivate(request)
+	}
+	if err != nil {
+		writeError(writer, err)
+		return
+	}
+
+	result["status"] = "0000"
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(result)
+}
+
+func (s *Server) takeInjection(endpoint string) *Injection {
+	for index, injection := range s.injections {
+		if injection.Endpoint != "" && injection.Endpoint != endpoint {
+			continue
+		}
+		result := *injection
+		if injection.Times > 0 {
+			injection.Times--
+			if injection.Times == 0 {
+				s.injections = append(s.injections[:index], s.injections[index+1:]...)
+			}
+		}
+		return &result
+	}
+	return nil
+}
+
+func writeError(writer http.ResponseWriter, err error) {
+	statusErr, ok := err.(*statusError)
+	if !ok {
+		statusErr = newStatusError("5900", err.Error())
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusErr.httpStatus)
+	if statusErr.status == "" {
+		_, _ = writer.Write([]byte(http.StatusText(statusErr.httpStatus)))
+		return
+	}
+	_ = json.NewEncoder(writer).Encode(map[string]string{"status": statusErr.status, "message": statusErr.message})
+}
+
+// authenticate 는 Api-Key, Api-Sign, Api-Nonce 헤더를 검증하고 요청 body 를 돌려줍니다.
+func (s *Server) authenticate(request *http.Request) (url.Values, error) {
+	if request.Method != http.MethodPost {
+		return nil, newStatusError("5302", "Method Not Allowed.")
+	}
+
+	rawBody, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		return nil, newStatusError("5100", "Bad Request.")
+	}
+	body, err := url.ParseQuery(string(rawBody))
+	if err != nil {
+		return nil, newStatusError("5100", "Bad Request.")
+	}
+
+	if request.Header.Get("Api-Key") != s.connectKey {
+		return nil, newStatusError("5300", "Invalid Apikey")
+	}
+	nonce := request.Header.Get("Api-Nonce")
+	expected := sign(s.secretKey, request.URL.Path, string(rawBody), nonce)
+	if nonce == "" || !hmac.Equal([]byte(request.Header.Get("Api-Sign")), []byte(expected)) {
+		return nil, newStatusError("5100", "Bad Request.(Auth Data)")
+	}
+	if body.Get("endpoint") != request.URL.Path {
+		return nil, newStatusError("5100", "Bad Request.(endpoint)")
+	}
+	return body, nil
+}
+
+// sign 은 gobithumb 의 Api-Sign 과 같은 방식으로 서명을 만듭니다.
+func sign(secretKey string, endpoint string, body string, nonce string) string {
+	hmacParsed := hmac.New(sha512.New, []byte(secretKey))
+	hmacParsed.Write([]byte(endpoint + "\x00" + body + "\x00" + nonce))
+	return base64.StdEncoding.EncodeToString([]byte(hex.EncodeToString(hmacParsed.Sum(nil))))
+}
+
+func milliString(t time.Time) string {
+	return fmt.Sprint(t.UnixNano() / int64(time.Millisecond))
+}
+
+func microString(t time.Time) string {
+	return fmt.Sprint(t.UnixNano() / int64(time.Microsecond))
+}
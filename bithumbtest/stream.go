@@ -0,0 +1,346 @@
+package bithumbtest
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// 가짜 서버의 WebSocket(/pub/ws) 입니다. 체결 엔진에서 생긴 체결과 호가 변화를 구독한 연결에 보냅니다.
+
+const wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var kst = time.FixedZone("KST", 9*60*60)
+
+type streamConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	mutex   sync.Mutex
+	symbols map[string]map[string]bool // type -> symbol
+	ticks   []string
+
+	send      chan []byte
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func (s *Server) serveStream(writer http.ResponseWriter, request *http.Request) {
+	key := request.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(request.Header.Get("Upgrade"), "websocket") || key == "" {
+		http.Error(writer, "websocket upgrade required", http.StatusBadRequest)
+		return
+	}
+	hijacker, ok := writer.(http.Hijacker)
+	if !ok {
+		http.Error(writer, "hijack not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, bufrw, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+
+	accept := sha1.Sum([]byte(key + wsAcceptGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(accept[:]) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return
+	}
+
+	stream := &streamConn{
+		conn:    conn,
+		reader:  bufrw.Reader,
+		symbols: make(map[string]map[string]bool),
+		send:    make(chan []byte, 1024),
+		done:    make(chan struct{}),
+	}
+
+	s.mutex.Lock()
+	s.streams[stream] = struct{}{}
+	s.mutex.Unlock()
+
+	go stream.writeLoop()
+	stream.push(map[string]interface{}{"status": "0000", "resmsg": "Connected Successfully"})
+	stream.readLoop()
+
+	s.mutex.Lock()
+	delete(s.streams, stream)
+	s.mutex.Unlock()
+	stream.close()
+}
+
+func (c *streamConn) readLoop() {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case 0x8: // close
+			return
+		case 0x9: // ping
+			c.pushFrame(0xA, payload)
+		case 0x1, 0x2:
+			c.handleRequest(payload)
+		}
+	}
+}
+
+// handleRequest 는 {"type":"ticker","symbols":["BTC_KRW"],"tickTypes":["30M"]} 형태의 구독 요청을 처리합니다.
+func (c *streamConn) handleRequest(payload []byte) {
+	var request struct {
+		Type      string   `json:"type"`
+		Symbols   []string `json:"symbols"`
+		TickTypes []string `json:"tickTypes"`
+	}
+	if err := json.Unmarshal(payload, &request); err != nil || request.Type == "" || len(request.Symbols) == 0 {
+		c.push(map[string]interface{}{"status": "5100", "resmsg": "Invalid Filter Syntax"})
+		return
+	}
+
+	// 실제 서버처럼 같은 type 의 요청은 이전 구독을 대체함
+	c.mutex.Lock()
+	symbols := make(map[string]bool)
+	for _, symbol := range request.Symbols {
+		symbols[strings.ToUpper(symbol)] = true
+	}
+	c.symbols[request.Type] = symbols
+	if request.Type == "ticker" {
+		c.ticks = request.TickTypes
+		if len(c.ticks) == 0 {
+			c.ticks = []string{"24H"}
+		}
+	}
+	c.mutex.Unlock()
+
+	c.push(map[string]interface{}{"status": "0000", "resmsg": "Filter Registered Successfully"})
+}
+
+func (c *streamConn) subscribed(streamType string, symbol string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.symbols[streamType][symbol]
+}
+
+func (c *streamConn) tickTypes() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]string(nil), c.ticks...)
+}
+
+func (c *streamConn) readFrame() (byte, []byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return 0, nil, err
+	}
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(c.reader, extended); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended)
+	}
+	if length > 1<<20 {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+
+	var maskKey [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.reader, maskKey[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= maskKey[i%4]
+		}
+	}
+	return opcode, payload, nil
+}
+
+// writeLoop 는 send 에 쌓인 frame 을 순서대로 보냅니다. server 가 보내는 frame 에는 mask 를 씌우지 않습니다.
+func (c *streamConn) writeLoop() {
+	for {
+		select {
+		case frame := <-c.send:
+			if _, err := c.conn.Write(frame); err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *streamConn) push(message interface{}) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	c.pushFrame(0x1, payload)
+}
+
+// pushFrame 은 frame 을 보낼 순서에 넣습니다. 읽지 않는 연결 때문에 체결 엔진이 멈추지 않도록, 버퍼가 가득 차면 연결을 끊습니다.
+func (c *streamConn) pushFrame(opcode byte, payload []byte) {
+	frame := []byte{0x80 | opcode}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, 126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	default:
+		frame = append(frame, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(len(payload)))
+	}
+	frame = append(frame, payload...)
+
+	select {
+	case c.send <- frame:
+	case <-c.done:
+	default:
+		c.close()
+	}
+}
+
+func (c *streamConn) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		_ = c.conn.Close()
+	})
+}
+
+//==============================PUBLISH======================================
+
+func streamSymbol(bk *book) string {
+	return strings.ToUpper(string(bk.orderCurrency) + "_" + string(bk.paymentCurrency))
+}
+
+// publishTrade 는 체결 하나를 transaction, ticker 구독자에게 보냅니다. s.mutex 를 잡은 상태에서 호출됩니다.
+func (s *Server) publishTrade(bk *book, side b.OrderSide, price b.Decimal, units b.Decimal, total b.Decimal, now time.Time) {
+	if len(s.streams) == 0 {
+		return
+	}
+	symbol := streamSymbol(bk)
+
+	buySellGb := "2"
+	if side == b.Ask {
+		buySellGb = "1"
+	}
+	upDown := "dn"
+	if len(bk.trades) < 2 || !price.LessThan(bk.trades[len(bk.trades)-2].price) {
+		upDown = "up"
+	}
+	transaction := map[string]interface{}{
+		"type": "transaction",
+		"content": map[string]interface{}{
+			"list": []interface{}{map[string]interface{}{
+				"symbol":    symbol,
+				"buySellGb": buySellGb,
+				"contPrice": price.String(),
+				"contQty":   units.String(),
+				"contAmt":   total.String(),
+				"contDtm":   now.In(kst).Format("2006-01-02 15:04:05.000000"),
+				"updn":      upDown,
+			}},
+		},
+	}
+
+	ticker := rawTicker(bk)
+	for stream := range s.streams {
+		if stream.subscribed("transaction", symbol) {
+			stream.push(transaction)
+		}
+		if !stream.subscribed("ticker", symbol) {
+			continue
+		}
+		for _, tickType := range stream.tickTypes() {
+			stream.push(map[string]interface{}{
+				"type": "ticker",
+				"content": map[string]interface{}{
+					"symbol":         symbol,
+					"tickType":       tickType,
+					"date":           now.In(kst).Format("20060102"),
+					"time":           now.In(kst).Format("150405"),
+					"openPrice":      ticker["opening_price"],
+					"closePrice":     ticker["closing_price"],
+					"lowPrice":       ticker["min_price"],
+					"highPrice":      ticker["max_price"],
+					"value":          ticker["acc_trade_value"],
+					"volume":         ticker["units_traded"],
+					"prevClosePrice": ticker["prev_closing_price"],
+					"chgRate":        ticker["fluctate_rate_24H"],
+					"chgAmt":         ticker["fluctate_24H"],
+				},
+			})
+		}
+	}
+}
+
+// publishDepth 는 한 가격대의 남은 수량을 orderbookdepth 구독자에게 보냅니다. 수량이 0 이면 가격대가 사라진 것입니다.
+func (s *Server) publishDepth(bk *book, side b.OrderSide, price b.Decimal, now time.Time) {
+	if len(s.streams) == 0 || price.Sign() <= 0 {
+		return
+	}
+	symbol := streamSymbol(bk)
+
+	levels := bk.asks
+	if side == b.Bid {
+		levels = bk.bids
+	}
+	count := 0
+	for _, data := range levels {
+		if data.price.Equal(price) {
+			count++
+		}
+	}
+
+	depth := map[string]interface{}{
+		"type": "orderbookdepth",
+		"content": map[string]interface{}{
+			"list": []interface{}{map[string]interface{}{
+				"symbol":    symbol,
+				"orderType": side,
+				"price":     price.String(),
+				"quantity":  bk.levelQuantity(side, price).String(),
+				"total":     count,
+			}},
+			"datetime": microString(now),
+		},
+	}
+	for stream := range s.streams {
+		if stream.subscribed("orderbookdepth", symbol) {
+			stream.push(depth)
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package bithumbtest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// 가짜 서버의 API 2.0 (/v1/*) 입니다. JWT 서명과 query_hash 를 검증하고, legacy API 와 같은 잔고, 체결 엔진을 사용합니다.
+// /v1/candles 는 지원하지 않습니다.
+
+type v2Error struct {
+	httpStatus int
+	name       string
+	message    string
+}
+
+func (e *v2Error) Error() string {
+	return e.name + " " + e.message
+}
+
+func writeV2Error(writer http.ResponseWriter, err error) {
+	errV2, ok := err.(*v2Error)
+	if !ok {
+		errV2 = &v2Error{httpStatus: http.StatusInternalServerError, name: "server_error", message: err.Error()}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(errV2.httpStatus)
+	if errV2.name == "" {
+		_, _ = writer.Write([]byte(http.StatusText(errV2.httpStatus)))
+		return
+	}
+	_ = json.NewEncoder(writer).Encode(map[string]interface{}{"error": map[string]string{"name": errV2.name, "message": errV2.message}})
+}
+
+// v2ErrorOf 는 체결 엔진이 반환한 legacy 오류를 API 2.0 오류로 바꿉니다.
+func v2ErrorOf(err error, side b.OrderSide) error {
+	statusErr, ok := err.(*statusError)
+	if !ok {
+		return err
+	}
+	switch {
+	case statusErr.status == "5600" && strings.Contains(statusErr.message, "사용가능"):
+		return &v2Error{httpStatus: http.StatusBadRequest, name: "insufficient_funds_" + string(side), message: "주문가능한 금액이 부족합니다."}
+	case statusErr.status == "5500" || statusErr.status == "5100":
+		return &v2Error{httpStatus: http.StatusBadRequest, name: "validation_error", message: statusErr.message}
+	}
+	return &v2Error{httpStatus: http.StatusBadRequest, name: "bad_request", message: statusErr.message}
+}
+
+func (s *Server) serveV2(writer http.ResponseWriter, request *http.Request) {
+	var result interface{}
+	var err error
+	switch {
+	case request.URL.Path == "/v1/market/all":
+		s.mutex.Lock()
+		result = s.v2Markets()
+		s.mutex.Unlock()
+	case strings.HasPrefix(request.URL.Path, "/v1/candles/"):
+		err = &v2Error{httpStatus: http.StatusNotFound, name: "not_found", message: "bithumbtest 는 /v1/candles 를 지원하지 않습니다."}
+	default:
+		result, err = s.serveV2Private(request)
+	}
+	if err != nil {
+		writeV2Error(writer, err)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(writer).Encode(result)
+}
+
+func (s *Server) serveV2Private(request *http.Request) (interface{}, error) {
+	query, err := s.authenticateV2(request)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch request.Method + " " + request.URL.Path {
+	case "GET /v1/accounts":
+		return s.v2Accounts(), nil
+	case "GET /v1/orders":
+		return s.v2Orders(query)
+	case "POST /v1/orders":
+		return s.v2PlaceOrder(query)
+	case "GET /v1/order":
+		o, ok := s.orders[query.Get("uuid")]
+		if !ok {
+			return nil, &v2Error{httpStatus: http.StatusNotFound, name: "order_not_found", message: "주문을 찾지 못했습니다."}
+		}
+		return v2Order(o, true), nil
+	case "DELETE /v1/order":
+		o, ok := s.orders[query.Get("uuid")]
+		if !ok || o.status != b.OrderPending {
+			return nil, &v2Error{httpStatus: http.StatusNotFound, name: "order_not_found", message: "주문을 찾지 못했습니다."}
+		}
+		s.cancel(s.bookOf(o.orderCurrency, o.paymentCurrency), o, time.Now())
+		return v2Order(o, false), nil
+	}
+	return nil, &v2Error{httpStatus: http.StatusNotFound, name: "not_found", message: "Not Found"}
+}
+
+// authenticateV2 는 Authorization: Bearer 헤더의 JWT 를 검증하고 요청 parameter 를 돌려줍니다.
+func (s *Server) authenticateV2(request *http.Request) (url.Values, error) {
+	query := request.URL.Query()
+	if request.Method == http.MethodPost {
+		var body map[string]string
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			return nil, &v2Error{httpStatus: http.StatusBadRequest, name: "invalid_parameter", message: "잘못된 요청입니다."}
+		}
+		query = url.Values{}
+		for key, value := range body {
+			query.Set(key, value)
+		}
+	}
+
+	token := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, &v2Error{httpStatus: http.StatusUnauthorized, name: "jwt_verification", message: "잘못된 토큰입니다."}
+	}
+	signature := hmac.New(sha256.New, []byte(s.secretKey))
+	signature.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal([]byte(parts[2]), []byte(base64.RawURLEncoding.EncodeToString(signature.Sum(nil)))) {
+		return nil, &v2Error{httpStatus: http.StatusUnauthorized, name: "jwt_verification", message: "잘못된 토큰입니다."}
+	}
+
+	var claims struct {
+		AccessKey string `json:"access_key"`
+		Nonce     string `json:"nonce"`
+		QueryHash string `json:"query_hash"`
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || json.Unmarshal(payload, &claims) != nil || claims.Nonce == "" {
+		return nil, &v2Error{httpStatus: http.StatusUnauthorized, name: "jwt_verification", message: "잘못된 토큰입니다."}
+	}
+	if claims.AccessKey != s.connectKey {
+		return nil, &v2Error{httpStatus: http.StatusUnauthorized, name: "invalid_access_key", message: "잘못된 엑세스 키입니다."}
+	}
+	if len(query) > 0 {
+		hash := sha512.Sum512([]byte(query.Encode()))
+		if claims.QueryHash != hex.EncodeToString(hash[:]) {
+			return nil, &v2Error{httpStatus: http.StatusUnauthorized, name: "invalid_query_payload", message: "query_hash 가 일치하지 않습니다."}
+		}
+	}
+	return query, nil
+}
+
+func v2MarketOf(raw string) (b.Currency, b.Currency, bool) {
+	index := strings.Index(raw, "-")
+	if index <= 0 {
+		return "", "", false
+	}
+	return b.Currency(strings.ToLower(raw[index+1:])), b.Currency(strings.ToLower(raw[:index])), true
+}
+
+func v2MarketID(orderCurrency b.Currency, paymentCurrency b.Currency) string {
+	return strings.ToUpper(string(paymentCurrency) + "-" + string(orderCurrency))
+}
+
+func v2State(o *order) string {
+	switch o.status {
+	case b.OrderCompleted:
+		return "done"
+	case b.OrderCancel:
+		return "cancel"
+	}
+	if o.watchPrice.Sign() > 0 && o.remaining.Equal(o.units) && len(o.contracts) == 0 {
+		return "watch"
+	}
+	return "wait"
+}
+
+// v2Order 는 주문을 API 2.0 응답 형태로 바꿉니다. withTrades 이면 체결 내역도 포함합니다.
+func v2Order(o *order, withTrades bool) map[string]interface{} {
+	ordType := "limit"
+	if o.price.Sign() <= 0 {
+		ordType = "market"
+	}
+	executed := o.units.Sub(o.remaining)
+	paidFee := b.Decimal{}
+	for _, data := range o.contracts {
+		paidFee = paidFee.Add(data.fee)
+	}
+
+	result := map[string]interface{}{
+		"uuid":             o.id,
+		"side":             string(o.side),
+		"ord_type":         ordType,
+		"price":            o.price.String(),
+		"state":            v2State(o),
+		"market":           v2MarketID(o.orderCurrency, o.paymentCurrency),
+		"created_at":       o.created.In(kst).Format(time.RFC3339),
+		"volume":           o.units.String(),
+		"remaining_volume": o.remaining.String(),
+		"executed_volume":  executed.String(),
+		"locked":           o.locked.String(),
+		"paid_fee":         paidFee.String(),
+		"trades_count":     len(o.contracts),
+	}
+	if withTrades {
+		trades := make([]interface{}, 0, len(o.contracts))
+		for index, data := range o.contracts {
+			trades = append(trades, map[string]interface{}{
+				"market":     result["market"],
+				"uuid":       o.id + "-" + strconv.Itoa(index),
+				"price":      data.price.String(),
+				"volume":     data.units.String(),
+				"funds":      data.price.Mul(data.units).String(),
+				"side":       string(o.side),
+				"created_at": data.date.In(kst).Format(time.RFC3339),
+			})
+		}
+		result["trades"] = trades
+	}
+	return result
+}
+
+func (s *Server) v2Markets() []interface{} {
+	result := []interface{}{}
+	for _, paymentCurrency := range []b.Currency{b.KRW, b.BTC} {
+		for _, bk := range s.marketsOf(paymentCurrency) {
+			name := strings.ToUpper(string(bk.orderCurrency))
+			result = append(result, map[string]interface{}{
+				"market":         v2MarketID(bk.orderCurrency, bk.paymentCurrency),
+				"korean_name":    name,
+				"english_name":   name,
+				"market_warning": "NONE",
+			})
+		}
+	}
+	return result
+}
+
+func (s *Server) v2Accounts() []interface{} {
+	currencies := make([]b.Currency, 0, len(s.balances))
+	for currency := range s.balances {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+
+	result := []interface{}{}
+	for _, currency := range currencies {
+		bl := s.balances[currency]
+		if bl.total.Sign() <= 0 && currency != b.KRW {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"currency":               strings.ToUpper(string(currency)),
+			"balance":                bl.available().String(),
+			"locked":                 bl.inUse.String(),
+			"avg_buy_price":          "0",
+			"avg_buy_price_modified": false,
+			"unit_currency":          "KRW",
+		})
+	}
+	return result
+}
+
+func (s *Server) v2Orders(query url.Values) (interface{}, error) {
+	orderCurrency, paymentCurrency, ok := v2MarketOf(query.Get("market"))
+	if !ok {
+		return nil, &v2Error{httpStatus: http.StatusBadRequest, name: "validation_error", message: "market 이 필요합니다."}
+	}
+	state := query.Get("state")
+	if state == "" {
+		state = "wait"
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 100
+	}
+
+	var matched []*order
+	for _, o := range s.sortedOrders() {
+		if o.orderCurrency == orderCurrency && o.paymentCurrency == paymentCurrency && v2State(o) == state {
+			matched = append(matched, o)
+		}
+	}
+	if query.Get("order_by") != "asc" {
+		for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+			matched[i], matched[j] = matched[j], matched[i]
+		}
+	}
+
+	result := []interface{}{}
+	for index := (page - 1) * limit; index < len(matched) && index < page*limit; index++ {
+		result = append(result, v2Order(matched[index], false))
+	}
+	return result, nil
+}
+
+// v2PlaceOrder 는 지정가(limit) 와 시장가 매도(market) 주문을 legacy 주문으로 바꿔 체결 엔진에 넣습니다.
+func (s *Server) v2PlaceOrder(query url.Values) (interface{}, error) {
+	orderCurrency, paymentCurrency, ok := v2MarketOf(query.Get("market"))
+	side := b.OrderSide(query.Get("side"))
+	if !ok || !side.Valid() {
+		return nil, &v2Error{httpStatus: http.StatusBadRequest, name: "validation_error", message: "market, side 가 필요합니다."}
+	}
+
+	body := url.Values{}
+	body.Set("order_currency", string(orderCurrency))
+	body.Set("payment_currency", string(paymentCurrency))
+	body.Set("units", query.Get("volume"))
+	body.Set("price", query.Get("price"))
+	body.Set("type", string(side))
+
+	var result map[string]interface{}
+	var err error
+	switch query.Get("ord_type") {
+	case "limit":
+		result, err = s.placeOrder(body, b.Decimal{})
+	case "market":
+		if side != b.Ask {
+			return nil, &v2Error{httpStatus: http.StatusBadRequest, name: "validation_error", message: "시장가(market) 주문은 매도만 가능합니다."}
+		}
+		result, err = s.tradeMarket(body, side)
+	default:
+		return nil, &v2Error{httpStatus: http.StatusBadRequest, name: "validation_error", message: "bithumbtest 는 limit, market 주문만 지원합니다."}
+	}
+	if err != nil {
+		return nil, v2ErrorOf(err, side)
+	}
+	return v2Order(s.orders[toID(result["order_id"])], false), nil
+}
+
+func toID(raw interface{}) string {
+	id, _ := raw.(string)
+	return id
+}
@@ -0,0 +1,198 @@
+package gobithumb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// cancelConcurrency 는 CancelAll 이 동시에 보내는 취소 요청의 개수입니다. 요청 속도는 private API 의 rate limiter 를 따릅니다.
+const cancelConcurrency = 4
+
+// cancelAllRounds 는 CancelAll 이 GetOrder 로 남은 주문을 다시 확인하는 최대 횟수입니다.
+const cancelAllRounds = 10
+
+// CancelResult 는 주문 하나의 취소 결과입니다.
+// 취소하기 전에 체결되거나 이미 취소되어 주문이 없으면 NotFound 가 true 이고 Err 는 nil 입니다.
+type CancelResult struct {
+	Market   Market
+	Order    Order
+	NotFound bool
+	Err      error
+}
+
+// CancelReport 는 CancelAll, CancelAllEverywhere 의 주문별 결과입니다.
+// Errors 에는 주문 목록을 가져오지 못한 시장의 오류가 들어갑니다.
+type CancelReport struct {
+	Results []CancelResult
+	Errors  []error
+}
+
+// Cancelled 는 취소에 성공한 주문의 개수입니다.
+func (r CancelReport) Cancelled() int {
+	count := 0
+	for _, data := range r.Results {
+		if data.Err == nil && !data.NotFound {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed 는 취소에 실패한 주문입니다.
+func (r CancelReport) Failed() []CancelResult {
+	var result []CancelResult
+	for _, data := range r.Results {
+		if data.Err != nil {
+			result = append(result, data)
+		}
+	}
+	return result
+}
+
+// err 는 실패가 있으면 첫 번째 오류를 감싸 반환합니다.
+func (r CancelReport) err() error {
+	failed := r.Failed()
+	switch {
+	case len(r.Errors) > 0:
+		return fmt.Errorf("gobithumb: %d 개 시장의 주문 목록 조회 실패, %d 개 주문 취소 실패: %w", len(r.Errors), len(failed), r.Errors[0])
+	case len(failed) > 0:
+		return fmt.Errorf("gobithumb: %d 개 주문 취소 실패: %w", len(failed), failed[0].Err)
+	}
+	return nil
+}
+
+func (b *BithumbRequester) CancelAll(market Market, sides ...OrderSide) (CancelReport, error) {
+	return b.CancelAllCtx(context.Background(), market, sides...)
+}
+
+// CancelAllCtx 는 market 의 대기 중인 주문을 모두 취소합니다. sides 를 주면 그 방향의 주문만 취소합니다.
+// 취소한 뒤 GetOrder 로 남은 주문을 다시 확인하며, 새로 생긴 주문이 없을 때까지 (최대 10번) 반복합니다.
+// 하나라도 실패하면 error 를 함께 반환하며, 주문별 결과는 CancelReport 에 있습니다.
+func (b *BithumbRequester) CancelAllCtx(ctx context.Context, market Market, sides ...OrderSide) (CancelReport, error) {
+
+	// parameter 정상 체크
+	for _, side := range sides {
+		if !side.Valid() {
+			return CancelReport{}, fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, side)
+		}
+	}
+
+	report := CancelReport{}
+	cancelMarket(ctx, b, market, sides, &report)
+	return report, report.err()
+}
+
+func (b *BithumbRequester) CancelAllEverywhere() (CancelReport, error) {
+	return b.CancelAllEverywhereCtx(context.Background())
+}
+
+// CancelAllEverywhereCtx 는 KRW, BTC 마켓의 대기 중인 주문을 모두 취소합니다.
+// 대기 중인 주문은 잔고를 묶어두므로, GetBalance 의 InUse 가 있는 currency 의 시장만 확인합니다.
+// (e.g. KRW 가 묶여 있으면 모든 KRW 마켓, ETH 만 묶여 있으면 ETH_KRW, ETH_BTC)
+func (b *BithumbRequester) CancelAllEverywhereCtx(ctx context.Context) (CancelReport, error) {
+	balances, err := b.GetBalanceCtx(ctx, ALL)
+	if err != nil {
+		return CancelReport{}, err
+	}
+	inUse := func(currency Currency) bool {
+		balance, ok := balances[currency]
+		return ok && balance.InUse.Sign() > 0
+	}
+
+	var markets []Market
+	for _, paymentCurrency := range []Currency{KRW, BTC} {
+		tradable, err := b.GetTradableMarketsCtx(ctx, paymentCurrency)
+		if err != nil {
+			return CancelReport{}, err
+		}
+		for _, market := range tradable {
+			if inUse(paymentCurrency) || inUse(market.Base) {
+				markets = append(markets, market)
+			}
+		}
+	}
+
+	report := CancelReport{}
+	for _, market := range markets {
+		if ctx.Err() != nil {
+			report.Errors = append(report.Errors, ctx.Err())
+			break
+		}
+		cancelMarket(ctx, b, market, nil, &report)
+	}
+	return report, report.err()
+}
+
+// cancelMarket 은 market 의 주문을 GetOrder 로 가져와 취소하고, 결과를 report 에 더합니다.
+func cancelMarket(ctx context.Context, requester Exchange, market Market, sides []OrderSide, report *CancelReport) {
+	tried := make(map[string]bool)
+	for round := 0; round < cancelAllRounds; round++ {
+		orders, err := requester.GetOrderCtx(ctx, market.Base, market.Quote, 1000)
+		if err != nil && !errors.Is(err, ErrOrderNotFound) {
+			report.Errors = append(report.Errors, fmt.Errorf("%s: %w", market, err))
+			return
+		}
+
+		// 이미 취소를 시도한 주문은 다시 보내지 않음
+		var targets []Order
+		for _, data := range orders {
+			if !tried[data.OrderID] && matchSide(data.Type, sides) {
+				tried[data.OrderID] = true
+				targets = append(targets, data)
+			}
+		}
+		if len(targets) == 0 {
+			return
+		}
+		report.Results = append(report.Results, cancelOrders(ctx, requester, market, targets)...)
+	}
+}
+
+func matchSide(side OrderSide, sides []OrderSide) bool {
+	if len(sides) == 0 {
+		return true
+	}
+	for _, data := range sides {
+		if data == side {
+			return true
+		}
+	}
+	return false
+}
+
+// cancelOrders 는 orders 를 cancelConcurrency 개씩 동시에 취소합니다.
+// 취소는 같은 요청을 다시 보내도 안전하므로, 일시적인 오류는 재시도합니다.
+func cancelOrders(ctx context.Context, requester Exchange, market Market, orders []Order) []CancelResult {
+	retryCtx := ctx
+	if tradeRetryGuard(ctx) == nil {
+		retryCtx = WithTradeRetry(ctx, func(context.Context, string, map[string]string) (bool, error) { return true, nil })
+	}
+
+	results := make([]CancelResult, len(orders))
+	indexes := make(chan int)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < cancelConcurrency; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for index := range indexes {
+				order := orders[index]
+				err := requester.CancelOrderCtx(retryCtx, market.Base, market.Quote, order.OrderID, order.Type)
+				result := CancelResult{Market: market, Order: order, Err: err}
+				if errors.Is(err, ErrOrderNotFound) {
+					result.NotFound = true
+					result.Err = nil
+				}
+				results[index] = result
+			}
+		}()
+	}
+	for index := range orders {
+		indexes <- index
+	}
+	close(indexes)
+	waitGroup.Wait()
+	return results
+}
@@ -1,8 +1,16 @@
 package gobithumb
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Currency string
 type TimeInterval string
 type SearchType string
+type OrderSide string
+type OrderStatus string
+type CancelType string
 
 const (
 	AAVE    Currency = "aave"
@@ -231,6 +239,76 @@ const (
 	InKRWDeposit SearchType = "9"
 )
 
+const (
+	Bid OrderSide = "bid" // 매수
+	Ask OrderSide = "ask" // 매도
+
+	OrderPending   OrderStatus = "Pending"   // 체결 대기 (일부 체결 포함)
+	OrderCompleted OrderStatus = "Completed" // 전량 체결
+	OrderCancel    OrderStatus = "Cancel"    // 취소
+
+	CancelNone   CancelType = ""
+	CancelByUser CancelType = "사용자취소"
+)
+
+// ParseOrderSide 는 "bid", "ask" 를 OrderSide 로 바꿉니다. 대소문자는 구분하지 않습니다.
+func ParseOrderSide(raw string) (OrderSide, error) {
+	side := OrderSide(strings.ToLower(strings.TrimSpace(raw)))
+	if !side.Valid() {
+		return "", fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, raw)
+	}
+	return side, nil
+}
+
+func (o OrderSide) Valid() bool {
+	return o == Bid || o == Ask
+}
+
+// Opposite 는 반대 방향을 반환합니다. (bid <-> ask)
+func (o OrderSide) Opposite() OrderSide {
+	if o == Bid {
+		return Ask
+	}
+	return Bid
+}
+
+// ParseOrderStatus 는 "Pending", "Completed", "Cancel" 을 OrderStatus 로 바꿉니다. 대소문자는 구분하지 않습니다.
+func ParseOrderStatus(raw string) (OrderStatus, error) {
+	for _, status := range []OrderStatus{OrderPending, OrderCompleted, OrderCancel} {
+		if strings.EqualFold(strings.TrimSpace(raw), string(status)) {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("%w: 알 수 없는 주문 상태입니다. (%q)", ErrInvalidParameter, raw)
+}
+
+func (o OrderStatus) Valid() bool {
+	return o == OrderPending || o == OrderCompleted || o == OrderCancel
+}
+
+// Done 은 더 이상 체결될 수 없는 상태(전량 체결, 취소) 인지 반환합니다.
+func (o OrderStatus) Done() bool {
+	return o == OrderCompleted || o == OrderCancel
+}
+
+// parseSide, parseStatus 는 응답을 읽을 때 사용합니다. 알 수 없는 값은 버리지 않고 그대로 담아둡니다.
+func parseSide(raw string) OrderSide {
+	if side, err := ParseOrderSide(raw); err == nil {
+		return side
+	}
+	return OrderSide(raw)
+}
+
+func parseStatus(raw string) OrderStatus {
+	if status, err := ParseOrderStatus(raw); err == nil {
+		return status
+	}
+	return OrderStatus(raw)
+}
+
+// COIN_ALL 은 라이브러리 작성 당시의 상장 목록입니다. 상장 폐지된 coin 이 포함되어 있고 새 coin 은 빠져있습니다.
+//
+// Deprecated: 현재 상장 목록은 BithumbRequester.Markets() 의 MarketRegistry 를 사용하세요.
 func COIN_ALL() []Currency {
 	return []Currency{AAVE, ABT, ADA, ADD, ADP, AE, AION, ALGO, AMO, ANKR, ANV, ANW, AOA, APIS, APIX, APM, ARN, ARPA, ATD, ATOM, AUTO, AWO, BAL, BASIC, BAT, BCD, BCH, BCHA, BEL, BHP, BHPC, BIOT, BLACK, BLY, BNP, BOA, BORA, BSV, BTC, BTG, BTT, BXA, BZNT, CBK, CENNZ, CHL, CHR, CMT, COMP, CON, COS, COSM, CRO, CTXC, CVC, CVT, DAC, DACC, DAD, DASH, DOT, DVC, DVP, EGG, EL, ELF, EM, ENJ, EOS, EOSDAC, ETC, ETH, ETHOS, ETZ, EVT369L, EVZ, FAB, FCT, FIT, FLETA, FNB, FX, FZZ, GLM, GOM2, GRT, GTO, GXC, HC, HDAC, HIVE, HORUS, HSR, HYC, ICX, INS, IOST, IPX, ITC, ITG, JST, KEOS, KKKK, KNC, KRW, LAMB, LBA, LINK, LOOM, LRC, LTC, LUNA, MAN, MBL, MCI, MCO, MEETONE, META, MIR, MITH, MIX, MLK, MTL, MVC, MXC, NEWS, NPXS, OBSR, OCN, OGO, OMG, ONG, ONT, ONX, ORBS, ORC, PAY, PCH, PCM, PIVX, PLX, POLA, POLY, POWR, PPT, PST, QBZ, QKC, QTCON, QTUM, RDN, REN, REP, RINGX, RNT, ROM, SALT, SAND, SNT, SNX, SOC, SRM, SSX, STEEM, STRAX, SUN, SXP, TEMCO, TFUEL, THETA, TMTG, TRUE, TRV, TRX, UMA, UNI, VALOR, VEN, VET, VSYS, VTHO, WAVES, WAXP, WEMIX, WET, WICC, WIN, WOM, WOZX, WPX, WTC, XEM, XLM, XMR, XNO, XPR, XRP, XSR, XTZ, XVG, YFI, ZEC, ZIL, ZRX}
 }
@@ -9,32 +9,32 @@ import (
 //==============================TICKER SETTING======================================
 
 type Ticker struct {
-	OpeningPrice     float64
-	ClosingPrice     float64
-	MinPrice         float64
-	MaxPrice         float64
-	UnitsTraded      float64
-	AccTradeValue    float64
-	PrevClosingPrice float64
-	UnitsTraded24H   float64
-	AccTradeValue24H float64
-	Fluctate24H      float64
-	FluctateRate24H  float64
+	OpeningPrice     Decimal
+	ClosingPrice     Decimal
+	MinPrice         Decimal
+	MaxPrice         Decimal
+	UnitsTraded      Decimal
+	AccTradeValue    Decimal
+	PrevClosingPrice Decimal
+	UnitsTraded24H   Decimal
+	AccTradeValue24H Decimal
+	Fluctate24H      Decimal
+	FluctateRate24H  Decimal
 }
 
 func newTicker(rawTicker map[string]interface{}) Ticker {
 	newTicker := Ticker{}
-	newTicker.OpeningPrice, _ = strconv.ParseFloat(rawTicker["opening_price"].(string), 64)
-	newTicker.ClosingPrice, _ = strconv.ParseFloat(rawTicker["closing_price"].(string), 64)
-	newTicker.MinPrice, _ = strconv.ParseFloat(rawTicker["min_price"].(string), 64)
-	newTicker.MaxPrice, _ = strconv.ParseFloat(rawTicker["max_price"].(string), 64)
-	newTicker.UnitsTraded, _ = strconv.ParseFloat(rawTicker["units_traded"].(string), 64)
-	newTicker.AccTradeValue, _ = strconv.ParseFloat(rawTicker["acc_trade_value"].(string), 64)
-	newTicker.PrevClosingPrice, _ = strconv.ParseFloat(rawTicker["prev_closing_price"].(string), 64)
-	newTicker.UnitsTraded24H, _ = strconv.ParseFloat(rawTicker["units_traded_24H"].(string), 64)
-	newTicker.AccTradeValue24H, _ = strconv.ParseFloat(rawTicker["acc_trade_value_24H"].(string), 64)
-	newTicker.Fluctate24H, _ = strconv.ParseFloat(rawTicker["fluctate_24H"].(string), 64)
-	newTicker.FluctateRate24H, _ = strconv.ParseFloat(rawTicker["fluctate_rate_24H"].(string), 64)
+	newTicker.OpeningPrice = toDecimal(rawTicker["opening_price"])
+	newTicker.ClosingPrice = toDecimal(rawTicker["closing_price"])
+	newTicker.MinPrice = toDecimal(rawTicker["min_price"])
+	newTicker.MaxPrice = toDecimal(rawTicker["max_price"])
+	newTicker.UnitsTraded = toDecimal(rawTicker["units_traded"])
+	newTicker.AccTradeValue = toDecimal(rawTicker["acc_trade_value"])
+	newTicker.PrevClosingPrice = toDecimal(rawTicker["prev_closing_price"])
+	newTicker.UnitsTraded24H = toDecimal(rawTicker["units_traded_24H"])
+	newTicker.AccTradeValue24H = toDecimal(rawTicker["acc_trade_value_24H"])
+	newTicker.Fluctate24H = toDecimal(rawTicker["fluctate_24H"])
+	newTicker.FluctateRate24H = toDecimal(rawTicker["fluctate_rate_24H"])
 
 	return newTicker
 }
@@ -42,8 +42,8 @@ func newTicker(rawTicker map[string]interface{}) Ticker {
 //==============================ORDERBOOK SETTING======================================
 
 type Bidask struct {
-	Price    float64
-	Quantity float64
+	Price    Decimal
+	Quantity Decimal
 }
 
 type Orderbook struct {
@@ -54,20 +54,20 @@ type Orderbook struct {
 func newOrderbook(rawOrderbook map[string]interface{}) Orderbook {
 	newOrderbook := Orderbook{}
 
-	bids := rawOrderbook["bids"].([]interface{})
-	asks := rawOrderbook["asks"].([]interface{})
+	bids := toSlice(rawOrderbook["bids"])
+	asks := toSlice(rawOrderbook["asks"])
 
 	newOrderbook.Bids = make([]Bidask, len(bids))
 	newOrderbook.Asks = make([]Bidask, len(asks))
 	for index, data := range bids {
-		oneBid := data.(map[string]interface{})
-		newOrderbook.Bids[index].Price, _ = strconv.ParseFloat(oneBid["price"].(string), 64)
-		newOrderbook.Bids[index].Quantity, _ = strconv.ParseFloat(oneBid["quantity"].(string), 64)
+		oneBid := toMap(data)
+		newOrderbook.Bids[index].Price = toDecimal(oneBid["price"])
+		newOrderbook.Bids[index].Quantity = toDecimal(oneBid["quantity"])
 	}
 	for index, data := range asks {
-		oneAsk := data.(map[string]interface{})
-		newOrderbook.Asks[index].Price, _ = strconv.ParseFloat(oneAsk["price"].(string), 64)
-		newOrderbook.Asks[index].Quantity, _ = strconv.ParseFloat(oneAsk["quantity"].(string), 64)
+		oneAsk := toMap(data)
+		newOrderbook.Asks[index].Price = toDecimal(oneAsk["price"])
+		newOrderbook.Asks[index].Quantity = toDecimal(oneAsk["quantity"])
 	}
 	return newOrderbook
 }
@@ -76,10 +76,10 @@ func newOrderbook(rawOrderbook map[string]interface{}) Orderbook {
 
 type OneTransaction struct {
 	TransactionDate time.Time
-	Type            string
-	UnitsTraded     float64
-	Price           float64
-	Total           float64
+	Type            OrderSide
+	UnitsTraded     Decimal
+	Price           Decimal
+	Total           Decimal
 }
 
 const trTimeForm = "2006-01-02 15:04:05"
@@ -87,12 +87,12 @@ const trTimeForm = "2006-01-02 15:04:05"
 func newTransactionHistory(rawTransactionHistory []interface{}) []OneTransaction {
 	result := make([]OneTransaction, len(rawTransactionHistory))
 	for index, data := range rawTransactionHistory {
-		dataMap := data.(map[string]interface{})
-		result[index].UnitsTraded, _ = strconv.ParseFloat(dataMap["units_traded"].(string), 64)
-		result[index].Price, _ = strconv.ParseFloat(dataMap["price"].(string), 64)
-		result[index].Total, _ = strconv.ParseFloat(dataMap["total"].(string), 64)
-		result[index].TransactionDate, _ = time.Parse(trTimeForm, dataMap["transaction_date"].(string))
-		result[index].Type, _ = dataMap["type"].(string)
+		dataMap := toMap(data)
+		result[index].UnitsTraded = toDecimal(dataMap["units_traded"])
+		result[index].Price = toDecimal(dataMap["price"])
+		result[index].Total = toDecimal(dataMap["total"])
+		result[index].TransactionDate, _ = time.Parse(trTimeForm, toString(dataMap["transaction_date"]))
+		result[index].Type = parseSide(toString(dataMap["type"]))
 	}
 	return result
 }
@@ -113,16 +113,16 @@ type BTCI struct {
 }
 
 func newBTCI(rawBTAI, rawBTMI interface{}) BTCI {
-	btai := rawBTAI.(map[string]interface{})
-	btmi := rawBTMI.(map[string]interface{})
+	btai := toMap(rawBTAI)
+	btmi := toMap(rawBTMI)
 
 	newBTCI := BTCI{}
-	newBTCI.BTAI.MarketIndex, _ = strconv.ParseFloat(btai["market_index"].(string), 64)
-	newBTCI.BTAI.Rate, _ = strconv.ParseFloat(btai["rate"].(string), 64)
-	newBTCI.BTAI.Width, _ = strconv.ParseFloat(btai["width"].(string), 64)
-	newBTCI.BTMI.MarketIndex, _ = strconv.ParseFloat(btmi["market_index"].(string), 64)
-	newBTCI.BTMI.Rate, _ = strconv.ParseFloat(btmi["rate"].(string), 64)
-	newBTCI.BTMI.Width, _ = strconv.ParseFloat(btmi["width"].(string), 64)
+	newBTCI.BTAI.MarketIndex = toFloat(btai["market_index"])
+	newBTCI.BTAI.Rate = toFloat(btai["rate"])
+	newBTCI.BTAI.Width = toFloat(btai["width"])
+	newBTCI.BTMI.MarketIndex = toFloat(btmi["market_index"])
+	newBTCI.BTMI.Rate = toFloat(btmi["rate"])
+	newBTCI.BTMI.Width = toFloat(btmi["width"])
 	return newBTCI
 }
 
@@ -136,22 +136,28 @@ type RawCandleStick struct {
 
 type OneCandleStick struct {
 	Time         time.Time
-	OpeningPrice float64
-	ClosingPrice float64
-	HighPrice    float64
-	LowPrice     float64
-	UnitsTraded  float64
+	OpeningPrice Decimal
+	ClosingPrice Decimal
+	HighPrice    Decimal
+	LowPrice     Decimal
+	UnitsTraded  Decimal
 }
 
 func newCandleStick(rawCandleStick RawCandleStick) []OneCandleStick {
-	candleStick := make([]OneCandleStick, len(rawCandleStick.Data))
-	for index, data := range rawCandleStick.Data {
-		candleStick[index].Time = milliStringToTime(strconv.FormatInt(int64(int(data[0].(float64))), 10))
-		candleStick[index].OpeningPrice, _ = strconv.ParseFloat(data[1].(string), 64)
-		candleStick[index].ClosingPrice, _ = strconv.ParseFloat(data[2].(string), 64)
-		candleStick[index].HighPrice, _ = strconv.ParseFloat(data[3].(string), 64)
-		candleStick[index].LowPrice, _ = strconv.ParseFloat(data[4].(string), 64)
-		candleStick[index].UnitsTraded, _ = strconv.ParseFloat(data[5].(string), 64)
+	candleStick := make([]OneCandleStick, 0, len(rawCandleStick.Data))
+	for _, data := range rawCandleStick.Data {
+		// 값이 모자란 행은 버림
+		if len(data) < 6 {
+			continue
+		}
+		oneCandleStick := OneCandleStick{}
+		oneCandleStick.Time = milliStringToTime(strconv.FormatInt(int64(toFloat(data[0])), 10))
+		oneCandleStick.OpeningPrice = toDecimal(data[1])
+		oneCandleStick.ClosingPrice = toDecimal(data[2])
+		oneCandleStick.HighPrice = toDecimal(data[3])
+		oneCandleStick.LowPrice = toDecimal(data[4])
+		oneCandleStick.UnitsTraded = toDecimal(data[5])
+		candleStick = append(candleStick, oneCandleStick)
 	}
 	return candleStick
 }
@@ -167,66 +173,82 @@ func newCandleStick(rawCandleStick RawCandleStick) []OneCandleStick {
 type Account struct {
 	ID       string
 	Created  time.Time
-	Balance  float64
-	TradeFee float64
+	Balance  Decimal
+	TradeFee Decimal
 }
 
 func newAccount(rawAccount map[string]interface{}) Account {
 	newAccount := Account{}
-	newAccount.ID = rawAccount["account_id"].(string)
-	newAccount.Created = milliStringToTime(rawAccount["created"].(string))
-	newAccount.Balance, _ = strconv.ParseFloat(rawAccount["balance"].(string), 64)
-	newAccount.TradeFee, _ = strconv.ParseFloat(rawAccount["trade_fee"].(string), 64)
+	newAccount.ID = toString(rawAccount["account_id"])
+	newAccount.Created = milliStringToTime(toString(rawAccount["created"]))
+	newAccount.Balance = toDecimal(rawAccount["balance"])
+	newAccount.TradeFee = toDecimal(rawAccount["trade_fee"])
 	return newAccount
 }
 
 //==============================BALANCE SETTING======================================
 
 type Balance struct {
-	Total     float64
-	InUse     float64
-	Available float64
-	XCoinLast float64
+	Total     Decimal
+	InUse     Decimal
+	Available Decimal
+	XCoinLast Decimal
 }
 
-func newBalance(rawBalance map[string]interface{}, coin string) *Balance {
-	newBalance := Balance{}
-	newBalance.Total, _ = strconv.ParseFloat(rawBalance["total_"+coin].(string), 64)
-	newBalance.InUse, _ = strconv.ParseFloat(rawBalance["in_use_"+coin].(string), 64)
-	newBalance.Available, _ = strconv.ParseFloat(rawBalance["available_"+coin].(string), 64)
-	if coin != "krw" {
-		newBalance.XCoinLast, _ = strconv.ParseFloat(rawBalance["xcoin_last_"+coin].(string), 64)
+// newBalances 는 total_, in_use_, available_, xcoin_last_ 로 시작하는 key 들을 currency 별 잔고로 묶습니다.
+// xcoin_last_ 는 KRW 기준 마지막 체결가이며, KRW 에는 없습니다.
+func newBalances(rawBalance map[string]interface{}) map[Currency]*Balance {
+	result := make(map[Currency]*Balance)
+	for index, data := range rawBalance {
+		coin, value := rawBalanceStringToBalance(index)
+		if value == 0 {
+			continue
+		}
+		currency := Currency(strings.ToLower(coin))
+		if _, ok := result[currency]; !ok {
+			result[currency] = &Balance{}
+		}
+		switch value {
+		case 1:
+			result[currency].Total = toDecimal(data)
+		case 2:
+			result[currency].InUse = toDecimal(data)
+		case 3:
+			result[currency].Available = toDecimal(data)
+		case 4:
+			result[currency].XCoinLast = toDecimal(data)
+		}
 	}
-	return &newBalance
+	return result
 }
 
 //==============================BALANCE SETTING======================================
 
 type UserTicker struct {
-	OpeningPrice    float64
-	ClosingPrice    float64
-	AveragePrice    float64
-	MaxPrice        float64
-	MinPrice        float64
-	UnitsTraded     float64
-	Volume1Day      float64
-	Volume7Day      float64
-	Fluctate24H     float64
-	FluctateRate24H float64
+	OpeningPrice    Decimal
+	ClosingPrice    Decimal
+	AveragePrice    Decimal
+	MaxPrice        Decimal
+	MinPrice        Decimal
+	UnitsTraded     Decimal
+	Volume1Day      Decimal
+	Volume7Day      Decimal
+	Fluctate24H     Decimal
+	FluctateRate24H Decimal
 }
 
 func newUserTicker(rawUserTicker map[string]interface{}) UserTicker {
 	newUserTicker := UserTicker{}
-	newUserTicker.OpeningPrice, _ = strconv.ParseFloat(rawUserTicker["opening_price"].(string), 64)
-	newUserTicker.ClosingPrice, _ = strconv.ParseFloat(rawUserTicker["closing_price"].(string), 64)
-	newUserTicker.AveragePrice, _ = strconv.ParseFloat(rawUserTicker["average_price"].(string), 64)
-	newUserTicker.MinPrice, _ = strconv.ParseFloat(rawUserTicker["min_price"].(string), 64)
-	newUserTicker.MaxPrice, _ = strconv.ParseFloat(rawUserTicker["max_price"].(string), 64)
-	newUserTicker.UnitsTraded, _ = strconv.ParseFloat(rawUserTicker["units_traded"].(string), 64)
-	newUserTicker.Volume1Day, _ = strconv.ParseFloat(rawUserTicker["volume_1day"].(string), 64)
-	newUserTicker.Volume7Day, _ = strconv.ParseFloat(rawUserTicker["volume_7day"].(string), 64)
-	newUserTicker.Fluctate24H, _ = strconv.ParseFloat(rawUserTicker["fluctate_24H"].(string), 64)
-	newUserTicker.FluctateRate24H, _ = strconv.ParseFloat(rawUserTicker["fluctate_rate_24H"].(string), 64)
+	newUserTicker.OpeningPrice = toDecimal(rawUserTicker["opening_price"])
+	newUserTicker.ClosingPrice = toDecimal(rawUserTicker["closing_price"])
+	newUserTicker.AveragePrice = toDecimal(rawUserTicker["average_price"])
+	newUserTicker.MinPrice = toDecimal(rawUserTicker["min_price"])
+	newUserTicker.MaxPrice = toDecimal(rawUserTicker["max_price"])
+	newUserTicker.UnitsTraded = toDecimal(rawUserTicker["units_traded"])
+	newUserTicker.Volume1Day = toDecimal(rawUserTicker["volume_1day"])
+	newUserTicker.Volume7Day = toDecimal(rawUserTicker["volume_7day"])
+	newUserTicker.Fluctate24H = toDecimal(rawUserTicker["fluctate_24H"])
+	newUserTicker.FluctateRate24H = toDecimal(rawUserTicker["fluctate_rate_24H"])
 	return newUserTicker
 }
 
@@ -237,24 +259,24 @@ type Order struct {
 	OrderCurrency   Currency
 	PaymentCurrency Currency
 	OrderID         string
-	Price           float64
-	Type            string
-	Units           float64
-	UnitsRemaining  float64
-	WatchPrice      float64
+	Price           Decimal
+	Type            OrderSide
+	Units           Decimal
+	UnitsRemaining  Decimal
+	WatchPrice      Decimal
 }
 
 func newOrder(rawOrder map[string]interface{}) Order {
 	newOrder := Order{}
-	newOrder.OrderDate = microStringToTime(rawOrder["order_date"].(string))
-	newOrder.OrderCurrency = Currency(strings.ToLower(rawOrder["order_currency"].(string)))
-	newOrder.PaymentCurrency = Currency(strings.ToLower(rawOrder["payment_currency"].(string)))
-	newOrder.OrderID = rawOrder["order_id"].(string)
-	newOrder.Price, _ = strconv.ParseFloat(rawOrder["price"].(string), 64)
-	newOrder.Type = rawOrder["type"].(string)
-	newOrder.Units, _ = strconv.ParseFloat(rawOrder["units"].(string), 64)
-	newOrder.UnitsRemaining, _ = strconv.ParseFloat(rawOrder["units_remaining"].(string), 64)
-	newOrder.WatchPrice, _ = strconv.ParseFloat(rawOrder["watch_price"].(string), 64)
+	newOrder.OrderDate = microStringToTime(toString(rawOrder["order_date"]))
+	newOrder.OrderCurrency = Currency(strings.ToLower(toString(rawOrder["order_currency"])))
+	newOrder.PaymentCurrency = Currency(strings.ToLower(toString(rawOrder["payment_currency"])))
+	newOrder.OrderID = toString(rawOrder["order_id"])
+	newOrder.Price = toDecimal(rawOrder["price"])
+	newOrder.Type = parseSide(toString(rawOrder["type"]))
+	newOrder.Units = toDecimal(rawOrder["units"])
+	newOrder.UnitsRemaining = toDecimal(rawOrder["units_remaining"])
+	newOrder.WatchPrice = toDecimal(rawOrder["watch_price"])
 	return newOrder
 }
 
@@ -262,49 +284,49 @@ func newOrder(rawOrder map[string]interface{}) Order {
 
 type SingleOrderDetail struct {
 	TransactionDate time.Time
-	Price           float64
-	Units           float64
+	Price           Decimal
+	Units           Decimal
 	FeeCurrency     Currency
-	Fee             float64
-	Total           float64
+	Fee             Decimal
+	Total           Decimal
 }
 
 type OrderDetail struct {
 	OrderDate       time.Time
-	Type            string
-	OrderStatus     string
+	Type            OrderSide
+	OrderStatus     OrderStatus
 	OrderCurrency   Currency
 	PaymentCurrency Currency
-	OrderPrice      float64
-	OrderQty        float64
+	OrderPrice      Decimal
+	OrderQty        Decimal
 	CancelDate      time.Time
-	CancelType      string
+	CancelType      CancelType
 	Contract        []SingleOrderDetail
 }
 
 func newOrderDetail(newOrderDetail OrderDetail, rawOrderDetail map[string]interface{}) OrderDetail {
-	newOrderDetail.OrderDate = microStringToTime(rawOrderDetail["order_date"].(string))
-	newOrderDetail.Type = rawOrderDetail["type"].(string)
-	newOrderDetail.OrderStatus = rawOrderDetail["order_status"].(string)
-	newOrderDetail.OrderCurrency = Currency(strings.ToLower(rawOrderDetail["order_currency"].(string)))
-	newOrderDetail.PaymentCurrency = Currency(strings.ToLower(rawOrderDetail["payment_currency"].(string)))
-	newOrderDetail.OrderPrice, _ = strconv.ParseFloat(rawOrderDetail["order_price"].(string), 64)
-	newOrderDetail.OrderQty, _ = strconv.ParseFloat(rawOrderDetail["payment_currency"].(string), 64)
-	if rawOrderDetail["cancel_date"].(string) != "" {
-		newOrderDetail.CancelDate = microStringToTime(rawOrderDetail["cancel_date"].(string))
+	newOrderDetail.OrderDate = microStringToTime(toString(rawOrderDetail["order_date"]))
+	newOrderDetail.Type = parseSide(toString(rawOrderDetail["type"]))
+	newOrderDetail.OrderStatus = parseStatus(toString(rawOrderDetail["order_status"]))
+	newOrderDetail.OrderCurrency = Currency(strings.ToLower(toString(rawOrderDetail["order_currency"])))
+	newOrderDetail.PaymentCurrency = Currency(strings.ToLower(toString(rawOrderDetail["payment_currency"])))
+	newOrderDetail.OrderPrice = toDecimal(rawOrderDetail["order_price"])
+	newOrderDetail.OrderQty = toDecimal(rawOrderDetail["order_qty"])
+	if toString(rawOrderDetail["cancel_date"]) != "" {
+		newOrderDetail.CancelDate = microStringToTime(toString(rawOrderDetail["cancel_date"]))
 	}
-	newOrderDetail.CancelType = rawOrderDetail["cancel_type"].(string)
+	newOrderDetail.CancelType = CancelType(toString(rawOrderDetail["cancel_type"]))
 
-	contracts := rawOrderDetail["contract"].([]interface{})
+	contracts := toSlice(rawOrderDetail["contract"])
 	newOrderDetail.Contract = make([]SingleOrderDetail, len(contracts))
 	for index, data := range contracts {
-		singleContract := data.(map[string]interface{})
-		newOrderDetail.Contract[index].TransactionDate = microStringToTime(singleContract["transaction_date"].(string))
-		newOrderDetail.Contract[index].Price, _ = strconv.ParseFloat(singleContract["price"].(string), 64)
-		newOrderDetail.Contract[index].Units, _ = strconv.ParseFloat(singleContract["units"].(string), 64)
-		newOrderDetail.Contract[index].FeeCurrency = Currency(strings.ToLower(singleContract["fee_currency"].(string)))
-		newOrderDetail.Contract[index].Fee, _ = strconv.ParseFloat(singleContract["fee"].(string), 64)
-		newOrderDetail.Contract[index].Total, _ = strconv.ParseFloat(singleContract["total"].(string), 64)
+		singleContract := toMap(data)
+		newOrderDetail.Contract[index].TransactionDate = microStringToTime(toString(singleContract["transaction_date"]))
+		newOrderDetail.Contract[index].Price = toDecimal(singleContract["price"])
+		newOrderDetail.Contract[index].Units = toDecimal(singleContract["units"])
+		newOrderDetail.Contract[index].FeeCurrency = Currency(strings.ToLower(toString(singleContract["fee_currency"])))
+		newOrderDetail.Contract[index].Fee = toDecimal(singleContract["fee"])
+		newOrderDetail.Contract[index].Total = toDecimal(singleContract["total"])
 	}
 
 	return newOrderDetail
@@ -317,28 +339,28 @@ type Transaction struct {
 	TransferDate    time.Time
 	OrderCurrency   Currency
 	PaymentCurrency Currency
-	Units           float64
-	Price           float64
-	Amount          float64
+	Units           Decimal
+	Price           Decimal
+	Amount          Decimal
 	FeeCurrency     Currency
-	Fee             float64
-	OrderBalance    float64
-	PaymentBalance  float64
+	Fee             Decimal
+	OrderBalance    Decimal
+	PaymentBalance  Decimal
 }
 
 func newTransaction(rawTransaction map[string]interface{}) Transaction {
 	newTransaction := Transaction{}
-	newTransaction.Search = SearchType(rawTransaction["search"].(string))
-	newTransaction.TransferDate = microStringToTime(rawTransaction["transfer_date"].(string))
-	newTransaction.OrderCurrency = Currency(strings.ToLower(rawTransaction["order_currency"].(string)))
-	newTransaction.PaymentCurrency = Currency(strings.ToLower(rawTransaction["payment_currency"].(string)))
-	newTransaction.FeeCurrency = Currency(strings.ToLower(rawTransaction["fee_currency"].(string)))
-	newTransaction.Units, _ = strconv.ParseFloat(rawTransaction["units"].(string), 64)
-	newTransaction.Price, _ = strconv.ParseFloat(rawTransaction["price"].(string), 64)
-	newTransaction.Amount, _ = strconv.ParseFloat(rawTransaction["amount"].(string), 64)
-	newTransaction.Fee, _ = strconv.ParseFloat(rawTransaction["fee"].(string), 64)
-	newTransaction.OrderBalance, _ = strconv.ParseFloat(rawTransaction["order_balance"].(string), 64)
-	newTransaction.PaymentBalance, _ = strconv.ParseFloat(rawTransaction["payment_balance"].(string), 64)
+	newTransaction.Search = SearchType(toString(rawTransaction["search"]))
+	newTransaction.TransferDate = microStringToTime(toString(rawTransaction["transfer_date"]))
+	newTransaction.OrderCurrency = Currency(strings.ToLower(toString(rawTransaction["order_currency"])))
+	newTransaction.PaymentCurrency = Currency(strings.ToLower(toString(rawTransaction["payment_currency"])))
+	newTransaction.FeeCurrency = Currency(strings.ToLower(toString(rawTransaction["fee_currency"])))
+	newTransaction.Units = toDecimal(rawTransaction["units"])
+	newTransaction.Price = toDecimal(rawTransaction["price"])
+	newTransaction.Amount = toDecimal(rawTransaction["amount"])
+	newTransaction.Fee = toDecimal(rawTransaction["fee"])
+	newTransaction.OrderBalance = toDecimal(rawTransaction["order_balance"])
+	newTransaction.PaymentBalance = toDecimal(rawTransaction["payment_balance"])
 	return newTransaction
 }
 
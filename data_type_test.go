@@ -0,0 +1,23 @@
+package gobithumb
+
+import "testing"
+
+func TestNewCandleStickSkipsShortRows(t *testing.T) {
+	raw := RawCandleStick{Data: [][]interface{}{
+		{float64(1700000000000), "100", "110", "120", "90", "1.5"},
+		{float64(1700000060000), "110", "105"},
+		{float64(1700000120000), "105", "108", "109", "104", "0.25"},
+	}}
+	result := newCandleStick(raw)
+	if len(result) != 2 {
+		t.Fatalf("len = %d, want 2", len(result))
+	}
+	for index, want := range []struct {
+		time    int64
+		closing string
+	}{{1700000000, "110"}, {1700000120, "108"}} {
+		if result[index].Time.Unix() != want.time || result[index].ClosingPrice.String() != want.closing {
+			t.Errorf("candle[%d] = %s %s, want %d %s", index, result[index].Time, result[index].ClosingPrice, want.time, want.closing)
+		}
+	}
+}
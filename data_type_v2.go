@@ -0,0 +1,239 @@
+package gobithumb
+
+import (
+	"strings"
+	"time"
+)
+
+// API 2.0 (/v1/*) 응답 타입입니다.
+
+//==============================V2 MARKET DATA======================================
+
+type V2Market struct {
+	Market      Market
+	KoreanName  string
+	EnglishName string
+	Warning     bool // 투자유의 종목이면 true
+}
+
+func newV2Market(rawMarket map[string]interface{}) V2Market {
+	newMarket := V2Market{}
+	newMarket.Market = parseV2Market(toString(rawMarket["market"]))
+	newMarket.KoreanName = toString(rawMarket["korean_name"])
+	newMarket.EnglishName = toString(rawMarket["english_name"])
+	newMarket.Warning = toString(rawMarket["market_warning"]) == "CAUTION"
+	return newMarket
+}
+
+// V2CandleUnit 은 /v1/candles 의 캔들 단위입니다.
+type V2CandleUnit string
+
+const (
+	V2Minute1  V2CandleUnit = "minutes/1"
+	V2Minute3  V2CandleUnit = "minutes/3"
+	V2Minute5  V2CandleUnit = "minutes/5"
+	V2Minute10 V2CandleUnit = "minutes/10"
+	V2Minute15 V2CandleUnit = "minutes/15"
+	V2Minute30 V2CandleUnit = "minutes/30"
+	V2Hour1    V2CandleUnit = "minutes/60"
+	V2Hour4    V2CandleUnit = "minutes/240"
+	V2Day      V2CandleUnit = "days"
+	V2Week     V2CandleUnit = "weeks"
+	V2Month    V2CandleUnit = "months"
+)
+
+func (u V2CandleUnit) Valid() bool {
+	switch u {
+	case V2Minute1, V2Minute3, V2Minute5, V2Minute10, V2Minute15, V2Minute30, V2Hour1, V2Hour4, V2Day, V2Week, V2Month:
+		return true
+	}
+	return false
+}
+
+// V2Candle 의 Time 은 캔들이 시작하는 시각 (UTC) 입니다.
+type V2Candle struct {
+	Market Market
+	Time   time.Time
+	Open   Decimal
+	High   Decimal
+	Low    Decimal
+	Close  Decimal
+	Volume Decimal
+	Value  Decimal // 누적 거래 금액
+}
+
+func newV2Candle(rawCandle map[string]interface{}) V2Candle {
+	newCandle := V2Candle{}
+	newCandle.Market = parseV2Market(toString(rawCandle["market"]))
+	newCandle.Time, _ = time.ParseInLocation("2006-01-02T15:04:05", toString(rawCandle["candle_date_time_utc"]), time.UTC)
+	newCandle.Open = toDecimal(rawCandle["opening_price"])
+	newCandle.High = toDecimal(rawCandle["high_price"])
+	newCandle.Low = toDecimal(rawCandle["low_price"])
+	newCandle.Close = toDecimal(rawCandle["trade_price"])
+	newCandle.Volume = toDecimal(rawCandle["candle_acc_trade_volume"])
+	newCandle.Value = toDecimal(rawCandle["candle_acc_trade_price"])
+	return newCandle
+}
+
+//==============================V2 ACCOUNT======================================
+
+// V2Account 의 Balance 는 주문 가능 수량, Locked 는 주문 중인 수량입니다.
+type V2Account struct {
+	Currency     Currency
+	UnitCurrency Currency
+	Balance      Decimal
+	Locked       Decimal
+	AvgBuyPrice  Decimal
+}
+
+func newV2Account(rawAccount map[string]interface{}) V2Account {
+	newAccount := V2Account{}
+	newAccount.Currency = Currency(strings.ToLower(toString(rawAccount["currency"])))
+	newAccount.UnitCurrency = Currency(strings.ToLower(toString(rawAccount["unit_currency"])))
+	newAccount.Balance = toDecimal(rawAccount["balance"])
+	newAccount.Locked = toDecimal(rawAccount["locked"])
+	newAccount.AvgBuyPrice = toDecimal(rawAccount["avg_buy_price"])
+	return newAccount
+}
+
+// balance 는 legacy API 의 Balance 형태로 바꿉니다.
+func (a V2Account) balance() *Balance {
+	return &Balance{Total: a.Balance.Add(a.Locked), InUse: a.Locked, Available: a.Balance}
+}
+
+//==============================V2 ORDER======================================
+
+// V2OrderType 은 API 2.0 의 주문 방식입니다.
+// V2OrderLimit 은 지정가, V2OrderPrice 는 총액을 지정하는 시장가 매수, V2OrderMarket 은 수량을 지정하는 시장가 매도입니다.
+type V2OrderType string
+
+const (
+	V2OrderLimit  V2OrderType = "limit"
+	V2OrderPrice  V2OrderType = "price"
+	V2OrderMarket V2OrderType = "market"
+)
+
+// V2OrderState 는 API 2.0 의 주문 상태입니다. V2Watch 는 예약 주문이 감시가격을 기다리는 상태입니다.
+type V2OrderState string
+
+const (
+	V2Wait   V2OrderState = "wait"
+	V2Watch  V2OrderState = "watch"
+	V2Done   V2OrderState = "done"
+	V2Cancel V2OrderState = "cancel"
+)
+
+// Status 는 legacy API 의 OrderStatus 로 바꿉니다.
+func (s V2OrderState) Status() OrderStatus {
+	switch s {
+	case V2Wait, V2Watch:
+		return OrderPending
+	case V2Done:
+		return OrderCompleted
+	case V2Cancel:
+		return OrderCancel
+	}
+	return OrderStatus(s)
+}
+
+// V2OrderRequest 는 /v1/orders 로 보낼 주문입니다.
+// V2OrderLimit 은 Volume, Price 가, V2OrderPrice 는 Price (매수 총액, Bid) 가, V2OrderMarket 은 Volume (Ask) 이 필요합니다.
+type V2OrderRequest struct {
+	Market  Market
+	Side    OrderSide
+	OrdType V2OrderType
+	Volume  Decimal
+	Price   Decimal
+}
+
+type V2Order struct {
+	UUID            string
+	Market          Market
+	Side            OrderSide
+	OrdType         V2OrderType
+	State           V2OrderState
+	Price           Decimal
+	Volume          Decimal
+	RemainingVolume Decimal
+	ExecutedVolume  Decimal
+	Locked          Decimal
+	PaidFee         Decimal
+	CreatedAt       time.Time
+	Trades          []V2Trade
+}
+
+type V2Trade struct {
+	UUID      string
+	Price     Decimal
+	Volume    Decimal
+	Funds     Decimal
+	CreatedAt time.Time
+}
+
+func newV2Order(rawOrder map[string]interface{}) V2Order {
+	newOrder := V2Order{}
+	newOrder.UUID = toString(rawOrder["uuid"])
+	newOrder.Market = parseV2Market(toString(rawOrder["market"]))
+	newOrder.Side = parseSide(toString(rawOrder["side"]))
+	newOrder.OrdType = V2OrderType(toString(rawOrder["ord_type"]))
+	newOrder.State = V2OrderState(toString(rawOrder["state"]))
+	newOrder.Price = toDecimal(rawOrder["price"])
+	newOrder.Volume = toDecimal(rawOrder["volume"])
+	newOrder.RemainingVolume = toDecimal(rawOrder["remaining_volume"])
+	newOrder.ExecutedVolume = toDecimal(rawOrder["executed_volume"])
+	newOrder.Locked = toDecimal(rawOrder["locked"])
+	newOrder.PaidFee = toDecimal(rawOrder["paid_fee"])
+	newOrder.CreatedAt, _ = time.Parse(time.RFC3339, toString(rawOrder["created_at"]))
+
+	for _, data := range toSlice(rawOrder["trades"]) {
+		rawTrade := toMap(data)
+		trade := V2Trade{}
+		trade.UUID = toString(rawTrade["uuid"])
+		trade.Price = toDecimal(rawTrade["price"])
+		trade.Volume = toDecimal(rawTrade["volume"])
+		trade.Funds = toDecimal(rawTrade["funds"])
+		trade.CreatedAt, _ = time.Parse(time.RFC3339, toString(rawTrade["created_at"]))
+		newOrder.Trades = append(newOrder.Trades, trade)
+	}
+	return newOrder
+}
+
+// order 는 legacy API 의 Order 형태로 바꿉니다.
+func (o V2Order) order() Order {
+	return Order{
+		OrderDate:       o.CreatedAt,
+		OrderCurrency:   o.Market.Base,
+		PaymentCurrency: o.Market.Quote,
+		OrderID:         o.UUID,
+		Price:           o.Price,
+		Type:            o.Side,
+		Units:           o.Volume,
+		UnitsRemaining:  o.RemainingVolume,
+	}
+}
+
+// orderDetail 은 legacy API 의 OrderDetail 형태로 바꿉니다. API 2.0 은 체결별 수수료를 주지 않으므로 Fee 는 0 입니다.
+func (o V2Order) orderDetail() OrderDetail {
+	result := OrderDetail{
+		OrderDate:       o.CreatedAt,
+		Type:            o.Side,
+		OrderStatus:     o.State.Status(),
+		OrderCurrency:   o.Market.Base,
+		PaymentCurrency: o.Market.Quote,
+		OrderPrice:      o.Price,
+		OrderQty:        o.Volume,
+		CancelType:      CancelNone,
+	}
+	if o.State == V2Cancel {
+		result.CancelType = CancelByUser
+	}
+	result.Contract = make([]SingleOrderDetail, len(o.Trades))
+	for index, data := range o.Trades {
+		result.Contract[index].TransactionDate = data.CreatedAt
+		result.Contract[index].Price = data.Price
+		result.Contract[index].Units = data.Volume
+		result.Contract[index].FeeCurrency = o.Market.Quote
+		result.Contract[index].Total = data.Funds
+	}
+	return result
+}
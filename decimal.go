@@ -0,0 +1,295 @@
+package gobithumb
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal 은 가격, 수량, 잔고를 오차 없이 다루기 위한 10진 고정소수점 수입니다.
+// value * 10^-scale 의 값을 가지며, zero value 는 0 입니다.
+// 모든 연산은 새 값을 반환하므로 여러 goroutine 에서 함께 사용해도 안전합니다.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+// errInvalidDecimal 은 errors.Is(err, ErrInvalidParameter) 로 확인할 수 있습니다.
+var errInvalidDecimal = fmt.Errorf("%w: invalid decimal", ErrInvalidParameter)
+
+// maxDecimalExponent 는 ParseDecimal 이 받는 지수의 최대 크기입니다. "1e1000000000" 같은 값으로 큰 메모리를 쓰지 않도록 제한합니다.
+const maxDecimalExponent = 1000
+
+var bigTen = big.NewInt(10)
+
+func NewDecimal(value int64, scale int32) Decimal {
+	return Decimal{value: big.NewInt(value), scale: scale}.normalizeScale()
+}
+
+func NewDecimalFromInt(value int64) Decimal {
+	return Decimal{value: big.NewInt(value)}
+}
+
+// NewDecimalFromFloat 은 float64 를 가장 짧은 10진 표현으로 바꿉니다. (e.g. 0.1 -> "0.1")
+// NaN, Inf 는 0 이 됩니다.
+func NewDecimalFromFloat(value float64) Decimal {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Decimal{}
+	}
+	result, _ := ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	return result
+}
+
+// ParseDecimal 은 "123", "-0.00012345", "1.5e-7" 형태의 문자열을 읽습니다.
+// 잘못된 문자열이거나 지수가 maxDecimalExponent 보다 크면 ErrInvalidParameter 를 반환합니다.
+func ParseDecimal(raw string) (Decimal, error) {
+	text := strings.TrimSpace(raw)
+
+	exponent := 0
+	if index := strings.IndexAny(text, "eE"); index >= 0 {
+		parsed, err := strconv.Atoi(text[index+1:])
+		if err != nil || parsed > maxDecimalExponent || parsed < -maxDecimalExponent {
+			return Decimal{}, errInvalidDecimal
+		}
+		exponent = parsed
+		text = text[:index]
+	}
+
+	sign := ""
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		sign = text[:1]
+		text = text[1:]
+	}
+
+	intPart, fracPart := text, ""
+	if index := strings.IndexByte(text, '.'); index >= 0 {
+		intPart, fracPart = text[:index], text[index+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" || len(fracPart) > math.MaxInt32-maxDecimalExponent || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Decimal{}, errInvalidDecimal
+	}
+
+	value, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return Decimal{}, errInvalidDecimal
+	}
+	return Decimal{value: value, scale: int32(len(fracPart) - exponent)}.normalizeScale(), nil
+}
+
+// MustDecimal 은 ParseDecimal 과 같지만, 잘못된 문자열이면 panic 합니다. 상수 값을 만들 때만 사용하세요.
+func MustDecimal(raw string) Decimal {
+	result, err := ParseDecimal(raw)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// normalizeScale 은 scale 이 음수가 되지 않도록 맞춥니다.
+func (d Decimal) normalizeScale() Decimal {
+	if d.scale >= 0 {
+		return d
+	}
+	return Decimal{value: new(big.Int).Mul(d.bigInt(), pow10(-d.scale)), scale: 0}
+}
+
+func (d Decimal) bigInt() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale <= d.scale {
+		return d.bigInt()
+	}
+	return new(big.Int).Mul(d.bigInt(), pow10(scale-d.scale))
+}
+
+func maxScale(a Decimal, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+func (d Decimal) String() string {
+	text := new(big.Int).Abs(d.bigInt()).String()
+	if d.scale > 0 {
+		if len(text) <= int(d.scale) {
+			text = strings.Repeat("0", int(d.scale)-len(text)+1) + text
+		}
+		text = text[:len(text)-int(d.scale)] + "." + text[len(text)-int(d.scale):]
+	}
+	if d.Sign() < 0 {
+		text = "-" + text
+	}
+	return text
+}
+
+func (d Decimal) Float64() float64 {
+	result, _ := strconv.ParseFloat(d.String(), 64)
+	return result
+}
+
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+func (d Decimal) Sign() int {
+	return d.bigInt().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+func (d Decimal) Cmp(other Decimal) int {
+	scale := maxScale(d, other)
+	return d.rescale(scale).Cmp(other.rescale(scale))
+}
+
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.Cmp(other) < 0
+}
+
+func (d Decimal) GreaterThan(other Decimal) bool {
+	return d.Cmp(other) > 0
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.bigInt()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.bigInt()), scale: d.scale}
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return Decimal{value: new(big.Int).Add(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := maxScale(d, other)
+	return Decimal{value: new(big.Int).Sub(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.bigInt(), other.bigInt()), scale: d.scale + other.scale}
+}
+
+// Div 는 소수점 아래 places 자리까지 계산하고 나머지는 버립니다. 0 으로 나누면 0 을 반환합니다.
+func (d Decimal) Div(other Decimal, places int32) Decimal {
+	if other.IsZero() {
+		return Decimal{}
+	}
+	if places < 0 {
+		places = 0
+	}
+	numerator := new(big.Int).Mul(d.bigInt(), pow10(places+other.scale))
+	denominator := new(big.Int).Mul(other.bigInt(), pow10(d.scale))
+	return Decimal{value: numerator.Quo(numerator, denominator), scale: places}
+}
+
+// Truncate 는 소수점 아래 places 자리 밑을 버립니다. (0 방향)
+func (d Decimal) Truncate(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	return Decimal{value: new(big.Int).Quo(d.bigInt(), pow10(d.scale-places)), scale: places}
+}
+
+// Floor 는 소수점 아래 places 자리로 내림합니다. (음의 무한대 방향)
+func (d Decimal) Floor(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	// big.Int 의 Div 는 양수로 나눌 때 내림과 같음
+	return Decimal{value: new(big.Int).Div(d.bigInt(), pow10(d.scale-places)), scale: places}
+}
+
+// Ceil 은 소수점 아래 places 자리로 올림합니다. (양의 무한대 방향)
+func (d Decimal) Ceil(places int32) Decimal {
+	return d.Neg().Floor(places).Neg()
+}
+
+// Round 는 소수점 아래 places 자리로 반올림합니다. (0.5 는 0 에서 먼 쪽으로)
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	half := Decimal{value: big.NewInt(5), scale: places + 1}
+	if d.Sign() < 0 {
+		return d.Sub(half).Truncate(places)
+	}
+	return d.Add(half).Truncate(places)
+}
+
+func MinDecimal(first Decimal, rest ...Decimal) Decimal {
+	result := first
+	for _, data := range rest {
+		if data.LessThan(result) {
+			result = data
+		}
+	}
+	return result
+}
+
+func MaxDecimal(first Decimal, rest ...Decimal) Decimal {
+	result := first
+	for _, data := range rest {
+		if data.GreaterThan(result) {
+			result = data
+		}
+	}
+	return result
+}
+
+// JSON 으로는 정밀도를 잃지 않도록 문자열로 저장합니다. 읽을 때는 문자열과 숫자 모두 허용합니다.
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(data []byte) error {
+	parsed, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,174 @@
+package gobithumb
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"0", "0"},
+		{"123", "123"},
+		{"-123", "-123"},
+		{"+7", "7"},
+		{"0.00012345", "0.00012345"},
+		{"-0.5", "-0.5"},
+		{".5", "0.5"},
+		{"1.", "1"},
+		{"1.50", "1.50"},
+		{" 42 ", "42"},
+		{"1.5e-7", "0.00000015"},
+		{"1.5E3", "1500"},
+		{"12e2", "1200"},
+		{"1e1000", "1" + strings.Repeat("0", 1000)},
+	}
+	for _, test := range tests {
+		result, err := ParseDecimal(test.raw)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) error: %v", test.raw, err)
+			continue
+		}
+		if result.String() != test.want {
+			t.Errorf("ParseDecimal(%q) = %s, want %s", test.raw, result, test.want)
+		}
+	}
+}
+
+func TestParseDecimalInvalid(t *testing.T) {
+	for _, raw := range []string{"", "-", ".", "abc", "1.2.3", "1e", "1e1.5", "1e1000000000", "1e-1000000000", "0x10", "1,000"} {
+		if _, err := ParseDecimal(raw); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("ParseDecimal(%q) error = %v, want ErrInvalidParameter", raw, err)
+		}
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		value Decimal
+		want  string
+	}{
+		{Decimal{}, "0"},
+		{NewDecimal(5, 0), "5"},
+		{NewDecimal(5, 3), "0.005"},
+		{NewDecimal(-5, 3), "-0.005"},
+		{NewDecimal(12345, 2), "123.45"},
+		{NewDecimal(12, -2), "1200"},
+		{NewDecimalFromFloat(0.1), "0.1"},
+		{MustDecimal("1").Sub(MustDecimal("1.25")), "-0.25"},
+	}
+	for _, test := range tests {
+		if test.value.String() != test.want {
+			t.Errorf("String() = %s, want %s", test.value, test.want)
+		}
+	}
+}
+
+func TestDecimalRoundTrip(t *testing.T) {
+	original := MustDecimal("0.00012345")
+
+	parsed, err := ParseDecimal(original.String())
+	if err != nil || !parsed.Equal(original) || parsed.String() != "0.00012345" {
+		t.Fatalf("string round trip = %s (%v)", parsed, err)
+	}
+
+	encoded, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `"0.00012345"` {
+		t.Fatalf("MarshalJSON = %s", encoded)
+	}
+	var decoded Decimal
+	if err := json.Unmarshal(encoded, &decoded); err != nil || decoded.String() != "0.00012345" {
+		t.Fatalf("UnmarshalJSON = %s (%v)", decoded, err)
+	}
+
+	// 응답의 숫자 값도 float64 를 거치지 않고 그대로 읽음
+	var raw map[string]interface{}
+	if err := decodeJSON([]byte(`{"price": 0.00012345, "units": 12345678901234567890.123456789}`), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if price := toDecimal(raw["price"]); price.String() != "0.00012345" {
+		t.Errorf("toDecimal(number) = %s", price)
+	}
+	if units := toDecimal(raw["units"]); units.String() != "12345678901234567890.123456789" {
+		t.Errorf("toDecimal(large number) = %s", units)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var result struct {
+		Quoted Decimal `json:"quoted"`
+		Number Decimal `json:"number"`
+		Null   Decimal `json:"null"`
+	}
+	if err := json.Unmarshal([]byte(`{"quoted": "1.25", "number": 3.5, "null": null}`), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Quoted.String() != "1.25" || result.Number.String() != "3.5" || !result.Null.IsZero() {
+		t.Errorf("UnmarshalJSON = %s, %s, %s", result.Quoted, result.Number, result.Null)
+	}
+	if err := json.Unmarshal([]byte(`{"quoted": "abc"}`), &result); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("UnmarshalJSON(invalid) error = %v", err)
+	}
+}
+
+func TestDecimalDiv(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		places int32
+		want   string
+	}{
+		{"10", "4", 2, "2.50"},
+		{"1", "3", 8, "0.33333333"},
+		{"2", "3", 4, "0.6666"},
+		{"-2", "3", 4, "-0.6666"},
+		{"0.0003", "0.0001", 0, "3"},
+		{"123.45", "0.5", 1, "246.9"},
+		{"1", "0", 8, "0"},
+		{"7", "2", -1, "3"},
+	}
+	for _, test := range tests {
+		result := MustDecimal(test.a).Div(MustDecimal(test.b), test.places)
+		if result.String() != test.want {
+			t.Errorf("%s.Div(%s, %d) = %s, want %s", test.a, test.b, test.places, result, test.want)
+		}
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	tests := []struct {
+		value  string
+		places int32
+		round  string
+		floor  string
+		ceil   string
+	}{
+		{"1.2345", 2, "1.23", "1.23", "1.24"},
+		{"1.235", 2, "1.24", "1.23", "1.24"},
+		{"-1.235", 2, "-1.24", "-1.24", "-1.23"},
+		{"-1.2345", 2, "-1.23", "-1.24", "-1.23"},
+		{"2.5", 0, "3", "2", "3"},
+		{"-2.5", 0, "-3", "-3", "-2"},
+		{"0.00012345", 4, "0.0001", "0.0001", "0.0002"},
+		{"1.5", 3, "1.5", "1.5", "1.5"},
+		{"15", 0, "15", "15", "15"},
+	}
+	for _, test := range tests {
+		value := MustDecimal(test.value)
+		if result := value.Round(test.places); result.String() != test.round {
+			t.Errorf("%s.Round(%d) = %s, want %s", test.value, test.places, result, test.round)
+		}
+		if result := value.Floor(test.places); result.String() != test.floor {
+			t.Errorf("%s.Floor(%d) = %s, want %s", test.value, test.places, result, test.floor)
+		}
+		if result := value.Ceil(test.places); result.String() != test.ceil {
+			t.Errorf("%s.Ceil(%d) = %s, want %s", test.value, test.places, result, test.ceil)
+		}
+	}
+}
@@ -1,18 +1,18 @@
 package gobithumb
 
 import (
-	"fmt"
+	"bytes"
+	"encoding/json"
 	"strconv"
+	"strings"
 	"time"
 )
 
-func timelog(a ...interface{}) {
-	fmt.Print(time.Now().Format(time.StampMilli) + "\t")
-	fmt.Println(a...)
-}
-
 func milliStringToTime(milliString string) time.Time {
 
+	if len(milliString) < 4 {
+		return time.Time{}
+	}
 	timeSec, _ := strconv.ParseInt(milliString[:len(milliString)-3], 10, 64)
 	timeMilli, _ := strconv.ParseInt(milliString[len(milliString)-3:], 10, 64)
 	return time.Unix(timeSec, timeMilli*1000000)
@@ -20,6 +20,9 @@ func milliStringToTime(milliString string) time.Time {
 
 func microStringToTime(microString string) time.Time {
 
+	if len(microString) < 7 {
+		return time.Time{}
+	}
 	timeSec, _ := strconv.ParseInt(microString[:len(microString)-6], 10, 64)
 	timeMilli, _ := strconv.ParseInt(microString[len(microString)-6:], 10, 64)
 	return time.Unix(timeSec, timeMilli*1000)
@@ -27,13 +30,59 @@ func microStringToTime(microString string) time.Time {
 
 func rawBalanceStringToBalance(raw string) (string, int) {
 
-	if raw[0] == 't' {
+	if strings.HasPrefix(raw, "total_") {
 		return raw[6:], 1 //total_coin
-	} else if raw[0] == 'i' {
+	} else if strings.HasPrefix(raw, "in_use_") {
 		return raw[7:], 2 //in_use_coin
-	} else if raw[0] == 'a' {
+	} else if strings.HasPrefix(raw, "available_") {
 		return raw[10:], 3 //available_coin
-	} else {
+	} else if strings.HasPrefix(raw, "xcoin_last_") {
 		return raw[11:], 4 //xcoin
 	}
+	return raw, 0
+}
+
+// 아래 함수들은 응답 JSON 의 값을 panic 없이 꺼내기 위해 사용합니다.
+// 값이 없거나 타입이 다르면 zero value 를 반환합니다.
+
+// decodeJSON 은 숫자를 float64 가 아닌 json.Number 로 읽어, 큰 수나 긴 소수의 정밀도를 잃지 않도록 합니다.
+func decodeJSON(data []byte, result interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(result)
+}
+
+func toString(raw interface{}) string {
+	switch value := raw.(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return ""
+}
+
+func toFloat(raw interface{}) float64 {
+	if value, ok := raw.(float64); ok {
+		return value
+	}
+	result, _ := strconv.ParseFloat(toString(raw), 64)
+	return result
+}
+
+func toDecimal(raw interface{}) Decimal {
+	result, _ := ParseDecimal(toString(raw))
+	return result
+}
+
+func toMap(raw interface{}) map[string]interface{} {
+	result, _ := raw.(map[string]interface{})
+	return result
+}
+
+func toSlice(raw interface{}) []interface{} {
+	result, _ := raw.([]interface{})
+	return result
 }
@@ -0,0 +1,76 @@
+package gobithumb
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Bithumb 이 돌려주는 대표적인 오류에 대한 sentinel error 입니다.
+// APIError 는 errors.Is 로 아래 값들과 비교할 수 있습니다.
+var (
+	ErrInsufficientBalance = errors.New("gobithumb: insufficient balance")
+	ErrInvalidAPIKey       = errors.New("gobithumb: invalid api key")
+	ErrRateLimited         = errors.New("gobithumb: rate limited")
+	ErrMaintenance         = errors.New("gobithumb: server maintenance")
+	ErrInvalidParameter    = errors.New("gobithumb: invalid parameter")
+	ErrInvalidResponse     = errors.New("gobithumb: invalid response")
+	ErrTemporary           = errors.New("gobithumb: temporary server error")
+	ErrOrderNotFound       = errors.New("gobithumb: order not found")
+)
+
+const statusOK = "0000"
+
+// APIError 는 Bithumb 서버가 요청을 거절했을 때 반환됩니다.
+// API 2.0 (/v1/*) 응답이면 Status 에 error.name (e.g. "insufficient_funds_bid") 이 들어갑니다.
+type APIError struct {
+	HTTPStatus int
+	Status     string
+	Message    string
+	Endpoint   string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gobithumb: %s failed (http %d, status %s): %s", e.Endpoint, e.HTTPStatus, e.Status, e.Message)
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInsufficientBalance:
+		return e.Status == "5600" && (strings.Contains(e.Message, "사용가능") || strings.Contains(e.Message, "부족")) ||
+			strings.HasPrefix(e.Status, "insufficient_funds")
+	case ErrInvalidAPIKey:
+		switch e.Status {
+		case "5300", "5200", "invalid_access_key", "expired_access_key", "jwt_verification", "no_authorization_ip":
+			return true
+		}
+		return false
+	case ErrRateLimited:
+		return e.HTTPStatus == http.StatusTooManyRequests || strings.Contains(e.Message, "Too Many Requests")
+	case ErrMaintenance:
+		return e.HTTPStatus == http.StatusServiceUnavailable || strings.Contains(e.Message, "점검")
+	case ErrInvalidParameter:
+		return e.Status == "5100" || e.Status == "5500" || e.Status == "validation_error" || e.Status == "invalid_parameter"
+	case ErrOrderNotFound:
+		return e.Status == "5600" && strings.Contains(e.Message, "존재하지 않") || e.Status == "order_not_found"
+	case ErrTemporary:
+		return isTemporaryStatus(e.HTTPStatus, e.Status, e.Message)
+	}
+	return false
+}
+
+// RequestError 는 네트워크 오류, context 취소, 해석할 수 없는 응답 등
+// 서버의 응답 코드를 받지 못한 실패를 감쌉니다.
+type RequestError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *RequestError) Error() string {
+	return "gobithumb: " + e.Endpoint + ": " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,58 @@
+package gobithumb
+
+import (
+	"context"
+	"time"
+)
+
+// 전략 코드가 BithumbRequester 대신 의존할 수 있는 interface 입니다.
+// 모든 method 는 BithumbRequester 의 ...Ctx method 와 같으며, 테스트에서는 bithumbmock.Exchange 로 바꿔 쓸 수 있습니다.
+
+// MarketData 는 인증이 필요 없는 시세 조회 API 입니다.
+type MarketData interface {
+	GetTradableCoinListCtx(ctx context.Context) ([]Currency, error)
+	GetTradableMarketsCtx(ctx context.Context, paymentCurrency Currency) ([]Market, error)
+	GetTickerCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (map[Currency]Ticker, time.Time, error)
+	GetOrderbookCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (map[Currency]Orderbook, time.Time, error)
+	GetTransactionHistoryCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) ([]OneTransaction, error)
+	GetAssetsStatusCtx(ctx context.Context, orderCurrency Currency) (bool, bool, error)
+	GetBTCICtx(ctx context.Context) (BTCI, time.Time, error)
+	GetCandleStickCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, chartInterval TimeInterval) ([]OneCandleStick, error)
+}
+
+// AccountInfo 는 계정, 잔고, 주문 내역 조회 API 입니다.
+type AccountInfo interface {
+	GetAccountCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (Account, error)
+	GetBalanceCtx(ctx context.Context, orderCurrency Currency) (map[Currency]*Balance, error)
+	GetMarketBalanceCtx(ctx context.Context, market Market) (Balance, Balance, error)
+	GetUserTickerCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (UserTicker, error)
+	GetOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, count int, date ...time.Time) ([]Order, error)
+	GetOrderDetailCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, orderId string) (OrderDetail, error)
+	GetTransactionsCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, search SearchType, offsetCount ...int) ([]Transaction, error)
+}
+
+// Trading 은 주문, 취소 API 입니다.
+type Trading interface {
+	PlaceOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal, price Decimal, side OrderSide) (string, error)
+	CancelOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, orderId string, side OrderSide) error
+	MarketBuyCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error)
+	MarketSellCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error)
+	StopLimitCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, watchPrice Decimal, price Decimal, amount Decimal, side OrderSide) (string, error)
+}
+
+// Wallet 은 입금 주소 조회, 출금 API 입니다.
+type Wallet interface {
+	GetWalletAddressCtx(ctx context.Context, orderCurrency Currency) (string, error)
+	WithDrawCoinCtx(ctx context.Context, orderCurrency Currency, amount Decimal, address string, destination ...interface{}) error
+	WithdrawKRWCtx(ctx context.Context, account string, price int) error
+}
+
+// Exchange 는 BithumbRequester 가 제공하는 모든 API 입니다.
+type Exchange interface {
+	MarketData
+	AccountInfo
+	Trading
+	Wallet
+}
+
+var _ Exchange = (*BithumbRequester)(nil)
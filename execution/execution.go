@@ -0,0 +1,360 @@
+// Package execution 은 큰 주문을 작은 자식 주문으로 나누어 시간에 걸쳐 체결하는 TWAP, VWAP 알고리즘입니다.
+//
+// 호가가 얇은 시장에서 큰 시장가 주문은 가격을 크게 움직이므로, Scheduler (TWAP, VWAP) 가 나눈 일정대로 자식 주문
+// (기본값 시장가, WithLimitOrders 로 지정가) 을 넣습니다. 결과는 도착 가격 (시작할 때의 중간 호가) 과 평균 체결 가격을
+// 비교한 Report 로 돌려줍니다.
+//
+//	order := execution.Order{Market: gobithumb.NewMarket(gobithumb.BTC, gobithumb.KRW), Side: gobithumb.Bid, Units: gobithumb.MustDecimal("1")}
+//	report, err := execution.Execute(ctx, client, order, execution.TWAP{Duration: time.Hour, Slices: 12})
+//	fmt.Println(report)
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+var (
+	ErrIncomplete = errors.New("execution: order not fully executed")
+	ErrUnresolved = errors.New("execution: child order result unknown")
+)
+
+// Requester 는 Execute 가 호가 조회, 주문, 체결 확인에 사용하는 API 입니다. BithumbRequester, PaperTrader 가 구현합니다.
+type Requester interface {
+	b.MarketData
+	b.AccountInfo
+	b.Trading
+}
+
+// Order 는 나누어 체결할 부모 주문입니다.
+type Order struct {
+	Market b.Market
+	Side   b.OrderSide
+	Units  b.Decimal
+}
+
+type config struct {
+	limit       bool
+	limitTicks  int
+	rules       b.OrderRules
+	logger      b.Logger
+	fillTimeout time.Duration
+}
+
+// Option 은 Execute 의 동작을 바꿉니다.
+type Option func(*config)
+
+// WithLimitOrders 는 자식 주문을 시장가 대신 지정가로 넣습니다. 가격은 반대편 최우선 호가에서 ticks 호가 단위만큼 불리한 가격입니다.
+// (e.g. 매수는 최우선 매도 호가 + ticks 호가) Slice 가 끝날 때까지 체결되지 않은 수량은 취소하고 이후 자식 주문에 더합니다.
+func WithLimitOrders(ticks int) Option {
+	return func(c *config) {
+		c.limit = true
+		c.limitTicks = ticks
+	}
+}
+
+// WithOrderRules 는 자식 주문에 적용할 규칙입니다. (기본값 gobithumb.DefaultOrderRules())
+// 수량은 UnitsPlaces 로 내림하며, 최소 주문 금액보다 작은 자식 주문은 건너뛰고 다음 자식 주문에 더합니다.
+func WithOrderRules(rules b.OrderRules) Option {
+	return func(c *config) {
+		c.rules = rules
+	}
+}
+
+func WithLogger(logger b.Logger) Option {
+	return func(c *config) {
+		if logger == nil {
+			logger = b.NopLogger
+		}
+		c.logger = logger
+	}
+}
+
+// WithFillTimeout 은 자식 주문의 최종 체결 결과를 기다리는 최대 시간입니다. (기본값 30초)
+func WithFillTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.fillTimeout = timeout
+	}
+}
+
+func newConfig(options []Option) *config {
+	c := config{}
+	c.rules = b.DefaultOrderRules()
+	c.logger = b.NewStdLogger(os.Stdout, b.LogWarn)
+	c.fillTimeout = 30 * time.Second
+	for _, option := range options {
+		option(&c)
+	}
+	if c.fillTimeout <= 0 {
+		c.fillTimeout = 30 * time.Second
+	}
+	return &c
+}
+
+// Execute 는 scheduler 의 일정대로 자식 주문을 넣고, 모든 Slice 가 끝나면 Report 를 반환합니다.
+// 체결되지 않은 수량은 다음 자식 주문에 더하며, 마지막 자식 주문 뒤에도 남은 수량이 있으면 ErrIncomplete 를 함께 반환합니다.
+// 체결 결과를 확인하지 못한 자식 주문 (응답을 받지 못한 주문 요청 포함) 은 모두 체결될 수 있다고 보고 다음 자식 주문의 수량에서 빼며,
+// 다음 자식 주문 전에 다시 확인합니다. 끝날 때까지 확인하지 못하면 ErrUnresolved 를 반환합니다.
+// order.Units 는 주문 규칙의 수량 자릿수 (UnitsPlaces) 에 맞아야 합니다.
+// ctx 가 끝나면 걸려 있는 자식 주문을 취소하고 그때까지의 Report 와 ctx.Err() 를 반환합니다.
+func Execute(ctx context.Context, requester Requester, order Order, scheduler Scheduler, options ...Option) (*Report, error) {
+
+	// parameter 정상 체크
+	if !order.Market.Valid() {
+		return nil, fmt.Errorf("%w: 시장이 올바르지 않습니다. (%q)", b.ErrInvalidParameter, order.Market)
+	}
+	if !order.Side.Valid() {
+		return nil, fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", b.ErrInvalidParameter, order.Side)
+	}
+	if order.Units.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: 주문 수량은 0보다 커야 합니다.", b.ErrInvalidParameter)
+	}
+	if scheduler == nil {
+		return nil, fmt.Errorf("%w: scheduler 가 없습니다.", b.ErrInvalidParameter)
+	}
+	c := newConfig(options)
+	if !c.rules.RoundUnits(order.Units).Equal(order.Units) {
+		return nil, fmt.Errorf("%w: 주문 수량 %s 는 소수점 %d 자리까지만 가능합니다.", b.ErrInvalidUnits, order.Units, c.rules.UnitsPlaces)
+	}
+
+	start := time.Now()
+	bid, ask, err := bestPrices(ctx, requester, order.Market)
+	if err != nil {
+		return nil, err
+	}
+	slices, err := scheduler.Schedule(ctx, requester, order, start)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Market: order.Market, Side: order.Side, Units: order.Units, Start: start}
+	report.ArrivalPrice = bid.Add(ask).Div(b.NewDecimalFromInt(2), 8)
+	tracker := b.NewOrderTracker(requester, b.WithTrackInterval(200*time.Millisecond, 2*time.Second), b.WithTrackerLogger(c.logger))
+
+	scheduled := b.Decimal{}
+	for index, slice := range slices {
+		scheduled = scheduled.Add(slice.Units)
+		if err := sleepUntil(ctx, slice.Start); err != nil {
+			c.resolve(context.Background(), requester, report)
+			report.finish(time.Now())
+			return report, err
+		}
+
+		c.resolve(ctx, requester, report)
+		units := c.rules.RoundUnits(scheduled.Sub(report.Filled).Sub(report.inFlight()))
+		if units.Sign() <= 0 {
+			continue
+		}
+		bid, ask, err := bestPrices(ctx, requester, order.Market)
+		if err != nil {
+			c.logger.Log(b.LogWarn, "execution quote failed", b.LogField{Key: "market", Value: order.Market}, b.LogField{Key: "error", Value: err})
+			report.add(Child{Time: time.Now(), Units: units, Err: err})
+			continue
+		}
+		reference := ask
+		if order.Side == b.Ask {
+			reference = bid
+		}
+
+		// 최소 주문 금액보다 작으면 다음 자식 주문에 더함
+		last := index == len(slices)-1
+		if minValue, ok := c.rules.MinOrderValue[order.Market.Quote]; ok && !last && units.Mul(reference).LessThan(minValue) {
+			continue
+		}
+
+		child := c.child(ctx, requester, tracker, order, units, reference, slice)
+		if child.Err != nil {
+			c.logger.Log(b.LogWarn, "execution child order failed", b.LogField{Key: "market", Value: order.Market}, b.LogField{Key: "order_id", Value: child.OrderID}, b.LogField{Key: "error", Value: child.Err})
+		}
+		report.add(child)
+		if ctx.Err() != nil {
+			c.resolve(context.Background(), requester, report)
+			report.finish(time.Now())
+			return report, ctx.Err()
+		}
+	}
+
+	c.resolve(ctx, requester, report)
+	report.finish(time.Now())
+	if unresolved := report.Unresolved(); len(unresolved) > 0 {
+		ids := make([]string, len(unresolved))
+		for index, data := range unresolved {
+			ids[index] = data.OrderID
+			if data.OrderID == "" {
+				ids[index] = data.Time.Format("15:04:05") + " (주문 번호 없음)"
+			}
+		}
+		return report, fmt.Errorf("%w: %s 주문의 체결 결과를 주문 내역에서 확인하세요.", ErrUnresolved, strings.Join(ids, ", "))
+	}
+	if report.Remaining.Sign() > 0 {
+		if failed := report.Failed(); len(failed) > 0 {
+			return report, fmt.Errorf("%w: %s 중 %s 미체결, 마지막 오류: %v", ErrIncomplete, report.Units, report.Remaining, failed[len(failed)-1].Err)
+		}
+		return report, fmt.Errorf("%w: %s 중 %s 미체결", ErrIncomplete, report.Units, report.Remaining)
+	}
+	return report, nil
+}
+
+// child 는 자식 주문을 넣고 최종 체결 결과를 가져옵니다. 지정가 주문은 slice 가 끝날 때까지 기다린 뒤 남은 수량을 취소합니다.
+func (c *config) child(ctx context.Context, requester Requester, tracker *b.OrderTracker, order Order, units b.Decimal, reference b.Decimal, slice Slice) Child {
+	market := order.Market
+	child := Child{Time: time.Now(), Units: units}
+
+	var id string
+	var err error
+	switch {
+	case c.limit:
+		tick := c.rules.TickSize(market.Quote, reference).Mul(b.NewDecimalFromInt(int64(c.limitTicks)))
+		price := reference.Add(tick)
+		if order.Side == b.Ask {
+			price = reference.Sub(tick)
+		}
+		child.Price = c.rules.RoundPrice(market.Quote, price, order.Side)
+		id, err = requester.PlaceOrderCtx(ctx, market.Base, market.Quote, units, child.Price, order.Side)
+	case order.Side == b.Bid:
+		id, err = requester.MarketBuyCtx(ctx, market.Base, market.Quote, units)
+	default:
+		id, err = requester.MarketSellCtx(ctx, market.Base, market.Quote, units)
+	}
+	if err != nil {
+		// 응답을 받지 못한 주문 요청은 거래소에 들어갔을 수 있으므로 다시 주문하지 않도록 Unresolved 로 남김
+		child.Err = err
+		child.Unresolved = unknownResult(err)
+		return child
+	}
+	child.OrderID = id
+
+	// ctx 가 끝나도 걸려 있는 주문은 취소하고 체결 결과를 가져옴
+	if c.limit {
+		sleepUntil(ctx, slice.End)
+	}
+	waitCtx := ctx
+	if ctx.Err() != nil {
+		waitCtx = context.Background()
+	}
+	if c.limit {
+		cancelCtx, cancel := context.WithTimeout(waitCtx, c.fillTimeout)
+		err := requester.CancelOrderCtx(cancelCtx, market.Base, market.Quote, id, order.Side)
+		cancel()
+		if err != nil && !errors.Is(err, b.ErrOrderNotFound) {
+			child.Err = err
+		}
+	}
+
+	// 체결 결과를 모르면 다시 주문하지 않도록 Unresolved 로 남기고, 다음 자식 주문 전에 다시 확인함
+	fillCtx, cancel := context.WithTimeout(waitCtx, c.fillTimeout)
+	defer cancel()
+	detail, err := tracker.WaitFilled(fillCtx, market, id)
+	tracker.Untrack(id)
+	if err != nil && !errors.Is(err, b.ErrOrderCancelled) {
+		if child.Err == nil {
+			child.Err = err
+		}
+		child.Unresolved = true
+		return child
+	}
+	child.addFills(detail)
+	return child
+}
+
+// unknownResult 는 주문 요청의 오류가 서버의 응답 없이 실패한 것 (e.g. timeout, 연결 끊김, ctx 종료) 인지 확인합니다.
+// 이 경우 거래소가 주문을 받았는지 알 수 없습니다.
+func unknownResult(err error) bool {
+	var apiErr *b.APIError
+	var requestErr *b.RequestError
+	return !errors.As(err, &apiErr) && errors.As(err, &requestErr)
+}
+
+// resolve 는 결과를 모르는 자식 주문을 GetOrderDetail 로 다시 확인합니다. 아직 끝나지 않은 주문은 취소를 요청하고 다음에 다시 확인합니다.
+// 주문 번호를 받지 못한 자식 주문은 걸려 있는 주문에서 같은 주문을 찾으며, 찾지 못하면 (이미 체결되었을 수 있으므로) 계속 Unresolved 로 남깁니다.
+func (c *config) resolve(ctx context.Context, requester Requester, report *Report) {
+	for index, data := range report.Children {
+		if !data.Unresolved {
+			continue
+		}
+		queryCtx, cancel := context.WithTimeout(ctx, c.fillTimeout)
+		if data.OrderID == "" {
+			id, err := findChild(queryCtx, requester, report, data)
+			if err != nil || id == "" {
+				cancel()
+				c.logger.Log(b.LogWarn, "execution child order unresolved", b.LogField{Key: "market", Value: report.Market}, b.LogField{Key: "error", Value: err})
+				continue
+			}
+			report.Children[index].OrderID = id
+			data.OrderID = id
+		}
+		detail, err := requester.GetOrderDetailCtx(queryCtx, report.Market.Base, report.Market.Quote, data.OrderID)
+		if err == nil && !detail.OrderStatus.Done() {
+			err = requester.CancelOrderCtx(queryCtx, report.Market.Base, report.Market.Quote, data.OrderID, report.Side)
+			if err == nil || errors.Is(err, b.ErrOrderNotFound) {
+				detail, err = requester.GetOrderDetailCtx(queryCtx, report.Market.Base, report.Market.Quote, data.OrderID)
+			}
+		}
+		cancel()
+		if err != nil || !detail.OrderStatus.Done() {
+			c.logger.Log(b.LogWarn, "execution child order unresolved", b.LogField{Key: "market", Value: report.Market}, b.LogField{Key: "order_id", Value: data.OrderID}, b.LogField{Key: "error", Value: err})
+			continue
+		}
+		report.resolve(index, detail)
+	}
+}
+
+// findChild 는 주문 번호를 받지 못한 자식 주문과 방향, 가격, 수량이 같은 걸려 있는 주문을 찾습니다. 없으면 빈 문자열을 반환합니다.
+func findChild(ctx context.Context, requester Requester, report *Report, child Child) (string, error) {
+	// 주문 시각은 서버 기준이므로 여유를 둠
+	orders, err := requester.GetOrderCtx(ctx, report.Market.Base, report.Market.Quote, 100, child.Time.Add(-time.Minute))
+	if errors.Is(err, b.ErrOrderNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	known := make(map[string]bool)
+	for _, data := range report.Children {
+		if data.OrderID != "" {
+			known[data.OrderID] = true
+		}
+	}
+	for _, data := range orders {
+		if !known[data.OrderID] && data.Type == report.Side && data.Units.Equal(child.Units) && (child.Price.IsZero() || data.Price.Equal(child.Price)) {
+			return data.OrderID, nil
+		}
+	}
+	return "", nil
+}
+
+// bestPrices 는 최우선 매수, 매도 호가를 가져옵니다.
+func bestPrices(ctx context.Context, source b.MarketData, market b.Market) (b.Decimal, b.Decimal, error) {
+	orderbooks, _, err := source.GetOrderbookCtx(ctx, market.Base, market.Quote)
+	if err != nil {
+		return b.Decimal{}, b.Decimal{}, err
+	}
+	for currency, data := range orderbooks {
+		if strings.EqualFold(string(currency), string(market.Base)) && len(data.Bids) > 0 && len(data.Asks) > 0 {
+			return data.Bids[0].Price, data.Asks[0].Price, nil
+		}
+	}
+	return b.Decimal{}, b.Decimal{}, fmt.Errorf("%w: %s", b.ErrNoLiquidity, market)
+}
+
+func sleepUntil(ctx context.Context, date time.Time) error {
+	wait := time.Until(date)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
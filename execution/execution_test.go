@@ -0,0 +1,248 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+	"github.com/lutergs/gobithumb/bithumbtest"
+)
+
+func newClient(t *testing.T, options ...b.Option) (*bithumbtest.Server, *b.BithumbRequester) {
+	t.Helper()
+	server := bithumbtest.NewServer("connect key", "secret key")
+	t.Cleanup(server.Close)
+	server.SetBalance(b.KRW, b.MustDecimal("1000000000"))
+	server.SetBalance(b.BTC, b.MustDecimal("10"))
+	options = append([]b.Option{b.WithBaseURL(server.URL), b.WithRetryPolicy(b.RetryPolicy{MaxAttempts: 1}), b.WithLogger(b.NopLogger)}, options...)
+	return server, b.NewBithumb("connect key", "secret key", options...)
+}
+
+// childUnitsEqual 은 자식 주문의 수량이 want 와 같은지 확인합니다.
+func childUnitsEqual(report *Report, want ...string) bool {
+	if len(report.Children) != len(want) {
+		return false
+	}
+	for index, data := range report.Children {
+		if !data.Units.Equal(b.MustDecimal(want[index])) {
+			return false
+		}
+	}
+	return true
+}
+
+func childUnits(report *Report) []string {
+	result := make([]string, len(report.Children))
+	for index, data := range report.Children {
+		result[index] = data.Units.String()
+	}
+	return result
+}
+
+func TestSplit(t *testing.T) {
+	start := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		units   string
+		weights []float64
+	}{
+		{"1", []float64{1, 1, 1}},
+		{"0.0007", []float64{1, 1, 1, 1, 1, 1, 1, 1, 1}},
+		{"1.2345", []float64{1, 9, 0, 3}},
+		{"2", []float64{0.1, 0.2, 0.3}},
+		{"5", []float64{0, 0, 0}},
+	}
+	for _, test := range tests {
+		units := b.MustDecimal(test.units)
+		slices := split(units, start, time.Hour, append([]float64(nil), test.weights...))
+		if len(slices) != len(test.weights) {
+			t.Fatalf("split(%s) = %d slices, want %d", test.units, len(slices), len(test.weights))
+		}
+		total := b.Decimal{}
+		for index, data := range slices {
+			if data.Units.Sign() < 0 {
+				t.Errorf("split(%s)[%d] = %s, want >= 0", test.units, index, data.Units)
+			}
+			total = total.Add(data.Units)
+		}
+		if !total.Equal(units) {
+			t.Errorf("split(%s) sums to %s", test.units, total)
+		}
+		if !slices[0].Start.Equal(start) || !slices[len(slices)-1].End.Equal(start.Add(time.Hour)) {
+			t.Errorf("split(%s) covers %s ~ %s", test.units, slices[0].Start, slices[len(slices)-1].End)
+		}
+	}
+
+	// 가중치가 0 인 구간은 주문하지 않음
+	slices := split(b.MustDecimal("1"), start, time.Hour, []float64{1, 0, 3})
+	if !slices[0].Units.Equal(b.MustDecimal("0.25")) || !slices[1].Units.IsZero() || !slices[2].Units.Equal(b.MustDecimal("0.75")) {
+		t.Errorf("split weights = %s, %s, %s", slices[0].Units, slices[1].Units, slices[2].Units)
+	}
+}
+
+func TestVWAPSchedule(t *testing.T) {
+	server, client := newClient(t)
+	start := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	var candles []b.OneCandleStick
+	for day := 1; day <= 3; day++ {
+		for hour := 0; hour < 24; hour++ {
+			volume := "1"
+			if hour == 1 {
+				volume = "3"
+			}
+			candles = append(candles, b.OneCandleStick{Time: start.AddDate(0, 0, -day).Add(time.Duration(hour) * time.Hour), UnitsTraded: b.MustDecimal(volume)})
+		}
+	}
+	server.SetCandles(b.BTC, b.KRW, b.Hour1, candles)
+
+	order := Order{Market: b.NewMarket(b.BTC, b.KRW), Side: b.Bid, Units: b.MustDecimal("2")}
+	slices, err := VWAP{Duration: 2 * time.Hour, Slices: 2, Interval: b.Hour1}.Schedule(context.Background(), client, order, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 가중치는 float64 로 계산하므로 소수점 8자리의 오차가 있을 수 있지만, 합은 정확히 Units
+	tolerance := b.MustDecimal("0.00000001")
+	if len(slices) != 2 || slices[0].Units.Sub(b.MustDecimal("0.5")).Abs().GreaterThan(tolerance) || !slices[0].Units.Add(slices[1].Units).Equal(order.Units) {
+		t.Errorf("VWAP slices = %+v, want 0.5 and 1.5", slices)
+	}
+}
+
+func TestExecuteInvalidUnits(t *testing.T) {
+	_, client := newClient(t)
+	order := Order{Market: b.NewMarket(b.BTC, b.KRW), Side: b.Bid, Units: b.MustDecimal("1.00005")}
+	if _, err := Execute(context.Background(), client, order, TWAP{Duration: time.Second, Slices: 2}); !errors.Is(err, b.ErrInvalidUnits) {
+		t.Errorf("Execute error = %v, want ErrInvalidUnits", err)
+	}
+}
+
+func TestExecuteMinOrderValue(t *testing.T) {
+	server, client := newClient(t)
+	server.AddLiquidity(b.BTC, b.KRW, b.Bid, b.MustDecimal("29900000"), b.MustDecimal("1"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Ask, b.MustDecimal("30000000"), b.MustDecimal("1"))
+
+	// 0.0001 씩 나누면 3,000원으로 최소 주문 금액보다 작으므로 두 Slice 씩 합쳐서 주문
+	order := Order{Market: b.NewMarket(b.BTC, b.KRW), Side: b.Bid, Units: b.MustDecimal("0.0004")}
+	report, err := Execute(context.Background(), client, order, TWAP{Duration: 200 * time.Millisecond, Slices: 4}, WithLogger(b.NopLogger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !childUnitsEqual(report, "0.0002", "0.0002") {
+		t.Errorf("children = %v, want [0.0002 0.0002]", childUnits(report))
+	}
+	if !report.Filled.Equal(order.Units) || report.Remaining.Sign() != 0 {
+		t.Errorf("filled = %s, remaining = %s", report.Filled, report.Remaining)
+	}
+}
+
+func TestExecuteLimitCarryOver(t *testing.T) {
+	server, client := newClient(t)
+	server.AddLiquidity(b.BTC, b.KRW, b.Bid, b.MustDecimal("99000000"), b.MustDecimal("0.3"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Bid, b.MustDecimal("98000000"), b.MustDecimal("1"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Ask, b.MustDecimal("100000000"), b.MustDecimal("1"))
+
+	// 첫 자식 주문은 0.3 만 체결되고 나머지는 취소되어, 다음 자식 주문에 더해짐
+	order := Order{Market: b.NewMarket(b.BTC, b.KRW), Side: b.Ask, Units: b.MustDecimal("0.8")}
+	report, err := Execute(context.Background(), client, order, TWAP{Duration: 400 * time.Millisecond, Slices: 2}, WithLimitOrders(0), WithLogger(b.NopLogger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !childUnitsEqual(report, "0.4", "0.5") {
+		t.Fatalf("children = %v, want [0.4 0.5]", childUnits(report))
+	}
+	first, second := report.Children[0], report.Children[1]
+	if !first.Price.Equal(b.MustDecimal("99000000")) || !first.Filled.Equal(b.MustDecimal("0.3")) {
+		t.Errorf("first child = %s filled at %s, want 0.3 at 99000000", first.Filled, first.Price)
+	}
+	if !second.Price.Equal(b.MustDecimal("98000000")) || !second.Filled.Equal(b.MustDecimal("0.5")) {
+		t.Errorf("second child = %s filled at %s, want 0.5 at 98000000", second.Filled, second.Price)
+	}
+	detail, err := client.GetOrderDetail(b.BTC, b.KRW, first.OrderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.OrderStatus != b.OrderCancel {
+		t.Errorf("first child status = %s, want cancel", detail.OrderStatus)
+	}
+	if total, inUse := server.Balance(b.BTC); !total.Equal(b.MustDecimal("9.2")) || inUse.Sign() != 0 {
+		t.Errorf("btc balance = %s (in use %s), want 9.2", total, inUse)
+	}
+}
+
+func TestExecuteUnresolvedMarketOrder(t *testing.T) {
+	server, client := newClient(t, b.WithTimeout(100*time.Millisecond))
+	server.AddLiquidity(b.BTC, b.KRW, b.Bid, b.MustDecimal("99000000"), b.MustDecimal("1"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Ask, b.MustDecimal("100000000"), b.MustDecimal("1"))
+	server.Inject(bithumbtest.Injection{Endpoint: "/trade/market_buy", Delay: 300 * time.Millisecond, Times: 1})
+
+	// 응답을 받지 못한 첫 자식 주문은 체결되었을 수 있으므로 다음 자식 주문에 더하지 않음
+	order := Order{Market: b.NewMarket(b.BTC, b.KRW), Side: b.Bid, Units: b.MustDecimal("0.4")}
+	report, err := Execute(context.Background(), client, order, TWAP{Duration: time.Second, Slices: 2}, WithLogger(b.NopLogger))
+	if !errors.Is(err, ErrUnresolved) {
+		t.Fatalf("Execute error = %v, want ErrUnresolved", err)
+	}
+	if !childUnitsEqual(report, "0.2", "0.2") {
+		t.Errorf("children = %v, want [0.2 0.2]", childUnits(report))
+	}
+	if unresolved := report.Unresolved(); len(unresolved) != 1 || unresolved[0].OrderID != "" {
+		t.Errorf("unresolved = %+v, want first child without order id", unresolved)
+	}
+	if requests := server.Requests("/trade/market_buy"); requests != 2 {
+		t.Errorf("market buy requests = %d, want 2", requests)
+	}
+	if total, _ := server.Balance(b.BTC); !total.Equal(b.MustDecimal("10.4")) {
+		t.Errorf("btc balance = %s, want 10.4", total)
+	}
+}
+
+func TestExecuteUnresolvedLimitOrderFound(t *testing.T) {
+	server, client := newClient(t, b.WithTimeout(100*time.Millisecond))
+	server.AddLiquidity(b.BTC, b.KRW, b.Bid, b.MustDecimal("99000000"), b.MustDecimal("1"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Ask, b.MustDecimal("100000000"), b.MustDecimal("0.1"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Ask, b.MustDecimal("101000000"), b.MustDecimal("1"))
+	server.Inject(bithumbtest.Injection{Endpoint: "/trade/place", Delay: 200 * time.Millisecond, Times: 1})
+
+	// 응답을 받지 못한 자식 주문이 걸려 있으면 찾아서 취소하고 체결된 수량만 반영
+	order := Order{Market: b.NewMarket(b.BTC, b.KRW), Side: b.Bid, Units: b.MustDecimal("0.4")}
+	report, err := Execute(context.Background(), client, order, TWAP{Duration: time.Second, Slices: 2}, WithLimitOrders(0), WithLogger(b.NopLogger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !childUnitsEqual(report, "0.2", "0.3") {
+		t.Fatalf("children = %v, want [0.2 0.3]", childUnits(report))
+	}
+	first := report.Children[0]
+	if first.Unresolved || first.OrderID == "" || !first.Filled.Equal(b.MustDecimal("0.1")) || first.Err != nil {
+		t.Errorf("first child = %+v, want resolved with 0.1 filled", first)
+	}
+	if !report.Filled.Equal(order.Units) {
+		t.Errorf("filled = %s, want %s", report.Filled, order.Units)
+	}
+	if requests := server.Requests("/trade/place"); requests != 2 {
+		t.Errorf("place requests = %d, want 2", requests)
+	}
+}
+
+func TestReportSlippage(t *testing.T) {
+	tests := []struct {
+		side    b.OrderSide
+		average string
+		want    float64
+	}{
+		{b.Bid, "101", 100},
+		{b.Bid, "99", -100},
+		{b.Ask, "99", 100},
+		{b.Ask, "101", -100},
+	}
+	for _, test := range tests {
+		report := &Report{Side: test.side, Units: b.MustDecimal("2"), ArrivalPrice: b.MustDecimal("100")}
+		report.add(Child{Units: b.MustDecimal("2"), Filled: b.MustDecimal("2"), Total: b.MustDecimal(test.average).Mul(b.MustDecimal("2"))})
+		report.finish(time.Now())
+		if report.Slippage < test.want-1e-9 || report.Slippage > test.want+1e-9 {
+			t.Errorf("%s at %s: slippage = %f, want %f", test.side, test.average, report.Slippage, test.want)
+		}
+		if !report.AveragePrice.Equal(b.MustDecimal(test.average)) || report.Remaining.Sign() != 0 {
+			t.Errorf("%s: average = %s, remaining = %s", test.side, report.AveragePrice, report.Remaining)
+		}
+	}
+}
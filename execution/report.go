@@ -0,0 +1,156 @@
+package execution
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// Child 는 자식 주문 하나의 결과입니다. 주문에 실패하면 Err 가 있고 OrderID 는 비어 있습니다.
+// 취소나 체결 확인에 실패하거나 주문 요청의 응답을 받지 못해 체결 결과를 모르면 Unresolved 이며, 이후 GetOrderDetail 로 다시 확인합니다.
+// 응답을 받지 못한 주문은 OrderID 가 비어 있을 수 있습니다.
+type Child struct {
+	Time       time.Time
+	OrderID    string
+	Units      b.Decimal
+	Price      b.Decimal // 지정가 주문 가격, 시장가 주문은 0
+	Filled     b.Decimal
+	Total      b.Decimal // 체결 금액 (payment currency)
+	Fees       map[b.Currency]b.Decimal
+	Unresolved bool
+	Err        error
+}
+
+// AveragePrice 는 평균 체결 가격입니다. 체결이 없으면 0 입니다.
+func (c Child) AveragePrice() b.Decimal {
+	return c.Total.Div(c.Filled, 8)
+}
+
+func (c *Child) addFills(detail b.OrderDetail) {
+	for _, data := range detail.Contract {
+		c.Filled = c.Filled.Add(data.Units)
+		c.Total = c.Total.Add(data.Price.Mul(data.Units))
+		if data.Fee.Sign() != 0 {
+			if c.Fees == nil {
+				c.Fees = make(map[b.Currency]b.Decimal)
+			}
+			c.Fees[data.FeeCurrency] = c.Fees[data.FeeCurrency].Add(data.Fee)
+		}
+	}
+}
+
+// Report 는 Execute 의 결과입니다.
+// ArrivalPrice 는 시작할 때의 중간 호가이며, Slippage 는 평균 체결 가격이 ArrivalPrice 보다 불리한 정도 (bp) 입니다.
+// (매수는 평균 체결 가격이 높을수록, 매도는 낮을수록 양수)
+type Report struct {
+	Market       b.Market
+	Side         b.OrderSide
+	Units        b.Decimal
+	Filled       b.Decimal
+	Remaining    b.Decimal
+	Total        b.Decimal
+	AveragePrice b.Decimal
+	ArrivalPrice b.Decimal
+	Slippage     float64
+	Fees         map[b.Currency]b.Decimal
+	Children     []Child
+	Start        time.Time
+	End          time.Time
+}
+
+func (r *Report) add(child Child) {
+	r.Children = append(r.Children, child)
+	r.accumulate(child)
+}
+
+// resolve 는 결과를 몰랐던 index 번째 자식 주문의 최종 주문 상태를 반영합니다.
+func (r *Report) resolve(index int, detail b.OrderDetail) {
+	child := &r.Children[index]
+	child.addFills(detail)
+	child.Unresolved = false
+	child.Err = nil
+	r.accumulate(*child)
+}
+
+// inFlight 는 결과를 모르는 자식 주문의 수량입니다. 모두 체결되었을 수 있으므로 다음 자식 주문에서 빼야 합니다.
+func (r *Report) inFlight() b.Decimal {
+	result := b.Decimal{}
+	for _, data := range r.Children {
+		if data.Unresolved {
+			result = result.Add(data.Units)
+		}
+	}
+	return result
+}
+
+func (r *Report) accumulate(child Child) {
+	r.Filled = r.Filled.Add(child.Filled)
+	r.Total = r.Total.Add(child.Total)
+	for currency, fee := range child.Fees {
+		if r.Fees == nil {
+			r.Fees = make(map[b.Currency]b.Decimal)
+		}
+		r.Fees[currency] = r.Fees[currency].Add(fee)
+	}
+}
+
+// finish 는 누적된 체결로 평균 가격, 슬리피지를 계산합니다.
+func (r *Report) finish(end time.Time) {
+	r.End = end
+	r.Remaining = r.Units.Sub(r.Filled)
+	r.AveragePrice = r.Total.Div(r.Filled, 8)
+	r.Slippage = 0
+	if r.Filled.Sign() > 0 && r.ArrivalPrice.Sign() > 0 {
+		arrival := r.ArrivalPrice.Float64()
+		r.Slippage = (r.AveragePrice.Float64() - arrival) / arrival * 10000
+		if r.Side == b.Ask {
+			r.Slippage = -r.Slippage
+		}
+	}
+}
+
+// Failed 는 주문에 실패한 자식 주문입니다.
+func (r *Report) Failed() []Child {
+	var result []Child
+	for _, data := range r.Children {
+		if data.Err != nil {
+			result = append(result, data)
+		}
+	}
+	return result
+}
+
+// Unresolved 는 체결 결과를 확인하지 못한 자식 주문입니다. 주문 내역에서 직접 확인해야 합니다.
+func (r *Report) Unresolved() []Child {
+	var result []Child
+	for _, data := range r.Children {
+		if data.Unresolved {
+			result = append(result, data)
+		}
+	}
+	return result
+}
+
+func (r *Report) String() string {
+	var builder strings.Builder
+	timeForm := "2006-01-02 15:04:05"
+	fmt.Fprintf(&builder, "Market        %s (%s)\n", r.Market, r.Side)
+	fmt.Fprintf(&builder, "Period        %s ~ %s (%d orders, %d failed, %d unresolved)\n", r.Start.Format(timeForm), r.End.Format(timeForm), len(r.Children), len(r.Failed()), len(r.Unresolved()))
+	fmt.Fprintf(&builder, "Filled        %s / %s\n", r.Filled, r.Units)
+	fmt.Fprintf(&builder, "Average price %s\n", r.AveragePrice.Round(2))
+	fmt.Fprintf(&builder, "Arrival price %s\n", r.ArrivalPrice.Round(2))
+	fmt.Fprintf(&builder, "Slippage      %.2f bp\n", r.Slippage)
+
+	currencies := make([]string, 0, len(r.Fees))
+	for currency := range r.Fees {
+		currencies = append(currencies, string(currency))
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		fmt.Fprintf(&builder, "Fees          %s %s\n", r.Fees[b.Currency(currency)], strings.ToUpper(currency))
+	}
+	return builder.String()
+}
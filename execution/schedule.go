@@ -0,0 +1,177 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// Slice 는 자식 주문 하나의 일정입니다. Start 에 Units 만큼 주문하며, 지정가 주문은 End 까지 기다립니다.
+type Slice struct {
+	Start time.Time
+	End   time.Time
+	Units b.Decimal
+}
+
+// Scheduler 는 부모 주문의 수량을 시간에 따라 나눕니다. 모든 Slice 의 Units 를 더하면 order.Units 입니다.
+type Scheduler interface {
+	Schedule(ctx context.Context, source b.MarketData, order Order, start time.Time) ([]Slice, error)
+}
+
+// TWAP 은 Duration 을 Slices 개의 같은 구간으로 나누고, 구간마다 같은 수량을 주문합니다.
+type TWAP struct {
+	Duration time.Duration
+	Slices   int
+}
+
+func (t TWAP) Schedule(ctx context.Context, source b.MarketData, order Order, start time.Time) ([]Slice, error) {
+	if err := checkSlices(t.Duration, t.Slices); err != nil {
+		return nil, err
+	}
+	weights := make([]float64, t.Slices)
+	for index := range weights {
+		weights[index] = 1
+	}
+	return split(order.Units, start, t.Duration, weights), nil
+}
+
+// VWAP 은 Duration 을 Slices 개의 같은 구간으로 나누고, 과거에 거래량이 많았던 시간대의 구간에 더 많이 주문합니다.
+// 최근 Days 일 동안의 GetCandleStick 거래량을 하루 중 시각 별로 모아 거래량 분포를 만들며, 거래량 자료가 없으면 TWAP 과 같습니다.
+type VWAP struct {
+	Duration time.Duration
+	Slices   int
+	Interval b.TimeInterval // 거래량 분포를 만들 캔들 간격 (기본값 30m)
+	Days     int            // 거래량 분포에 사용할 기간 (기본값 7일)
+}
+
+func (v VWAP) Schedule(ctx context.Context, source b.MarketData, order Order, start time.Time) ([]Slice, error) {
+	if err := checkSlices(v.Duration, v.Slices); err != nil {
+		return nil, err
+	}
+	interval := v.Interval
+	if interval == "" {
+		interval = b.Min30
+	}
+	days := v.Days
+	if days <= 0 {
+		days = 7
+	}
+	minutes, err := intervalMinutes(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	candles, err := source.GetCandleStickCtx(ctx, order.Market.Base, order.Market.Quote, interval)
+	if err != nil {
+		return nil, err
+	}
+	profile := volumeProfile{}
+	from := start.AddDate(0, 0, -days)
+	for _, data := range candles {
+		if data.Time.Before(from) || !data.Time.Before(start) {
+			continue
+		}
+		profile.add(data.Time, minutes, data.UnitsTraded.Float64())
+	}
+
+	step := v.Duration / time.Duration(v.Slices)
+	weights := make([]float64, v.Slices)
+	for index := range weights {
+		sliceStart := start.Add(step * time.Duration(index))
+		weights[index] = profile.weight(sliceStart, sliceStart.Add(step))
+	}
+	return split(order.Units, start, v.Duration, weights), nil
+}
+
+func checkSlices(duration time.Duration, slices int) error {
+	if slices <= 0 {
+		return fmt.Errorf("%w: 자식 주문 개수는 1 이상이어야 합니다. (%d)", b.ErrInvalidParameter, slices)
+	}
+	if duration < 0 {
+		return fmt.Errorf("%w: 주문 기간은 0보다 작을 수 없습니다. (%s)", b.ErrInvalidParameter, duration)
+	}
+	return nil
+}
+
+// split 은 units 를 weights 의 비율로 나눕니다. 가중치가 모두 0 이면 같은 수량으로 나눕니다.
+// 누적 수량을 기준으로 나누므로 반올림 오차가 쌓이지 않고, 마지막 Slice 에서 정확히 units 가 됩니다.
+func split(units b.Decimal, start time.Time, duration time.Duration, weights []float64) []Slice {
+	total := 0.0
+	for _, data := range weights {
+		total += data
+	}
+	if total <= 0 {
+		for index := range weights {
+			weights[index] = 1
+		}
+		total = float64(len(weights))
+	}
+
+	step := duration / time.Duration(len(weights))
+	result := make([]Slice, len(weights))
+	cumulative := 0.0
+	previous := b.Decimal{}
+	for index, data := range weights {
+		cumulative += data
+		target := units
+		if index < len(weights)-1 {
+			target = units.Mul(b.NewDecimalFromFloat(cumulative / total)).Floor(8)
+		}
+		sliceStart := start.Add(step * time.Duration(index))
+		result[index] = Slice{Start: sliceStart, End: sliceStart.Add(step), Units: target.Sub(previous)}
+		previous = target
+	}
+	result[len(result)-1].End = start.Add(duration)
+	return result
+}
+
+// volumeProfile 은 하루 중 분 (UTC 기준) 별 평균 거래량입니다.
+type volumeProfile [24 * 60]float64
+
+// add 는 date 에 시작하는 minutes 분 길이의 캔들 거래량을 분마다 고르게 나누어 더합니다.
+func (p *volumeProfile) add(date time.Time, minutes int, volume float64) {
+	first := minuteOfDay(date)
+	for offset := 0; offset < minutes; offset++ {
+		p[(first+offset)%len(p)] += volume / float64(minutes)
+	}
+}
+
+// weight 는 from ~ to 구간의 거래량 합입니다. 1분보다 짧은 부분은 길이에 비례해 더합니다.
+func (p *volumeProfile) weight(from time.Time, to time.Time) float64 {
+	total := 0.0
+	for current := from; current.Before(to); {
+		next := current.Truncate(time.Minute).Add(time.Minute)
+		if next.After(to) {
+			next = to
+		}
+		total += p[minuteOfDay(current)] * next.Sub(current).Minutes()
+		current = next
+	}
+	return total
+}
+
+func minuteOfDay(date time.Time) int {
+	date = date.UTC()
+	return date.Hour()*60 + date.Minute()
+}
+
+// intervalMinutes 는 "30m", "1h", "24h" 형태의 TimeInterval 을 분으로 바꿉니다.
+func intervalMinutes(interval b.TimeInterval) (int, error) {
+	raw := string(interval)
+	if len(raw) >= 2 {
+		count, err := strconv.Atoi(raw[:len(raw)-1])
+		if err == nil && count > 0 {
+			switch {
+			case strings.HasSuffix(raw, "m"):
+				return count, nil
+			case strings.HasSuffix(raw, "h"):
+				return count * 60, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: 잘못된 캔들 간격입니다. (%q)", b.ErrInvalidParameter, interval)
+}
@@ -0,0 +1,72 @@
+// Package indicators 는 []OneCandleStick 으로 SMA, EMA, RSI, MACD, Bollinger Bands, ATR, VWAP 을 계산합니다.
+//
+// 모든 지표는 두 가지로 사용할 수 있습니다.
+//   - SMAValues 처럼 ...Values 함수는 캔들 전체를 받아 같은 길이의 결과를 반환합니다. 값이 준비되지 않은 앞부분 (warm-up) 은 NaN 입니다.
+//   - NewSMA 처럼 New... 로 만든 지표는 봉이 끝날 때마다 Update 를 호출해 값을 하나씩 계산합니다. 준비되지 않았으면 ok 가 false 입니다.
+//
+// ...Values 함수는 내부에서 같은 지표에 Update 를 차례로 호출하므로 두 방식의 결과는 항상 같습니다.
+// 가격은 별도 언급이 없으면 종가를 사용하며, warm-up 길이와 초기값은 TA-Lib 과 같습니다.
+//
+//	rsi, err := indicators.RSIValues(candles, 14)
+//	if indicators.Ready(rsi[len(rsi)-1]) && rsi[len(rsi)-1] < 30 {
+//		// 과매도
+//	}
+package indicators
+
+import (
+	"fmt"
+	"math"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+var nan = math.NaN()
+
+// Ready 는 ...Values 결과의 값이 warm-up 이 끝난 값인지 확인합니다.
+func Ready(value float64) bool {
+	return !math.IsNaN(value)
+}
+
+func checkPeriod(name string, period int) error {
+	if period < 1 {
+		return fmt.Errorf("%w: %s 의 기간은 1 이상이어야 합니다. (%d)", b.ErrInvalidParameter, name, period)
+	}
+	return nil
+}
+
+// nanSlice 는 모든 값이 NaN 인 길이 length 의 slice 를 반환합니다.
+func nanSlice(length int) []float64 {
+	result := make([]float64, length)
+	for index := range result {
+		result[index] = nan
+	}
+	return result
+}
+
+// closeOf, highOf, lowOf, volumeOf 는 캔들 값을 float64 로 바꿉니다.
+func closeOf(bar b.OneCandleStick) float64 {
+	return bar.ClosingPrice.Float64()
+}
+
+func highOf(bar b.OneCandleStick) float64 {
+	return bar.HighPrice.Float64()
+}
+
+func lowOf(bar b.OneCandleStick) float64 {
+	return bar.LowPrice.Float64()
+}
+
+func volumeOf(bar b.OneCandleStick) float64 {
+	return bar.UnitsTraded.Float64()
+}
+
+// seriesOf 는 update 를 모든 캔들에 차례로 적용해 ...Values 결과를 만듭니다.
+func seriesOf(candles []b.OneCandleStick, update func(bar b.OneCandleStick) (float64, bool)) []float64 {
+	result := nanSlice(len(candles))
+	for index, bar := range candles {
+		if value, ok := update(bar); ok {
+			result[index] = value
+		}
+	}
+	return result
+}
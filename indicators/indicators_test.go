@@ -0,0 +1,69 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// closesOf 는 종가만 있는 캔들을 만듭니다.
+func closesOf(closes ...float64) []b.OneCandleStick {
+	result := make([]b.OneCandleStick, len(closes))
+	for index, data := range closes {
+		result[index].ClosingPrice = b.NewDecimalFromFloat(data)
+	}
+	return result
+}
+
+// barOf 는 고가, 저가, 종가, 거래량이 있는 캔들을 만듭니다.
+func barOf(high float64, low float64, close float64, volume float64) b.OneCandleStick {
+	bar := b.OneCandleStick{}
+	bar.HighPrice = b.NewDecimalFromFloat(high)
+	bar.LowPrice = b.NewDecimalFromFloat(low)
+	bar.ClosingPrice = b.NewDecimalFromFloat(close)
+	bar.UnitsTraded = b.NewDecimalFromFloat(volume)
+	return bar
+}
+
+// checkWarmUp 은 앞의 warmUp 개가 NaN 이고 그 뒤는 모두 준비된 값인지 확인합니다.
+func checkWarmUp(t *testing.T, name string, values []float64, warmUp int) {
+	t.Helper()
+	for index, data := range values {
+		if index < warmUp && Ready(data) {
+			t.Errorf("%s[%d] = %v, want NaN during warm-up of %d", name, index, data, warmUp)
+		}
+		if index >= warmUp && !Ready(data) {
+			t.Errorf("%s[%d] = NaN, want value after warm-up of %d", name, index, warmUp)
+		}
+	}
+}
+
+// checkValues 는 warm-up 이 끝난 값을 reference 와 tolerance 이내로 비교합니다.
+func checkValues(t *testing.T, name string, values []float64, warmUp int, reference []float64, tolerance float64) {
+	t.Helper()
+	if len(values) != warmUp+len(reference) {
+		t.Fatalf("%s has %d values, want %d", name, len(values), warmUp+len(reference))
+	}
+	checkWarmUp(t, name, values, warmUp)
+	for index, want := range reference {
+		if got := values[warmUp+index]; math.Abs(got-want) > tolerance {
+			t.Errorf("%s[%d] = %.6f, want %.6f", name, warmUp+index, got, want)
+		}
+	}
+}
+
+func TestInvalidPeriod(t *testing.T) {
+	if _, err := SMAValues(nil, 0); err == nil {
+		t.Error("SMA period 0 should fail")
+	}
+	if _, err := RSIValues(nil, -1); err == nil {
+		t.Error("RSI period -1 should fail")
+	}
+	if _, err := MACDValues(nil, 26, 12, 9); err == nil {
+		t.Error("MACD fast >= slow should fail")
+	}
+	if _, err := BollingerValues(nil, 20, 0); err == nil {
+		t.Error("Bollinger multiplier 0 should fail")
+	}
+}
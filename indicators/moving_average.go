@@ -0,0 +1,133 @@
+package indicators
+
+import (
+	b "github.com/lutergs/gobithumb"
+)
+
+//==============================SMA======================================
+
+// SMA 는 최근 period 개 종가의 단순 이동평균입니다. 처음 period-1 개는 준비되지 않습니다.
+type SMA struct {
+	window window
+}
+
+func NewSMA(period int) (*SMA, error) {
+	if err := checkPeriod("SMA", period); err != nil {
+		return nil, err
+	}
+	sma := SMA{}
+	sma.window = newWindow(period)
+	return &sma, nil
+}
+
+// Update 는 끝난 봉을 하나 더하고 현재 값을 반환합니다.
+func (s *SMA) Update(bar b.OneCandleStick) (float64, bool) {
+	return s.update(closeOf(bar))
+}
+
+func (s *SMA) update(value float64) (float64, bool) {
+	s.window.push(value)
+	if !s.window.full() {
+		return 0, false
+	}
+	return s.window.mean(), true
+}
+
+// SMAValues 는 모든 캔들의 SMA 를 반환합니다.
+func SMAValues(candles []b.OneCandleStick, period int) ([]float64, error) {
+	sma, err := NewSMA(period)
+	if err != nil {
+		return nil, err
+	}
+	return seriesOf(candles, sma.Update), nil
+}
+
+//==============================EMA======================================
+
+// EMA 는 종가의 지수 이동평균입니다. (alpha = 2 / (period + 1))
+// 첫 값은 처음 period 개 종가의 SMA 이므로, 처음 period-1 개는 준비되지 않습니다.
+type EMA struct {
+	period int
+	alpha  float64
+	count  int
+	value  float64
+}
+
+func NewEMA(period int) (*EMA, error) {
+	if err := checkPeriod("EMA", period); err != nil {
+		return nil, err
+	}
+	return newEMA(period), nil
+}
+
+func newEMA(period int) *EMA {
+	ema := EMA{}
+	ema.period = period
+	ema.alpha = 2 / float64(period+1)
+	return &ema
+}
+
+// Update 는 끝난 봉을 하나 더하고 현재 값을 반환합니다.
+func (e *EMA) Update(bar b.OneCandleStick) (float64, bool) {
+	return e.update(closeOf(bar))
+}
+
+func (e *EMA) update(value float64) (float64, bool) {
+	e.count++
+	if e.count <= e.period {
+		e.value += (value - e.value) / float64(e.count)
+		return e.value, e.count == e.period
+	}
+	e.value += e.alpha * (value - e.value)
+	return e.value, true
+}
+
+// EMAValues 는 모든 캔들의 EMA 를 반환합니다.
+func EMAValues(candles []b.OneCandleStick, period int) ([]float64, error) {
+	ema, err := NewEMA(period)
+	if err != nil {
+		return nil, err
+	}
+	return seriesOf(candles, ema.Update), nil
+}
+
+//==============================WINDOW======================================
+
+// window 는 최근 size 개의 값을 담는 ring buffer 입니다.
+type window struct {
+	values []float64
+	next   int
+	count  int
+	sum    float64
+}
+
+func newWindow(size int) window {
+	return window{values: make([]float64, size)}
+}
+
+func (w *window) push(value float64) {
+	if w.full() {
+		w.sum -= w.values[w.next]
+	} else {
+		w.count++
+	}
+	w.values[w.next] = value
+	w.sum += value
+	w.next = (w.next + 1) % len(w.values)
+
+	// 오차가 쌓이지 않도록 한 바퀴마다 합을 다시 계산
+	if w.next == 0 {
+		w.sum = 0
+		for _, data := range w.values {
+			w.sum += data
+		}
+	}
+}
+
+func (w *window) full() bool {
+	return w.count == len(w.values)
+}
+
+func (w *window) mean() float64 {
+	return w.sum / float64(w.count)
+}
@@ -0,0 +1,53 @@
+package indicators
+
+import (
+	"testing"
+)
+
+// StockCharts ChartSchool 의 이동평균 예시 (10일)
+var movingAverageCloses = []float64{
+	22.27, 22.19, 22.08, 22.17, 22.18, 22.13, 22.23, 22.43, 22.24, 22.29,
+	22.15, 22.39, 22.38, 22.61, 23.36, 24.05, 23.75, 23.83, 23.95, 23.63,
+	23.82, 23.87, 23.65, 23.19, 23.10, 23.33, 22.68, 23.10, 22.40, 22.17,
+}
+
+func TestSMA(t *testing.T) {
+	values, err := SMAValues(closesOf(movingAverageCloses...), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference := []float64{
+		22.22, 22.21, 22.23, 22.26, 22.30, 22.42, 22.61, 22.77, 22.91, 23.08, 23.21,
+		23.38, 23.53, 23.65, 23.71, 23.68, 23.61, 23.50, 23.43, 23.28, 23.13,
+	}
+	checkValues(t, "SMA(10)", values, 9, reference, 0.01)
+}
+
+func TestEMA(t *testing.T) {
+	values, err := EMAValues(closesOf(movingAverageCloses...), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference := []float64{
+		22.22, 22.21, 22.24, 22.27, 22.33, 22.52, 22.80, 22.97, 23.13, 23.28, 23.34,
+		23.43, 23.51, 23.54, 23.47, 23.40, 23.39, 23.26, 23.23, 23.08, 22.92,
+	}
+	checkValues(t, "EMA(10)", values, 9, reference, 0.01)
+}
+
+func TestMovingAverageStreaming(t *testing.T) {
+	candles := closesOf(movingAverageCloses...)
+	values, _ := EMAValues(candles, 10)
+	ema, _ := NewEMA(10)
+	for index, bar := range candles {
+		value, ok := ema.Update(bar)
+		if ok != Ready(values[index]) || (ok && value != values[index]) {
+			t.Fatalf("Update[%d] = %v, %v, want %v", index, value, ok, values[index])
+		}
+	}
+}
+
+func TestSMAPeriodOne(t *testing.T) {
+	values, _ := SMAValues(closesOf(1, 2, 3), 1)
+	checkValues(t, "SMA(1)", values, 0, []float64{1, 2, 3}, 0)
+}
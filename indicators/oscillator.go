@@ -0,0 +1,155 @@
+package indicators
+
+import (
+	"fmt"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+//==============================RSI======================================
+
+// RSI 는 Wilder 방식의 상대강도지수 (0~100) 입니다.
+// 처음 period 개의 종가 변화량의 평균으로 시작하므로, 처음 period 개는 준비되지 않습니다.
+// 기간 중 하락이 없으면 100, 변화가 전혀 없으면 50 입니다.
+type RSI struct {
+	period   int
+	started  bool
+	previous float64
+	count    int
+	avgGain  float64
+	avgLoss  float64
+}
+
+func NewRSI(period int) (*RSI, error) {
+	if err := checkPeriod("RSI", period); err != nil {
+		return nil, err
+	}
+	rsi := RSI{}
+	rsi.period = period
+	return &rsi, nil
+}
+
+// Update 는 끝난 봉을 하나 더하고 현재 값을 반환합니다.
+func (r *RSI) Update(bar b.OneCandleStick) (float64, bool) {
+	value := closeOf(bar)
+	if !r.started {
+		r.started = true
+		r.previous = value
+		return 0, false
+	}
+
+	gain, loss := 0.0, 0.0
+	if change := value - r.previous; change > 0 {
+		gain = change
+	} else {
+		loss = -change
+	}
+	r.previous = value
+
+	r.count++
+	if r.count <= r.period {
+		r.avgGain += gain / float64(r.period)
+		r.avgLoss += loss / float64(r.period)
+		if r.count < r.period {
+			return 0, false
+		}
+	} else {
+		r.avgGain = (r.avgGain*float64(r.period-1) + gain) / float64(r.period)
+		r.avgLoss = (r.avgLoss*float64(r.period-1) + loss) / float64(r.period)
+	}
+
+	if r.avgLoss == 0 {
+		if r.avgGain == 0 {
+			return 50, true
+		}
+		return 100, true
+	}
+	return 100 - 100/(1+r.avgGain/r.avgLoss), true
+}
+
+// RSIValues 는 모든 캔들의 RSI 를 반환합니다.
+func RSIValues(candles []b.OneCandleStick, period int) ([]float64, error) {
+	rsi, err := NewRSI(period)
+	if err != nil {
+		return nil, err
+	}
+	return seriesOf(candles, rsi.Update), nil
+}
+
+//==============================MACD======================================
+
+// MACDValue 는 MACD 선 (fast EMA - slow EMA), signal 선 (MACD 선의 EMA), 그 차이입니다.
+type MACDValue struct {
+	MACD      float64
+	Signal    float64
+	Histogram float64
+}
+
+// MACD 는 종가의 MACD 입니다. (보통 12, 26, 9)
+// MACD 선은 slow-1 번째 봉부터, signal 선은 그 뒤 signal-1 개의 봉이 더 지나야 준비되며, Update 는 둘 다 준비된 뒤부터 ok 입니다.
+// TA-Lib 과 같이 fast EMA 도 slow-1 번째 봉에서 시작하도록, 처음 slow-fast 개의 봉은 fast EMA 에 넣지 않습니다.
+type MACD struct {
+	fast   *EMA
+	slow   *EMA
+	signal *EMA
+	skip   int
+}
+
+func NewMACD(fast int, slow int, signal int) (*MACD, error) {
+
+	// parameter 정상 체크
+	for _, period := range []int{fast, slow, signal} {
+		if err := checkPeriod("MACD", period); err != nil {
+			return nil, err
+		}
+	}
+	if fast >= slow {
+		return nil, fmt.Errorf("%w: MACD 의 fast 기간은 slow 기간보다 짧아야 합니다. (%d, %d)", b.ErrInvalidParameter, fast, slow)
+	}
+
+	macd := MACD{}
+	macd.fast = newEMA(fast)
+	macd.slow = newEMA(slow)
+	macd.signal = newEMA(signal)
+	macd.skip = slow - fast
+	return &macd, nil
+}
+
+// Update 는 끝난 봉을 하나 더하고 현재 값을 반환합니다.
+func (m *MACD) Update(bar b.OneCandleStick) (MACDValue, bool) {
+	value := closeOf(bar)
+	slow, ok := m.slow.update(value)
+	if m.skip > 0 {
+		m.skip--
+		return MACDValue{}, false
+	}
+	fast, _ := m.fast.update(value)
+	if !ok {
+		return MACDValue{}, false
+	}
+
+	line := fast - slow
+	signal, ok := m.signal.update(line)
+	if !ok {
+		return MACDValue{}, false
+	}
+	return MACDValue{MACD: line, Signal: signal, Histogram: line - signal}, true
+}
+
+// MACDValues 는 모든 캔들의 MACD 를 반환합니다. 준비되지 않은 값은 세 필드 모두 NaN 입니다.
+func MACDValues(candles []b.OneCandleStick, fast int, slow int, signal int) ([]MACDValue, error) {
+	macd, err := NewMACD(fast, slow, signal)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]MACDValue, len(candles))
+	for index, bar := range candles {
+		value, ok := macd.Update(bar)
+		if !ok {
+			value = MACDValue{MACD: nan, Signal: nan, Histogram: nan}
+		}
+		result[index] = value
+	}
+	return result, nil
+}
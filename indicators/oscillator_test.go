@@ -0,0 +1,107 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+)
+
+// StockCharts ChartSchool 의 RSI 예시 (Wilder, 14일)
+var rsiCloses = []float64{
+	44.3389, 44.0902, 44.1497, 43.6124, 44.3278, 44.8264, 45.0955, 45.4245, 45.8433, 46.0826,
+	45.8931, 46.0328, 45.6140, 46.2820, 46.2820, 46.0028, 46.0328, 46.4116, 46.2222, 45.6439,
+	46.2122, 46.2521, 45.7137, 46.4515, 45.7835, 45.3548, 44.0288, 44.1783, 44.2181, 44.5672,
+	43.4205, 42.6628, 43.1314,
+}
+
+func TestRSI(t *testing.T) {
+	values, err := RSIValues(closesOf(rsiCloses...), 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference := []float64{
+		70.53, 66.32, 66.55, 69.41, 66.36, 57.97, 62.93, 63.26, 56.06, 62.38,
+		54.71, 50.42, 39.99, 41.46, 41.87, 45.46, 37.30, 33.08, 37.77,
+	}
+	checkValues(t, "RSI(14)", values, 14, reference, 0.01)
+}
+
+func TestRSIEdgeCases(t *testing.T) {
+	flat := make([]float64, 20)
+	rising := make([]float64, 20)
+	for index := range flat {
+		flat[index] = 100
+		rising[index] = float64(100 + index)
+	}
+
+	values, _ := RSIValues(closesOf(flat...), 14)
+	checkWarmUp(t, "RSI(flat)", values, 14)
+	for index := 14; index < len(values); index++ {
+		if values[index] != 50 {
+			t.Errorf("RSI(flat)[%d] = %v, want 50", index, values[index])
+		}
+	}
+
+	values, _ = RSIValues(closesOf(rising...), 14)
+	checkWarmUp(t, "RSI(no loss)", values, 14)
+	for index := 14; index < len(values); index++ {
+		if values[index] != 100 {
+			t.Errorf("RSI(no loss)[%d] = %v, want 100", index, values[index])
+		}
+	}
+}
+
+// referenceEMA 는 values[start:] 에 대해 TA-Lib 방식 (처음 period 개의 SMA 로 시작) 의 EMA 를 계산합니다.
+// 결과의 index 는 values 와 같고, 준비되지 않은 값은 NaN 입니다.
+func referenceEMA(values []float64, period int, start int) []float64 {
+	result := nanSlice(len(values))
+	seed := start + period - 1
+	if seed >= len(values) {
+		return result
+	}
+	sum := 0.0
+	for index := start; index <= seed; index++ {
+		sum += values[index]
+	}
+	result[seed] = sum / float64(period)
+	alpha := 2 / float64(period+1)
+	for index := seed + 1; index < len(values); index++ {
+		result[index] = result[index-1] + alpha*(values[index]-result[index-1])
+	}
+	return result
+}
+
+func TestMACD(t *testing.T) {
+	closes := make([]float64, 60)
+	for index := range closes {
+		closes[index] = 100 + 10*math.Sin(float64(index)/5) + float64(index)/3
+	}
+	values, err := MACDValues(closesOf(closes...), 12, 26, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// TA-Lib 의 MACD 는 fast, slow EMA 모두 slow-1 번째 봉에서 시작하며, lookback 은 slow + signal - 2 (33) 입니다.
+	fast := referenceEMA(closes, 12, 26-12)
+	slow := referenceEMA(closes, 26, 0)
+	line := make([]float64, len(closes))
+	for index := range line {
+		line[index] = fast[index] - slow[index]
+	}
+	signal := referenceEMA(line, 9, 25)
+
+	const warmUp = 33
+	macdLine := make([]float64, len(values))
+	for index, data := range values {
+		macdLine[index] = data.MACD
+		if index < warmUp {
+			continue
+		}
+		if math.Abs(data.MACD-line[index]) > 1e-9 || math.Abs(data.Signal-signal[index]) > 1e-9 {
+			t.Errorf("MACD[%d] = %+v, want %v, %v", index, data, line[index], signal[index])
+		}
+		if math.Abs(data.Histogram-(line[index]-signal[index])) > 1e-9 {
+			t.Errorf("MACD[%d].Histogram = %v, want %v", index, data.Histogram, line[index]-signal[index])
+		}
+	}
+	checkWarmUp(t, "MACD(12, 26, 9)", macdLine, warmUp)
+}
@@ -0,0 +1,125 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+//==============================BOLLINGER BANDS======================================
+
+// Band 는 Bollinger Bands 의 상단, 중심선 (SMA), 하단입니다.
+type Band struct {
+	Upper  float64
+	Middle float64
+	Lower  float64
+}
+
+// Bollinger 는 종가의 Bollinger Bands 입니다. (보통 20, 2)
+// 표준편차는 모표준편차이며, 처음 period-1 개는 준비되지 않습니다.
+type Bollinger struct {
+	window     window
+	multiplier float64
+}
+
+func NewBollinger(period int, multiplier float64) (*Bollinger, error) {
+
+	// parameter 정상 체크
+	if err := checkPeriod("Bollinger Bands", period); err != nil {
+		return nil, err
+	}
+	if !(multiplier > 0) {
+		return nil, fmt.Errorf("%w: Bollinger Bands 의 표준편차 배수는 0보다 커야 합니다.", b.ErrInvalidParameter)
+	}
+
+	bollinger := Bollinger{}
+	bollinger.window = newWindow(period)
+	bollinger.multiplier = multiplier
+	return &bollinger, nil
+}
+
+// Update 는 끝난 봉을 하나 더하고 현재 값을 반환합니다.
+func (bb *Bollinger) Update(bar b.OneCandleStick) (Band, bool) {
+	bb.window.push(closeOf(bar))
+	if !bb.window.full() {
+		return Band{}, false
+	}
+
+	mean := bb.window.mean()
+	variance := 0.0
+	for _, data := range bb.window.values {
+		variance += (data - mean) * (data - mean)
+	}
+	width := bb.multiplier * math.Sqrt(variance/float64(len(bb.window.values)))
+	return Band{Upper: mean + width, Middle: mean, Lower: mean - width}, true
+}
+
+// BollingerValues 는 모든 캔들의 Bollinger Bands 를 반환합니다. 준비되지 않은 값은 세 필드 모두 NaN 입니다.
+func BollingerValues(candles []b.OneCandleStick, period int, multiplier float64) ([]Band, error) {
+	bollinger, err := NewBollinger(period, multiplier)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Band, len(candles))
+	for index, bar := range candles {
+		value, ok := bollinger.Update(bar)
+		if !ok {
+			value = Band{Upper: nan, Middle: nan, Lower: nan}
+		}
+		result[index] = value
+	}
+	return result, nil
+}
+
+//==============================ATR======================================
+
+// ATR 은 Wilder 방식의 평균 진폭 (Average True Range) 입니다.
+// True Range 는 이전 종가가 필요하므로 두 번째 봉부터 계산하며, 처음 period 개는 준비되지 않습니다.
+type ATR struct {
+	period   int
+	started  bool
+	previous float64
+	count    int
+	value    float64
+}
+
+func NewATR(period int) (*ATR, error) {
+	if err := checkPeriod("ATR", period); err != nil {
+		return nil, err
+	}
+	atr := ATR{}
+	atr.period = period
+	return &atr, nil
+}
+
+// Update 는 끝난 봉을 하나 더하고 현재 값을 반환합니다.
+func (a *ATR) Update(bar b.OneCandleStick) (float64, bool) {
+	high, low := highOf(bar), lowOf(bar)
+	if !a.started {
+		a.started = true
+		a.previous = closeOf(bar)
+		return 0, false
+	}
+
+	trueRange := math.Max(high, a.previous) - math.Min(low, a.previous)
+	a.previous = closeOf(bar)
+
+	a.count++
+	if a.count <= a.period {
+		a.value += trueRange / float64(a.period)
+		return a.value, a.count == a.period
+	}
+	a.value = (a.value*float64(a.period-1) + trueRange) / float64(a.period)
+	return a.value, true
+}
+
+// ATRValues 는 모든 캔들의 ATR 을 반환합니다.
+func ATRValues(candles []b.OneCandleStick, period int) ([]float64, error) {
+	atr, err := NewATR(period)
+	if err != nil {
+		return nil, err
+	}
+	return seriesOf(candles, atr.Update), nil
+}
@@ -0,0 +1,61 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+func TestBollinger(t *testing.T) {
+	values, err := BollingerValues(closesOf(1, 2, 3, 4, 5, 7), 5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	middle := make([]float64, len(values))
+	for index, data := range values {
+		middle[index] = data.Middle
+	}
+	checkWarmUp(t, "Bollinger(5, 2)", middle, 4)
+
+	// 1~5 의 평균 3, 모분산 2 / 2~5, 7 의 평균 4.2, 모분산 2.96
+	reference := []Band{
+		{Upper: 3 + 2*math.Sqrt(2), Middle: 3, Lower: 3 - 2*math.Sqrt(2)},
+		{Upper: 4.2 + 2*math.Sqrt(2.96), Middle: 4.2, Lower: 4.2 - 2*math.Sqrt(2.96)},
+	}
+	for index, want := range reference {
+		got := values[4+index]
+		if math.Abs(got.Upper-want.Upper) > 1e-9 || math.Abs(got.Middle-want.Middle) > 1e-9 || math.Abs(got.Lower-want.Lower) > 1e-9 {
+			t.Errorf("Bollinger[%d] = %+v, want %+v", 4+index, got, want)
+		}
+	}
+}
+
+func TestBollingerMatchesSMA(t *testing.T) {
+	candles := closesOf(movingAverageCloses...)
+	bands, _ := BollingerValues(candles, 10, 2)
+	sma, _ := SMAValues(candles, 10)
+	for index := range bands {
+		if Ready(sma[index]) != Ready(bands[index].Middle) || (Ready(sma[index]) && math.Abs(sma[index]-bands[index].Middle) > 1e-9) {
+			t.Errorf("Bollinger[%d].Middle = %v, want SMA %v", index, bands[index].Middle, sma[index])
+		}
+	}
+}
+
+func TestATR(t *testing.T) {
+	candles := []b.OneCandleStick{
+		barOf(10, 8, 9, 0),
+		barOf(11, 9, 10, 0),  // TR 2
+		barOf(12, 9, 11, 0),  // TR 3
+		barOf(13, 10, 10, 0), // TR 3
+		barOf(15, 12, 14, 0), // TR 5 (고가 - 이전 종가)
+		barOf(14, 13, 13, 0), // TR 1
+	}
+	values, err := ATRValues(candles, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 첫 값은 처음 3개 TR 의 평균, 이후는 Wilder 평활 (이전 * 2 + TR) / 3
+	checkValues(t, "ATR(3)", values, 3, []float64{8.0 / 3, 31.0 / 9, 71.0 / 27}, 1e-9)
+}
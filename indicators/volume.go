@@ -0,0 +1,42 @@
+package indicators
+
+import (
+	b "github.com/lutergs/gobithumb"
+)
+
+//==============================VWAP======================================
+
+// VWAP 은 거래량 가중 평균 가격입니다. 봉의 가격은 대표 가격 (고가 + 저가 + 종가) / 3 을 사용합니다.
+// 첫 봉부터 누적하며, 거래량이 있는 봉이 나오기 전까지는 준비되지 않습니다. 세션마다 다시 계산하려면 Reset 을 호출하세요.
+type VWAP struct {
+	priceVolume float64
+	volume      float64
+}
+
+func NewVWAP() *VWAP {
+	vwap := VWAP{}
+	return &vwap
+}
+
+// Update 는 끝난 봉을 하나 더하고 현재 값을 반환합니다.
+func (v *VWAP) Update(bar b.OneCandleStick) (float64, bool) {
+	typical := (highOf(bar) + lowOf(bar) + closeOf(bar)) / 3
+	volume := volumeOf(bar)
+	v.priceVolume += typical * volume
+	v.volume += volume
+	if v.volume <= 0 {
+		return 0, false
+	}
+	return v.priceVolume / v.volume, true
+}
+
+// Reset 은 누적 값을 지웁니다.
+func (v *VWAP) Reset() {
+	v.priceVolume = 0
+	v.volume = 0
+}
+
+// VWAPValues 는 첫 캔들부터 누적한 VWAP 을 반환합니다.
+func VWAPValues(candles []b.OneCandleStick) []float64 {
+	return seriesOf(candles, NewVWAP().Update)
+}
@@ -0,0 +1,28 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+func TestVWAP(t *testing.T) {
+	candles := []b.OneCandleStick{
+		barOf(10, 8, 9, 0),   // 거래량이 없으면 준비되지 않음
+		barOf(12, 9, 12, 2),  // 대표 가격 11
+		barOf(15, 12, 12, 2), // 대표 가격 13
+		barOf(13, 10, 10, 4), // 대표 가격 11
+	}
+	values := VWAPValues(candles)
+	checkValues(t, "VWAP", values, 1, []float64{11, 12, 11.5}, 1e-9)
+
+	vwap := NewVWAP()
+	for _, bar := range candles {
+		vwap.Update(bar)
+	}
+	vwap.Reset()
+	if value, ok := vwap.Update(candles[2]); !ok || math.Abs(value-13) > 1e-9 {
+		t.Errorf("VWAP after Reset = %v, %v, want 13", value, ok)
+	}
+}
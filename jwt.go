@@ -0,0 +1,61 @@
+package gobithumb
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// API 2.0 (/v1/*) 의 인증 방식입니다. 요청마다 HS256 으로 서명한 JWT 를 Authorization: Bearer 헤더로 보냅니다.
+// parameter 가 있으면 query string 의 SHA512 hash 를 query_hash claim 에 담아, 서버가 parameter 변조를 확인할 수 있게 합니다.
+
+type jwtClaims struct {
+	AccessKey    string `json:"access_key"`
+	Nonce        string `json:"nonce"`
+	Timestamp    int64  `json:"timestamp"`
+	QueryHash    string `json:"query_hash,omitempty"`
+	QueryHashAlg string `json:"query_hash_alg,omitempty"`
+}
+
+// jwtToken 은 query (url.Values.Encode() 결과) 에 대한 JWT 를 만듭니다.
+func jwtToken(accessKey string, secretKey string, query string, now time.Time) (string, error) {
+	nonce, err := newUUID()
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwtClaims{AccessKey: accessKey, Nonce: nonce, Timestamp: now.UnixNano() / int64(time.Millisecond)}
+	if query != "" {
+		hash := sha512.Sum512([]byte(query))
+		claims.QueryHash = hex.EncodeToString(hash[:])
+		claims.QueryHashAlg = "SHA512"
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	encoding := base64.RawURLEncoding
+	unsigned := encoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encoding.EncodeToString(payload)
+
+	signature := hmac.New(sha256.New, []byte(secretKey))
+	signature.Write([]byte(unsigned))
+	return unsigned + "." + encoding.EncodeToString(signature.Sum(nil)), nil
+}
+
+// newUUID 는 nonce 로 사용할 무작위 UUID(v4) 를 만듭니다.
+func newUUID() (string, error) {
+	var raw [16]byte
+	if _, err := rand.Read(raw[:]); err != nil {
+		return "", err
+	}
+	raw[6] = raw[6]&0x0f | 0x40
+	raw[8] = raw[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", raw[0:4], raw[4:6], raw[6:8], raw[8:10], raw[10:16]), nil
+}
@@ -0,0 +1,236 @@
+package gobithumb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrOrderbookOutOfSync = errors.New("gobithumb: orderbook out of sync")
+
+const maxPendingDepthEvents = 4096
+
+// LiveOrderbook 은 GetOrderbook 의 snapshot 에 WebSocket 의 orderbookdepth 변경분을 적용해 호가창을 유지합니다.
+// 변경분의 순서가 뒤바뀌거나(datetime 역전) 매수 최고가가 매도 최저가 이상이 되면 snapshot 을 다시 받아옵니다.
+type LiveOrderbook struct {
+	requester       MarketData
+	orderCurrency   Currency
+	paymentCurrency Currency
+
+	mutex      sync.RWMutex
+	bids       []Bidask // 가격 내림차순
+	asks       []Bidask // 가격 오름차순
+	updatedAt  time.Time
+	snapshotAt time.Time
+	synced     bool
+
+	resyncInterval time.Duration
+	lastResync     time.Time
+	pending        []OrderbookDepthEvent
+}
+
+func NewLiveOrderbook(requester MarketData, orderCurrency Currency, paymentCurrency Currency) *LiveOrderbook {
+	return &LiveOrderbook{
+		requester:       requester,
+		orderCurrency:   orderCurrency,
+		paymentCurrency: paymentCurrency,
+		resyncInterval:  time.Second,
+	}
+}
+
+// Resync 는 GetOrderbook 으로 snapshot 을 다시 받아 호가창을 초기화합니다.
+// snapshot 이전에 받아둔 변경분은 버리고, 이후의 변경분은 다시 적용합니다.
+func (l *LiveOrderbook) Resync(ctx context.Context) error {
+	l.mutex.Lock()
+	l.lastResync = time.Now()
+	l.mutex.Unlock()
+
+	orderbooks, snapshotTime, err := l.requester.GetOrderbookCtx(ctx, l.orderCurrency, l.paymentCurrency)
+	if err != nil {
+		return err
+	}
+	snapshot := orderbooks[l.orderCurrency]
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.bids = append([]Bidask(nil), snapshot.Bids...)
+	l.asks = append([]Bidask(nil), snapshot.Asks...)
+	sort.Slice(l.bids, func(i, j int) bool { return l.bids[i].Price.GreaterThan(l.bids[j].Price) })
+	sort.Slice(l.asks, func(i, j int) bool { return l.asks[i].Price.LessThan(l.asks[j].Price) })
+	l.updatedAt = snapshotTime
+	l.snapshotAt = snapshotTime
+	l.synced = true
+
+	pending := l.pending
+	l.pending = nil
+	for _, event := range pending {
+		if err := l.applyLocked(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply 는 orderbookdepth 변경분 하나를 적용합니다. 다른 통화쌍의 항목은 무시합니다.
+// 호가창이 어긋난 것이 발견되면 ErrOrderbookOutOfSync 를 반환하며, Resync 전까지 변경분을 모아둡니다.
+func (l *LiveOrderbook) Apply(event OrderbookDepthEvent) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.synced {
+		if len(l.pending) >= maxPendingDepthEvents {
+			l.pending = l.pending[1:]
+		}
+		l.pending = append(l.pending, event)
+		return ErrOrderbookOutOfSync
+	}
+	return l.applyLocked(event)
+}
+
+func (l *LiveOrderbook) applyLocked(event OrderbookDepthEvent) error {
+	// snapshot 에 이미 반영된 변경분
+	if event.Time.Before(l.snapshotAt) {
+		return nil
+	}
+	if event.Time.Before(l.updatedAt) {
+		l.markOutOfSync()
+		return ErrOrderbookOutOfSync
+	}
+
+	for _, entry := range event.Entries {
+		if entry.OrderCurrency != l.orderCurrency || entry.PaymentCurrency != l.paymentCurrency {
+			continue
+		}
+		if entry.Type == Bid {
+			l.bids = updateLevel(l.bids, entry.Price, entry.Quantity, true)
+		} else if entry.Type == Ask {
+			l.asks = updateLevel(l.asks, entry.Price, entry.Quantity, false)
+		}
+	}
+	l.updatedAt = event.Time
+
+	if len(l.bids) > 0 && len(l.asks) > 0 && l.bids[0].Price.Cmp(l.asks[0].Price) >= 0 {
+		l.markOutOfSync()
+		return ErrOrderbookOutOfSync
+	}
+	return nil
+}
+
+func (l *LiveOrderbook) markOutOfSync() {
+	l.synced = false
+	l.pending = nil
+}
+
+// updateLevel 은 정렬된 호가 목록에서 price 의 잔량을 quantity 로 바꿉니다. quantity 가 0 이면 가격대를 지웁니다.
+func updateLevel(levels []Bidask, price Decimal, quantity Decimal, descending bool) []Bidask {
+	index := sort.Search(len(levels), func(i int) bool {
+		if descending {
+			return levels[i].Price.Cmp(price) <= 0
+		}
+		return levels[i].Price.Cmp(price) >= 0
+	})
+
+	found := index < len(levels) && levels[index].Price.Equal(price)
+	switch {
+	case found && quantity.Sign() <= 0:
+		return append(levels[:index], levels[index+1:]...)
+	case found:
+		levels[index].Quantity = quantity
+	case quantity.Sign() > 0:
+		levels = append(levels, Bidask{})
+		copy(levels[index+1:], levels[index:])
+		levels[index] = Bidask{Price: price, Quantity: quantity}
+	}
+	return levels
+}
+
+// Run 은 StreamClient 의 이벤트로 호가창을 갱신합니다. 처음 시작할 때, 다시 연결될 때, 이벤트가 버려졌을 때 (ErrStreamDropped)
+// 그리고 호가창이 어긋났을 때 자동으로 Resync 합니다. ctx 가 끝나면 ctx.Err() 를 반환합니다.
+func (l *LiveOrderbook) Run(ctx context.Context, stream *StreamClient) error {
+	if err := l.Resync(ctx); err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case status := <-stream.Status():
+			if status.Connected {
+				l.mutex.Lock()
+				l.markOutOfSync()
+				l.mutex.Unlock()
+				_ = l.Resync(ctx)
+			}
+		case event := <-stream.OrderbookDepths():
+			if err := l.Apply(event); err != nil && l.shouldResync() {
+				_ = l.Resync(ctx)
+			}
+		}
+	}
+}
+
+func (l *LiveOrderbook) shouldResync() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return time.Since(l.lastResync) >= l.resyncInterval
+}
+
+func (l *LiveOrderbook) Synced() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.synced
+}
+
+func (l *LiveOrderbook) UpdatedAt() time.Time {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.updatedAt
+}
+
+func (l *LiveOrderbook) BestBid() (Bidask, bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	if len(l.bids) == 0 {
+		return Bidask{}, false
+	}
+	return l.bids[0], true
+}
+
+func (l *LiveOrderbook) BestAsk() (Bidask, bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	if len(l.asks) == 0 {
+		return Bidask{}, false
+	}
+	return l.asks[0], true
+}
+
+// Depth 는 최우선 호가부터 최대 n 개 가격대를 복사해 반환합니다.
+func (l *LiveOrderbook) Depth(n int) ([]Bidask, []Bidask) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return copyLevels(l.bids, n), copyLevels(l.asks, n)
+}
+
+// Snapshot 은 현재 호가창 전체의 복사본을 반환합니다.
+func (l *LiveOrderbook) Snapshot() Orderbook {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return Orderbook{Bids: copyLevels(l.bids, len(l.bids)), Asks: copyLevels(l.asks, len(l.asks))}
+}
+
+func copyLevels(levels []Bidask, n int) []Bidask {
+	if n > len(levels) {
+		n = len(levels)
+	}
+	if n < 0 {
+		n = 0
+	}
+	result := make([]Bidask, n)
+	copy(result, levels[:n])
+	return result
+}
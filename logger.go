@@ -0,0 +1,85 @@
+package gobithumb
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "DEBUG"
+	case LogInfo:
+		return "INFO"
+	case LogWarn:
+		return "WARN"
+	case LogError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// LogField 는 로그에 함께 남길 key-value 값입니다. (e.g. endpoint, status, latency)
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger 를 구현하면 라이브러리의 로그를 원하는 곳(log/slog, zap 등)으로 보낼 수 있습니다.
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...LogField)
+}
+
+// LoggerFunc 는 일반 함수를 Logger 로 사용할 수 있게 해줍니다.
+type LoggerFunc func(level LogLevel, msg string, fields ...LogField)
+
+func (f LoggerFunc) Log(level LogLevel, msg string, fields ...LogField) {
+	f(level, msg, fields...)
+}
+
+// NopLogger 는 모든 로그를 버립니다. 라이브러리의 출력을 완전히 끄려면 WithLogger(NopLogger) 를 사용하세요.
+var NopLogger Logger = LoggerFunc(func(LogLevel, string, ...LogField) {})
+
+type stdLogger struct {
+	mutex    sync.Mutex
+	writer   io.Writer
+	minLevel LogLevel
+}
+
+// NewStdLogger 는 minLevel 이상의 로그를 writer 에 한 줄씩 기록하는 Logger 를 만듭니다.
+func NewStdLogger(writer io.Writer, minLevel LogLevel) Logger {
+	return &stdLogger{writer: writer, minLevel: minLevel}
+}
+
+func (s *stdLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	if level < s.minLevel {
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString(time.Now().Format(time.StampMilli))
+	builder.WriteString("\t")
+	builder.WriteString(level.String())
+	builder.WriteString("\t")
+	builder.WriteString(msg)
+	for _, field := range fields {
+		fmt.Fprintf(&builder, " %s=%v", field.Key, field.Value)
+	}
+	builder.WriteString("\n")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, _ = io.WriteString(s.writer, builder.String())
+}
@@ -0,0 +1,78 @@
+package gobithumb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Market 은 Base 를 Quote 로 사고파는 시장입니다. (e.g. BTC/KRW 는 Market{BTC, KRW}, ETH/BTC 는 Market{ETH, BTC})
+// API 의 order_currency 가 Base, payment_currency 가 Quote 입니다.
+type Market struct {
+	Base  Currency
+	Quote Currency
+}
+
+func NewMarket(base Currency, quote Currency) Market {
+	return Market{
+		Base:  Currency(strings.ToLower(string(base))),
+		Quote: Currency(strings.ToLower(string(quote))),
+	}
+}
+
+// ParseMarket 은 "BTC_KRW", "btc-krw", "ETH/BTC" 형태의 문자열을 Market 으로 바꿉니다.
+func ParseMarket(symbol string) (Market, error) {
+	index := strings.LastIndexAny(symbol, "_-/")
+	if index <= 0 || index == len(symbol)-1 {
+		return Market{}, fmt.Errorf("%w: 시장은 BASE_QUOTE 형태여야 합니다. (%q)", ErrInvalidParameter, symbol)
+	}
+	return NewMarket(Currency(symbol[:index]), Currency(symbol[index+1:])), nil
+}
+
+// String 은 Bithumb 이 사용하는 "BTC_KRW" 형태로 표현합니다.
+func (m Market) String() string {
+	return strings.ToUpper(string(m.Base) + "_" + string(m.Quote))
+}
+
+// MarshalText 는 JSON 등에서 "BTC_KRW" 형태로 저장합니다. zero value 는 빈 문자열입니다.
+func (m Market) MarshalText() ([]byte, error) {
+	if m == (Market{}) {
+		return []byte{}, nil
+	}
+	return []byte(m.String()), nil
+}
+
+func (m *Market) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*m = Market{}
+		return nil
+	}
+	market, err := ParseMarket(string(data))
+	if err != nil {
+		return err
+	}
+	*m = market
+	return nil
+}
+
+func (m Market) Valid() bool {
+	return m.Base != "" && m.Quote != "" && m.Base != m.Quote
+}
+
+// path 는 public API 주소에 붙는 "btc_krw" 형태의 경로입니다.
+func (m Market) path() string {
+	return strings.ToLower(string(m.Base) + "_" + string(m.Quote))
+}
+
+// v2ID 는 API 2.0 이 사용하는 "KRW-BTC" (quote-base) 형태의 시장 이름입니다.
+func (m Market) v2ID() string {
+	return strings.ToUpper(string(m.Quote) + "-" + string(m.Base))
+}
+
+// parseV2Market 은 "KRW-BTC" 형태의 API 2.0 시장 이름을 Market 으로 바꿉니다.
+func parseV2Market(id string) Market {
+	index := strings.Index(id, "-")
+	if index < 0 {
+		return Market{}
+	}
+	return NewMarket(Currency(id[index+1:]), Currency(id[:index]))
+}
@@ -0,0 +1,181 @@
+package gobithumb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrUnknownMarket = fmt.Errorf("%w: market is not listed", ErrInvalidParameter)
+
+// DefaultMarketRefresh 는 MarketRegistry 가 상장 목록을 다시 받아오는 기본 주기입니다.
+const DefaultMarketRefresh = 10 * time.Minute
+
+// MarketRegistry 는 현재 상장된 시장 목록을 ticker ALL 응답에서 받아와 보관합니다.
+// 목록은 refresh 주기가 지난 뒤 처음 조회할 때 다시 받아오며, GetTicker(ALL, ...) 응답으로도 갱신됩니다.
+type MarketRegistry struct {
+	source            MarketData
+	refresh           time.Duration
+	paymentCurrencies []Currency
+
+	refreshMutex sync.Mutex // 목록이 오래되었을 때 여러 goroutine 이 함께 다시 받아오지 않도록 막음
+
+	mutex    sync.RWMutex
+	markets  map[Currency][]Currency // payment currency -> order currency (이름 순)
+	loadedAt map[Currency]time.Time
+}
+
+// NewMarketRegistry 는 source 에서 KRW, BTC 시장 목록을 받아오는 MarketRegistry 를 만듭니다.
+// refresh 가 0 이하이면 한 번 받아온 목록을 Refresh 를 호출하기 전까지 계속 사용합니다.
+func NewMarketRegistry(source MarketData, refresh time.Duration) *MarketRegistry {
+
+	marketRegistry := MarketRegistry{}
+
+	marketRegistry.source = source
+	marketRegistry.refresh = refresh
+	marketRegistry.paymentCurrencies = []Currency{KRW, BTC}
+	marketRegistry.markets = make(map[Currency][]Currency)
+	marketRegistry.loadedAt = make(map[Currency]time.Time)
+
+	return &marketRegistry
+}
+
+// Refresh 는 모든 payment currency 의 시장 목록을 다시 받아옵니다.
+func (m *MarketRegistry) Refresh(ctx context.Context) error {
+	for _, paymentCurrency := range m.paymentCurrencies {
+		if err := m.refreshMarket(ctx, paymentCurrency); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MarketRegistry) refreshMarket(ctx context.Context, paymentCurrency Currency) error {
+	tickers, _, err := m.source.GetTickerCtx(ctx, ALL, paymentCurrency)
+	if err != nil {
+		return err
+	}
+	orderCurrencies := make([]Currency, 0, len(tickers))
+	for orderCurrency := range tickers {
+		orderCurrencies = append(orderCurrencies, orderCurrency)
+	}
+	m.update(paymentCurrency, orderCurrencies)
+	return nil
+}
+
+// update 는 paymentCurrency 시장의 목록을 바꿉니다.
+func (m *MarketRegistry) update(paymentCurrency Currency, orderCurrencies []Currency) {
+	result := make([]Currency, 0, len(orderCurrencies))
+	for _, data := range orderCurrencies {
+		result = append(result, Currency(strings.ToLower(string(data))))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.markets[paymentCurrency] = result
+	m.loadedAt[paymentCurrency] = time.Now()
+}
+
+// ensure 는 paymentCurrency 시장의 목록이 없거나 오래되었으면 다시 받아옵니다.
+// 여러 goroutine 이 동시에 호출해도 한 번만 받아오고, 나머지는 그 결과를 사용합니다.
+func (m *MarketRegistry) ensure(ctx context.Context, paymentCurrency Currency) error {
+	if m.fresh(paymentCurrency) {
+		return nil
+	}
+
+	m.refreshMutex.Lock()
+	defer m.refreshMutex.Unlock()
+	// 기다리는 동안 다른 goroutine 이 이미 받아왔을 수 있음
+	if m.fresh(paymentCurrency) {
+		return nil
+	}
+	return m.refreshMarket(ctx, paymentCurrency)
+}
+
+func (m *MarketRegistry) fresh(paymentCurrency Currency) bool {
+	m.mutex.RLock()
+	loadedAt, ok := m.loadedAt[paymentCurrency]
+	m.mutex.RUnlock()
+	return ok && (m.refresh <= 0 || time.Since(loadedAt) < m.refresh)
+}
+
+// Markets 는 paymentCurrency 로 거래할 수 있는 order currency 목록을 이름 순으로 반환합니다.
+func (m *MarketRegistry) Markets(ctx context.Context, paymentCurrency Currency) ([]Currency, error) {
+	if err := m.ensure(ctx, paymentCurrency); err != nil {
+		return nil, err
+	}
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]Currency(nil), m.markets[paymentCurrency]...), nil
+}
+
+// Currencies 는 어느 시장에든 상장된 모든 currency 를 (payment currency 포함) 이름 순으로 반환합니다.
+func (m *MarketRegistry) Currencies(ctx context.Context) ([]Currency, error) {
+	unique := make(map[Currency]bool)
+	for _, paymentCurrency := range m.paymentCurrencies {
+		markets, err := m.Markets(ctx, paymentCurrency)
+		if err != nil {
+			return nil, err
+		}
+		unique[paymentCurrency] = true
+		for _, data := range markets {
+			unique[data] = true
+		}
+	}
+
+	result := make([]Currency, 0, len(unique))
+	for data := range unique {
+		result = append(result, data)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result, nil
+}
+
+// List 는 모든 payment currency 의 상장 시장을 반환합니다.
+func (m *MarketRegistry) List(ctx context.Context) ([]Market, error) {
+	var result []Market
+	for _, paymentCurrency := range m.paymentCurrencies {
+		markets, err := m.Markets(ctx, paymentCurrency)
+		if err != nil {
+			return nil, err
+		}
+		for _, data := range markets {
+			result = append(result, NewMarket(data, paymentCurrency))
+		}
+	}
+	return result, nil
+}
+
+// IsListed 는 orderCurrency/paymentCurrency 시장이 상장되어 있는지 반환합니다.
+func (m *MarketRegistry) IsListed(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (bool, error) {
+	markets, err := m.Markets(ctx, paymentCurrency)
+	if err != nil {
+		return false, err
+	}
+	orderCurrency = Currency(strings.ToLower(string(orderCurrency)))
+	index := sort.Search(len(markets), func(i int) bool { return markets[i] >= orderCurrency })
+	return index < len(markets) && markets[index] == orderCurrency, nil
+}
+
+// Validate 는 시장이 상장되어 있지 않으면 ErrUnknownMarket 을 반환합니다.
+func (m *MarketRegistry) Validate(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) error {
+	listed, err := m.IsListed(ctx, orderCurrency, paymentCurrency)
+	if err != nil {
+		return err
+	}
+	if !listed {
+		return fmt.Errorf("%w: %s_%s", ErrUnknownMarket, strings.ToUpper(string(orderCurrency)), strings.ToUpper(string(paymentCurrency)))
+	}
+	return nil
+}
+
+// LoadedAt 은 paymentCurrency 시장의 목록을 마지막으로 받아온 시각입니다. 받아온 적이 없으면 zero value 입니다.
+func (m *MarketRegistry) LoadedAt(paymentCurrency Currency) time.Time {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.loadedAt[paymentCurrency]
+}
@@ -0,0 +1,137 @@
+package gobithumb
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option 은 NewBithumb 에 전달해 BithumbRequester 의 동작을 바꿉니다.
+type Option func(*BithumbRequester)
+
+// WithLogger 는 라이브러리가 사용할 Logger 를 지정합니다.
+// 기본값은 WARN 이상의 로그를 stdout 에 출력합니다.
+func WithLogger(logger Logger) Option {
+	return func(b *BithumbRequester) {
+		if logger == nil {
+			logger = NopLogger
+		}
+		b.requester.logger = logger
+	}
+}
+
+// WithBaseURL 은 요청을 보낼 서버 주소를 바꿉니다. (기본값 : https://api.bithumb.com)
+// 로컬 테스트 서버나 프록시를 사용할 때 유용합니다.
+func WithBaseURL(baseUrl string) Option {
+	return func(b *BithumbRequester) {
+		b.requester.basicUrl = strings.TrimRight(baseUrl, "/")
+	}
+}
+
+// WithHTTPClient 는 public, private API 요청에 사용할 http.Client 를 지정합니다. nil 이면 무시합니다.
+func WithHTTPClient(client *http.Client) Option {
+	return func(b *BithumbRequester) {
+		if client == nil {
+			return
+		}
+		b.requester.publicClient = client
+		b.requester.privateClient = client
+	}
+}
+
+// WithTransport 는 기본 http.Client 의 Transport 만 바꿉니다.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(b *BithumbRequester) {
+		b.requester.publicClient = &http.Client{Transport: transport, Timeout: b.requester.publicClient.Timeout}
+		b.requester.privateClient = &http.Client{Transport: transport, Timeout: b.requester.privateClient.Timeout}
+	}
+}
+
+// WithTimeout 은 각 요청마다 적용될 timeout 을 지정합니다.
+// 메소드에 전달한 context 에 더 짧은 deadline 이 있으면 그 값이 우선합니다.
+func WithTimeout(timeout time.Duration) Option {
+	return func(b *BithumbRequester) {
+		b.requester.timeout = timeout
+	}
+}
+
+// WithUserAgent 는 모든 요청의 User-Agent 헤더를 지정합니다.
+func WithUserAgent(userAgent string) Option {
+	return func(b *BithumbRequester) {
+		b.requester.headers.Set("User-Agent", userAgent)
+	}
+}
+
+// WithHeader 는 모든 요청에 추가할 헤더를 지정합니다. 인증 관련 헤더는 덮어쓸 수 없습니다.
+func WithHeader(key string, value string) Option {
+	return func(b *BithumbRequester) {
+		b.requester.headers.Add(key, value)
+	}
+}
+
+// WithRateLimit 은 public, private API 각각의 요청 속도 제한을 지정합니다.
+func WithRateLimit(public RateLimit, private RateLimit) Option {
+	return func(b *BithumbRequester) {
+		failFast := b.requester.publicLimiter.failFast
+		b.requester.publicLimiter = newRateLimiter(public)
+		b.requester.privateLimiter = newRateLimiter(private)
+		b.requester.publicLimiter.failFast = failFast
+		b.requester.privateLimiter.failFast = failFast
+	}
+}
+
+// WithRateLimitFailFast 를 사용하면 요청 한도를 넘었을 때 기다리지 않고 바로 ErrRateLimited 를 반환합니다.
+func WithRateLimitFailFast() Option {
+	return func(b *BithumbRequester) {
+		b.requester.publicLimiter.failFast = true
+		b.requester.privateLimiter.failFast = true
+	}
+}
+
+// WithRetryPolicy 는 public API 와 /info/* 조회 API 의 재시도 방식을 지정합니다.
+// 재시도를 끄려면 RetryPolicy{MaxAttempts: 1} 을 사용하세요.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(b *BithumbRequester) {
+		b.requester.retryPolicy = policy
+	}
+}
+
+// WithOrderRules 는 주문 전에 확인할 호가 단위, 수량, 최소 주문 금액 규칙을 지정합니다. (기본값 : DefaultOrderRules())
+// nil 을 넘기면 확인하지 않고 그대로 주문합니다.
+func WithOrderRules(rules *OrderRules) Option {
+	return func(b *BithumbRequester) {
+		b.orderRules = rules
+	}
+}
+
+// WithOrderRounding 은 규칙에 맞지 않는 주문을 거절하는 대신, 가격은 호가 단위로(매수는 내림, 매도는 올림), 수량은 허용된 자릿수로 내림해서 주문합니다.
+func WithOrderRounding() Option {
+	return func(b *BithumbRequester) {
+		b.roundOrders = true
+	}
+}
+
+// WithMarketRegistry 는 상장 목록을 보관할 MarketRegistry 를 지정합니다. 여러 client 가 같은 목록을 공유할 때 사용합니다.
+func WithMarketRegistry(registry *MarketRegistry) Option {
+	return func(b *BithumbRequester) {
+		if registry != nil {
+			b.markets = registry
+		}
+	}
+}
+
+// WithAPIVersion 은 잔고, 주문 조회, 지정가 주문, 시장가 매도, 주문 취소 메소드가 사용할 API 를 지정합니다. (기본값 : APIVersion1)
+// APIVersion2 에서 주문 ID 는 API 2.0 의 uuid 이며, 시장가 매수, 예약 주문, 출금 등 API 2.0 에 대응하는 기능이 없는 메소드는 legacy API 를 그대로 사용합니다.
+func WithAPIVersion(version APIVersion) Option {
+	return func(b *BithumbRequester) {
+		b.apiVersion = version
+	}
+}
+
+// WithJWTKeys 는 API 2.0 (JWT 인증) 요청에 사용할 key 를 지정합니다. 기본값은 NewBithumb 에 전달한 connectKey, secretKey 입니다.
+func WithJWTKeys(accessKey string, secretKey string) Option {
+	return func(b *BithumbRequester) {
+		b.requester.jwtAccessKey = accessKey
+		b.requester.jwtSecretKey = secretKey
+	}
+}
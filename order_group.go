@@ -0,0 +1,654 @@
+package gobithumb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// OrderGroupRequester 는 OrderGroup 이 주문을 넣고 GetOrderDetail 로 체결을, GetTicker 로 손절 가격을 확인하는 데 쓰는 API 입니다.
+type OrderGroupRequester interface {
+	Trading
+	AccountInfo
+	MarketData
+}
+
+var (
+	// ErrOrderGroupRunning 은 같은 OrderGroup 의 Run 이 이미 실행 중일 때 반환됩니다.
+	ErrOrderGroupRunning = errors.New("gobithumb: order group is already running")
+	// ErrOrderResultUnknown 은 ctx 로 중단된 주문 요청이 거래소에 들어갔는지 확인할 수 없을 때 반환됩니다. 주문 내역을 직접 확인해야 합니다.
+	ErrOrderResultUnknown = errors.New("gobithumb: order result unknown")
+)
+
+// errCancelPending 은 취소를 요청했지만 아직 주문이 끝나지 않았을 때 반환되며, 다음 step 에서 다시 확인합니다.
+var errCancelPending = errors.New("gobithumb: order cancel not confirmed yet")
+
+// OrderGroupStatus 는 OCO, bracket 주문 묶음의 상태입니다.
+type OrderGroupStatus string
+
+const (
+	OrderGroupPending   OrderGroupStatus = "pending"   // 진입 주문 체결 대기 (bracket)
+	OrderGroupActive    OrderGroupStatus = "active"    // 청산 주문이 걸려 있음
+	OrderGroupFilled    OrderGroupStatus = "filled"    // 청산 주문이 모두 체결되어 끝남
+	OrderGroupCancelled OrderGroupStatus = "cancelled" // Cancel 또는 외부에서 주문이 취소되어 끝남
+	OrderGroupFailed    OrderGroupStatus = "failed"    // 주문 실패로 끝남
+)
+
+// Done 은 더 이상 주문을 조회하지 않는 상태인지 확인합니다.
+func (o OrderGroupStatus) Done() bool {
+	return o == OrderGroupFilled || o == OrderGroupCancelled || o == OrderGroupFailed
+}
+
+// OrderLegType 은 주문 묶음 안에서 주문의 역할입니다.
+type OrderLegType string
+
+const (
+	EntryLeg      OrderLegType = "entry"       // 진입 주문 (bracket)
+	TakeProfitLeg OrderLegType = "take_profit" // 지정가 (익절) 주문
+	StopLossLeg   OrderLegType = "stop_loss"   // 손절 주문 (감시 가격에 도달하면 지정가로 주문)
+)
+
+// OCOOrder 는 익절 지정가 주문을 넣어 두고, 가격이 손절 감시 가격에 도달하면 익절 주문을 취소하고 손절 주문을 넣는 주문입니다.
+// Side 는 두 주문의 방향이며, Ask 이면 LimitPrice 가 StopPrice 보다 높아야 하고 Bid 이면 낮아야 합니다.
+type OCOOrder struct {
+	Market         Market
+	Side           OrderSide
+	Units          Decimal
+	LimitPrice     Decimal // 지정가 주문 가격
+	StopPrice      Decimal // 손절 감시 가격
+	StopLimitPrice Decimal // 손절 주문 가격, 0 이면 StopPrice
+}
+
+// BracketOrder 는 진입 주문이 체결되면 체결된 수량으로 익절, 손절 OCO 주문을 넣는 주문입니다.
+// Side 는 진입 방향이며, 청산 주문은 반대 방향입니다. (e.g. Bid 로 진입하면 TakeProfit > StopPrice 인 Ask 주문)
+type BracketOrder struct {
+	Market         Market
+	Side           OrderSide
+	Units          Decimal
+	EntryPrice     Decimal // 0 이면 시장가로 진입
+	TakeProfit     Decimal
+	StopPrice      Decimal
+	StopLimitPrice Decimal // 0 이면 StopPrice
+}
+
+// OrderLeg 는 주문 묶음 안의 주문 하나의 상태입니다.
+// 남은 수량을 맞추기 위해 다시 주문하면 OrderIDs 가 늘어나며, Filled 는 모든 주문의 누적 체결 수량입니다.
+type OrderLeg struct {
+	Type       OrderLegType
+	Side       OrderSide
+	Price      Decimal
+	WatchPrice Decimal
+	Triggered  bool   // 손절 주문이 감시 가격에 도달함
+	OrderID    string // 지금 걸려 있는 (또는 취소가 확인되지 않은) 주문, 없으면 빈 문자열
+	OrderIDs   []string
+	Open       Decimal
+	Filled     Decimal
+}
+
+// OrderGroupState 는 OrderGroup 의 현재 상태입니다. Entry 는 bracket 주문에서만 있습니다.
+type OrderGroupState struct {
+	Status     OrderGroupStatus
+	Market     Market
+	Units      Decimal
+	Entry      *OrderLeg
+	TakeProfit OrderLeg
+	StopLoss   OrderLeg
+	Err        error
+}
+
+// OrderGroupOption 은 NewOCO, NewBracket 에 전달해 OrderGroup 의 동작을 바꿉니다.
+type OrderGroupOption func(*OrderGroup)
+
+// WithGroupInterval 은 GetOrderDetail 로 주문을 조회하는 간격입니다. (기본값 1초)
+func WithGroupInterval(interval time.Duration) OrderGroupOption {
+	return func(o *OrderGroup) {
+		o.interval = interval
+	}
+}
+
+func WithGroupLogger(logger Logger) OrderGroupOption {
+	return func(o *OrderGroup) {
+		if logger == nil {
+			logger = NopLogger
+		}
+		o.logger = logger
+	}
+}
+
+// OrderGroup 은 OCO, bracket 주문 묶음입니다. Run 이 주문을 넣고 GetOrderDetail 로 체결을 확인합니다.
+//   - 거래소에는 익절 지정가 주문 하나만 걸어두므로, 청산 수량만큼의 잔고만 있으면 됩니다.
+//   - 손절은 GetTicker 의 현재가로 확인하며, 감시 가격에 도달하면 익절 주문의 취소를 확인한 뒤 남은 수량을 손절 지정가로 주문합니다.
+//     따라서 Run 이 실행 중일 때만 손절되며, 조회 간격만큼 늦게 발동될 수 있습니다.
+//   - 청산 주문이 모두 체결되면 끝납니다.
+//   - 직접 취소하지 않은 주문이 취소되면 (e.g. 웹에서 취소) 나머지 주문도 취소하고 끝납니다.
+type OrderGroup struct {
+	requester OrderGroupRequester
+	logger    Logger
+	interval  time.Duration
+
+	market     Market
+	units      Decimal
+	target     Decimal // 청산 주문의 총 수량, bracket 은 진입 주문의 체결 수량
+	entry      *groupLeg
+	takeProfit *groupLeg
+	stopLoss   *groupLeg
+	status     OrderGroupStatus
+	err        error
+
+	mutex     sync.Mutex
+	state     OrderGroupState
+	running   bool
+	cancelled bool
+	wake      chan struct{}
+}
+
+type groupLeg struct {
+	legType    OrderLegType
+	side       OrderSide
+	price      Decimal // 0 이면 시장가 (진입 주문만)
+	watchPrice Decimal // 손절 감시 가격, 현재가가 이 가격에 도달하면 price 로 지정가 주문 (손절 주문만)
+
+	triggered bool // 손절 감시 가격에 도달함
+
+	orderIDs    []string
+	orderID     string
+	orderUnits  Decimal
+	orderFilled Decimal
+	filled      Decimal
+	cancelling  bool      // 지금 주문의 취소를 요청함, 이후 보이는 취소는 외부 취소가 아님
+	closed      bool      // 최소 주문 수량 미만이라 더 이상 주문하지 않음
+	interrupted time.Time // 주문 요청 중에 ctx 가 끝난 시각, 다시 주문하기 전에 그 주문이 들어갔는지 확인함
+}
+
+// open 은 지금 걸려 있는 주문의 남은 수량입니다.
+func (l *groupLeg) open() Decimal {
+	if l.orderID == "" {
+		return Decimal{}
+	}
+	return l.orderUnits.Sub(l.orderFilled)
+}
+
+func (l *groupLeg) snapshot() OrderLeg {
+	return OrderLeg{
+		Type:       l.legType,
+		Side:       l.side,
+		Price:      l.price,
+		WatchPrice: l.watchPrice,
+		Triggered:  l.triggered,
+		OrderID:    l.orderID,
+		OrderIDs:   append([]string(nil), l.orderIDs...),
+		Open:       l.open(),
+		Filled:     l.filled,
+	}
+}
+
+func newOrderGroup(requester OrderGroupRequester, market Market, units Decimal, options []OrderGroupOption) *OrderGroup {
+
+	orderGroup := OrderGroup{}
+
+	orderGroup.requester = requester
+	orderGroup.logger = NewStdLogger(os.Stdout, LogWarn)
+	orderGroup.interval = time.Second
+	orderGroup.market = market
+	orderGroup.units = units
+	orderGroup.wake = make(chan struct{}, 1)
+
+	for _, option := range options {
+		option(&orderGroup)
+	}
+	if orderGroup.interval <= 0 {
+		orderGroup.interval = time.Second
+	}
+
+	return &orderGroup
+}
+
+// validateExit 은 청산 주문의 가격을 확인합니다.
+func validateExit(market Market, side OrderSide, units Decimal, limitPrice Decimal, stopPrice Decimal, stopLimitPrice Decimal) error {
+
+	// parameter 정상 체크
+	if !market.Valid() {
+		return fmt.Errorf("%w: 시장이 올바르지 않습니다. (%q)", ErrInvalidParameter, market)
+	}
+	if !side.Valid() {
+		return fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, side)
+	}
+	if units.Sign() <= 0 {
+		return fmt.Errorf("%w: 주문 수량은 0보다 커야 합니다.", ErrInvalidParameter)
+	}
+	if limitPrice.Sign() <= 0 || stopPrice.Sign() <= 0 || stopLimitPrice.Sign() < 0 {
+		return fmt.Errorf("%w: 익절, 손절 가격은 0보다 커야 합니다.", ErrInvalidParameter)
+	}
+	if side == Ask && limitPrice.Cmp(stopPrice) <= 0 {
+		return fmt.Errorf("%w: 매도 청산의 지정가 (%s) 는 감시 가격 (%s) 보다 높아야 합니다.", ErrInvalidParameter, limitPrice, stopPrice)
+	}
+	if side == Bid && limitPrice.Cmp(stopPrice) >= 0 {
+		return fmt.Errorf("%w: 매수 청산의 지정가 (%s) 는 감시 가격 (%s) 보다 낮아야 합니다.", ErrInvalidParameter, limitPrice, stopPrice)
+	}
+	return nil
+}
+
+func (o *OrderGroup) setExits(side OrderSide, limitPrice Decimal, stopPrice Decimal, stopLimitPrice Decimal) {
+	if stopLimitPrice.Sign() == 0 {
+		stopLimitPrice = stopPrice
+	}
+	o.takeProfit = &groupLeg{legType: TakeProfitLeg, side: side, price: limitPrice}
+	o.stopLoss = &groupLeg{legType: StopLossLeg, side: side, price: stopLimitPrice, watchPrice: stopPrice}
+}
+
+// NewOCO 는 OCO 주문을 만듭니다. 주문은 Run 을 실행하면 넣습니다.
+// 손절은 거래소의 StopLimit 주문이 아니라 Run 이 현재가를 보고 넣는 지정가 주문입니다.
+// StopLimit 주문을 익절 주문과 함께 걸면 두 주문이 같은 잔고를 묶어야 하므로, 익절 주문 하나만 걸어둡니다.
+// 따라서 Run 이 실행 중이 아닌 동안 (e.g. 프로세스 종료) 에는 가격이 감시 가격을 지나도 손절되지 않으며, 다시 Run 을 실행했을 때 확인합니다.
+func NewOCO(requester OrderGroupRequester, order OCOOrder, options ...OrderGroupOption) (*OrderGroup, error) {
+	if err := validateExit(order.Market, order.Side, order.Units, order.LimitPrice, order.StopPrice, order.StopLimitPrice); err != nil {
+		return nil, err
+	}
+
+	orderGroup := newOrderGroup(requester, order.Market, order.Units, options)
+	orderGroup.target = order.Units
+	orderGroup.status = OrderGroupActive
+	orderGroup.setExits(order.Side, order.LimitPrice, order.StopPrice, order.StopLimitPrice)
+	orderGroup.publish()
+	return orderGroup, nil
+}
+
+// NewBracket 은 bracket 주문을 만듭니다. 주문은 Run 을 실행하면 넣습니다.
+// 청산 주문은 진입 주문이 끝난 (전량 체결되거나 일부 체결 후 취소된) 뒤에 체결된 수량만큼 넣습니다.
+// 손절은 NewOCO 와 같이 Run 이 실행 중일 때만 현재가를 보고 지정가로 주문합니다.
+func NewBracket(requester OrderGroupRequester, order BracketOrder, options ...OrderGroupOption) (*OrderGroup, error) {
+	exitSide := order.Side.Opposite()
+	if err := validateExit(order.Market, exitSide, order.Units, order.TakeProfit, order.StopPrice, order.StopLimitPrice); err != nil {
+		return nil, err
+	}
+	if order.EntryPrice.Sign() < 0 {
+		return nil, fmt.Errorf("%w: 진입 가격은 0보다 작을 수 없습니다.", ErrInvalidParameter)
+	}
+	if order.EntryPrice.Sign() > 0 && (order.EntryPrice.Cmp(MinDecimal(order.TakeProfit, order.StopPrice)) <= 0 || order.EntryPrice.Cmp(MaxDecimal(order.TakeProfit, order.StopPrice)) >= 0) {
+		return nil, fmt.Errorf("%w: 진입 가격 (%s) 은 익절, 손절 가격 사이에 있어야 합니다.", ErrInvalidParameter, order.EntryPrice)
+	}
+
+	orderGroup := newOrderGroup(requester, order.Market, order.Units, options)
+	orderGroup.status = OrderGroupPending
+	orderGroup.entry = &groupLeg{legType: EntryLeg, side: order.Side, price: order.EntryPrice}
+	orderGroup.setExits(exitSide, order.TakeProfit, order.StopPrice, order.StopLimitPrice)
+	orderGroup.publish()
+	return orderGroup, nil
+}
+
+// State 는 현재 상태를 반환합니다. Run 이 실행 중이어도 호출할 수 있습니다.
+func (o *OrderGroup) State() OrderGroupState {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.state
+}
+
+// publish 는 Run 이 바꾼 상태를 State 로 읽을 수 있게 복사합니다.
+func (o *OrderGroup) publish() {
+	state := OrderGroupState{
+		Status:     o.status,
+		Market:     o.market,
+		Units:      o.units,
+		TakeProfit: o.takeProfit.snapshot(),
+		StopLoss:   o.stopLoss.snapshot(),
+		Err:        o.err,
+	}
+	if o.entry != nil {
+		entry := o.entry.snapshot()
+		state.Entry = &entry
+	}
+
+	o.mutex.Lock()
+	o.state = state
+	o.mutex.Unlock()
+}
+
+// Cancel 은 걸려 있는 주문을 모두 취소하도록 합니다. 취소는 Run 이 하며, Run 이 실행 중이 아니면 다음 Run 에서 취소합니다.
+func (o *OrderGroup) Cancel() {
+	o.mutex.Lock()
+	o.cancelled = true
+	o.mutex.Unlock()
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run 은 주문을 넣고 끝날 때까지 조회합니다.
+// 청산 주문이 모두 체결되면 nil, 취소되면 ErrOrderCancelled, 주문에 실패하면 그 오류를 반환합니다.
+// ctx 가 먼저 끝나면 (주문 요청 중이어도) 실패로 처리하지 않고 주문을 그대로 둔 채 ctx.Err() 를 반환하며, 다시 Run 을 실행하면 이어서 조회합니다.
+// 이때 중단된 주문 요청이 들어갔는지 확인할 수 없으면 다시 주문하지 않고 ErrOrderResultUnknown 으로 실패합니다.
+// 이미 Run 이 실행 중이면 ErrOrderGroupRunning 을 반환합니다.
+func (o *OrderGroup) Run(ctx context.Context) error {
+	o.mutex.Lock()
+	if o.running {
+		o.mutex.Unlock()
+		return ErrOrderGroupRunning
+	}
+	o.running = true
+	o.mutex.Unlock()
+	defer func() {
+		o.mutex.Lock()
+		o.running = false
+		o.mutex.Unlock()
+	}()
+
+	for {
+		if o.status.Done() {
+			return o.err
+		}
+
+		o.mutex.Lock()
+		cancelled := o.cancelled
+		o.mutex.Unlock()
+
+		if cancelled {
+			// 취소가 확인될 때까지 다음 step 에서 다시 시도
+			if err := o.cancelLegs(ctx); err != nil {
+				o.warn(ctx, "order group cancel failed", o.takeProfit, err)
+			} else {
+				o.finish(OrderGroupCancelled, fmt.Errorf("%w: 주문 묶음이 취소되었습니다.", ErrOrderCancelled))
+			}
+		} else {
+			o.step(ctx)
+		}
+		o.publish()
+		if o.status.Done() {
+			return o.err
+		}
+
+		timer := time.NewTimer(o.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-o.wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+func (o *OrderGroup) finish(status OrderGroupStatus, err error) {
+	o.status = status
+	o.err = err
+}
+
+// fail 은 남은 주문을 취소하고 실패로 끝냅니다.
+func (o *OrderGroup) fail(ctx context.Context, err error) {
+	if cancelErr := o.cancelLegs(ctx); cancelErr != nil {
+		o.logger.Log(LogError, "order group cancel failed", LogField{"market", o.market}, LogField{"error", cancelErr})
+	}
+	o.finish(OrderGroupFailed, err)
+}
+
+func (o *OrderGroup) warn(ctx context.Context, message string, leg *groupLeg, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	o.logger.Log(LogWarn, message, LogField{"market", o.market}, LogField{"leg", leg.legType}, LogField{"order_id", leg.orderID}, LogField{"error", err})
+}
+
+// step 은 주문 상태를 한 번 조회하고, 필요하면 주문을 넣거나 취소합니다. 일시적인 조회, 취소 오류는 다음 step 에서 다시 시도합니다.
+func (o *OrderGroup) step(ctx context.Context) {
+	if o.entry != nil && o.status == OrderGroupPending {
+		if len(o.entry.orderIDs) == 0 {
+			if err := o.place(ctx, o.entry, o.units); err != nil {
+				if !isContextError(err) {
+					o.finish(OrderGroupFailed, fmt.Errorf("gobithumb: 진입 주문 실패: %w", err))
+				}
+				return
+			}
+		}
+		status, err := o.refresh(ctx, o.entry)
+		if err != nil {
+			o.warn(ctx, "order group refresh failed", o.entry, err)
+			return
+		}
+		if !status.Done() {
+			return
+		}
+		if o.entry.filled.Sign() <= 0 {
+			o.finish(OrderGroupCancelled, fmt.Errorf("%w: 진입 주문이 체결되지 않고 취소되었습니다.", ErrOrderCancelled))
+			return
+		}
+		o.target = o.entry.filled
+		o.status = OrderGroupActive
+	}
+
+	for _, leg := range []*groupLeg{o.takeProfit, o.stopLoss} {
+		status, err := o.refresh(ctx, leg)
+		if err != nil {
+			o.warn(ctx, "order group refresh failed", leg, err)
+			return
+		}
+		// 직접 취소를 요청한 주문이 아닌데 취소되었으면 외부에서 취소한 것
+		if status == OrderCancel && !leg.cancelling {
+			if err := o.cancelLegs(ctx); err != nil {
+				o.warn(ctx, "order group cancel failed", leg, err)
+				return
+			}
+			o.finish(OrderGroupCancelled, fmt.Errorf("%w: %s 주문이 취소되었습니다.", ErrOrderCancelled, leg.legType))
+			return
+		}
+	}
+
+	if o.remaining().Sign() > 0 && !o.stopLoss.triggered {
+		if err := o.watchStop(ctx); err != nil {
+			o.warn(ctx, "order group price check failed", o.stopLoss, err)
+		}
+	}
+
+	// 손절이 발동되면 익절 주문의 취소가 확인된 뒤에 손절 주문을 넣으므로, 두 주문이 함께 잔고를 묶지 않음
+	leg := o.takeProfit
+	if o.stopLoss.triggered {
+		if err := o.cancelLeg(ctx, o.takeProfit); err != nil {
+			o.warn(ctx, "order group cancel failed", o.takeProfit, err)
+			return
+		}
+		leg = o.stopLoss
+	}
+	if remaining := o.remaining(); remaining.Sign() > 0 && leg.orderID == "" && !leg.closed {
+		if err := o.place(ctx, leg, remaining); err != nil {
+			switch {
+			case isContextError(err):
+				return
+			case o.takeProfit.filled.Add(o.stopLoss.filled).Sign() > 0 && errors.Is(err, ErrInvalidParameter):
+				// 일부 체결 후 남은 수량이 최소 주문 수량보다 작음
+				leg.closed = true
+				o.warn(ctx, "order group leg closed", leg, err)
+			default:
+				o.fail(ctx, fmt.Errorf("gobithumb: %s 주문 실패: %w", leg.legType, err))
+				return
+			}
+		}
+	}
+
+	if o.remaining().Sign() <= 0 || (o.takeProfit.orderID == "" && o.stopLoss.orderID == "") {
+		if err := o.cancelLegs(ctx); err != nil {
+			o.warn(ctx, "order group cancel failed", o.takeProfit, err)
+			return
+		}
+		o.finish(OrderGroupFilled, nil)
+	}
+}
+
+// watchStop 은 현재가가 손절 감시 가격에 도달했는지 확인합니다. (매도 청산은 감시 가격 이하, 매수 청산은 이상)
+func (o *OrderGroup) watchStop(ctx context.Context) error {
+	tickers, _, err := o.requester.GetTickerCtx(ctx, o.market.Base, o.market.Quote)
+	if err != nil {
+		return err
+	}
+	price := tickers[o.market.Base].ClosingPrice
+	if price.Sign() <= 0 {
+		return nil
+	}
+	leg := o.stopLoss
+	if (leg.side == Ask && price.Cmp(leg.watchPrice) <= 0) || (leg.side == Bid && price.Cmp(leg.watchPrice) >= 0) {
+		leg.triggered = true
+		o.logger.Log(LogInfo, "order group stop triggered", LogField{"market", o.market}, LogField{"price", price}, LogField{"watch_price", leg.watchPrice})
+	}
+	return nil
+}
+
+// remaining 은 청산 주문으로 더 체결되어야 하는 수량입니다.
+func (o *OrderGroup) remaining() Decimal {
+	return o.target.Sub(o.takeProfit.filled).Sub(o.stopLoss.filled)
+}
+
+// place 는 leg 의 주문을 units 만큼 넣습니다. 손절 주문은 이미 감시 가격에 도달한 뒤이므로 지정가로 넣습니다.
+// 이전 주문 요청이 ctx 로 중단되었으면 그 주문이 들어갔는지 먼저 확인해, 걸려 있으면 새로 주문하지 않고 이어서 사용하고,
+// 확인할 수 없으면 다시 주문하지 않고 ErrOrderResultUnknown 을 반환합니다.
+func (o *OrderGroup) place(ctx context.Context, leg *groupLeg, units Decimal) error {
+	if !leg.interrupted.IsZero() {
+		id, err := o.findOrder(ctx, leg, units)
+		if err != nil {
+			return err
+		}
+		leg.interrupted = time.Time{}
+		if id != "" {
+			o.logger.Log(LogInfo, "order group interrupted order found", LogField{"market", o.market}, LogField{"leg", leg.legType}, LogField{"order_id", id})
+			leg.setOrder(id, units)
+			return nil
+		}
+	}
+
+	started := time.Now()
+	var id string
+	var err error
+	switch {
+	case leg.price.Sign() > 0:
+		id, err = o.requester.PlaceOrderCtx(ctx, o.market.Base, o.market.Quote, units, leg.price, leg.side)
+	case leg.side == Bid:
+		id, err = o.requester.MarketBuyCtx(ctx, o.market.Base, o.market.Quote, units)
+	default:
+		id, err = o.requester.MarketSellCtx(ctx, o.market.Base, o.market.Quote, units)
+	}
+	if err != nil {
+		// 요청 중에 ctx 가 끝나면 주문이 들어갔는지 알 수 없음
+		if isContextError(err) {
+			leg.interrupted = started
+			o.logger.Log(LogWarn, "order group order interrupted", LogField{"market", o.market}, LogField{"leg", leg.legType}, LogField{"error", err})
+		}
+		return err
+	}
+	leg.setOrder(id, units)
+	return nil
+}
+
+func (l *groupLeg) setOrder(id string, units Decimal) {
+	l.orderID = id
+	l.orderIDs = append(l.orderIDs, id)
+	l.orderUnits = units
+	l.orderFilled = Decimal{}
+	l.cancelling = false
+}
+
+// findOrder 는 중단된 주문 요청 이후에 들어간 주문 중 leg 와 방향, 가격, 수량이 같은 걸려 있는 주문을 찾습니다.
+// 걸려 있는 주문이 없으면 같은 방향의 체결 내역을 확인해, 체결이 없으면 (주문이 들어가지 않았거나 체결 없이 끝남) 빈 문자열을 반환합니다.
+// 체결 내역에는 주문 번호가 없으므로, 같은 방향의 체결이 있으면 중단된 주문의 체결인지 알 수 없어 ErrOrderResultUnknown 을 반환합니다.
+func (o *OrderGroup) findOrder(ctx context.Context, leg *groupLeg, units Decimal) (string, error) {
+	// 주문, 체결 시각은 서버 기준이므로 여유를 둠
+	after := leg.interrupted.Add(-time.Minute)
+	orders, err := o.requester.GetOrderCtx(ctx, o.market.Base, o.market.Quote, 100, after)
+	if err != nil && !errors.Is(err, ErrOrderNotFound) {
+		return "", err
+	}
+
+	known := make(map[string]bool)
+	for _, data := range []*groupLeg{o.entry, o.takeProfit, o.stopLoss} {
+		if data == nil {
+			continue
+		}
+		for _, id := range data.orderIDs {
+			known[id] = true
+		}
+	}
+	for _, data := range orders {
+		if !known[data.OrderID] && data.Type == leg.side && data.Price.Equal(leg.price) && data.Units.Equal(units) {
+			return data.OrderID, nil
+		}
+	}
+
+	search := BuyComplete
+	if leg.side == Ask {
+		search = SellComplete
+	}
+	transactions, err := o.requester.GetTransactionsCtx(ctx, o.market.Base, o.market.Quote, search, 0, 50)
+	if err != nil {
+		return "", err
+	}
+	for _, data := range transactions {
+		if !data.TransferDate.Before(after) {
+			return "", fmt.Errorf("%w: %s 주문 요청이 중단된 뒤 %s 체결이 있어 주문 여부를 알 수 없습니다. 주문 내역을 확인하세요.", ErrOrderResultUnknown, leg.legType, data.TransferDate.Format("2006-01-02 15:04:05"))
+		}
+	}
+	return "", nil
+}
+
+// refresh 는 leg 의 주문을 조회해 체결 수량을 반영합니다. 주문이 끝나면 leg 의 주문을 비웁니다.
+func (o *OrderGroup) refresh(ctx context.Context, leg *groupLeg) (OrderStatus, error) {
+	if leg.orderID == "" {
+		return "", nil
+	}
+	detail, err := o.requester.GetOrderDetailCtx(ctx, o.market.Base, o.market.Quote, leg.orderID)
+	if err != nil {
+		return "", err
+	}
+
+	filled := Decimal{}
+	for _, data := range detail.Contract {
+		filled = filled.Add(data.Units)
+	}
+	leg.filled = leg.filled.Add(filled.Sub(leg.orderFilled))
+	leg.orderFilled = filled
+	if detail.OrderQty.Sign() > 0 {
+		leg.orderUnits = detail.OrderQty
+	}
+	if detail.OrderStatus.Done() {
+		leg.orderID = ""
+	}
+	return detail.OrderStatus, nil
+}
+
+// cancelLeg 는 leg 의 주문을 취소하고, 취소 전에 체결된 수량을 반영합니다.
+// 조회로 주문이 끝난 것 (취소 또는 전량 체결) 을 확인하기 전까지는 orderID 를 남겨두고 errCancelPending 을 반환합니다.
+func (o *OrderGroup) cancelLeg(ctx context.Context, leg *groupLeg) error {
+	if leg.orderID == "" {
+		return nil
+	}
+	leg.cancelling = true
+	err := o.requester.CancelOrderCtx(ctx, o.market.Base, o.market.Quote, leg.orderID, leg.side)
+	if err != nil && !errors.Is(err, ErrOrderNotFound) {
+		return err
+	}
+	if _, err := o.refresh(ctx, leg); err != nil {
+		return err
+	}
+	if leg.orderID != "" {
+		return errCancelPending
+	}
+	return nil
+}
+
+// cancelLegs 는 걸려 있는 주문을 모두 취소합니다.
+func (o *OrderGroup) cancelLegs(ctx context.Context) error {
+	var result error
+	for _, leg := range []*groupLeg{o.entry, o.takeProfit, o.stopLoss} {
+		if leg == nil {
+			continue
+		}
+		if err := o.cancelLeg(ctx, leg); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
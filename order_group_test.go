@@ -0,0 +1,302 @@
+package gobithumb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+	"github.com/lutergs/gobithumb/bithumbtest"
+)
+
+func newGroupClient(t *testing.T) (*bithumbtest.Server, *b.BithumbRequester) {
+	t.Helper()
+	server := bithumbtest.NewServer("connect key", "secret key")
+	t.Cleanup(server.Close)
+	server.SetLastPrice(b.BTC, b.KRW, b.MustDecimal("100000000"))
+	client := b.NewBithumb("connect key", "secret key", b.WithBaseURL(server.URL), b.WithRetryPolicy(b.RetryPolicy{MaxAttempts: 1}), b.WithLogger(b.NopLogger))
+	return server, client
+}
+
+func newTestOCO(t *testing.T, client *b.BithumbRequester) *b.OrderGroup {
+	t.Helper()
+	group, err := b.NewOCO(client, b.OCOOrder{
+		Market:         b.NewMarket(b.BTC, b.KRW),
+		Side:           b.Ask,
+		Units:          b.MustDecimal("0.5"),
+		LimitPrice:     b.MustDecimal("110000000"),
+		StopPrice:      b.MustDecimal("90000000"),
+		StopLimitPrice: b.MustDecimal("89000000"),
+	}, b.WithGroupInterval(10*time.Millisecond), b.WithGroupLogger(b.NopLogger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return group
+}
+
+// runGroup 은 Run 을 goroutine 으로 실행하고 결과를 channel 로 전달합니다.
+func runGroup(ctx context.Context, group *b.OrderGroup) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- group.Run(ctx)
+	}()
+	return result
+}
+
+// waitGroup 은 condition 이 참이 될 때까지 State 를 확인합니다.
+func waitGroup(t *testing.T, group *b.OrderGroup, condition func(b.OrderGroupState) bool) b.OrderGroupState {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		if state := group.State(); condition(state) {
+			return state
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("state not reached: %+v", group.State())
+	return b.OrderGroupState{}
+}
+
+func waitResult(t *testing.T, result <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(3 * time.Second):
+		t.Fatal("Run did not return")
+		return nil
+	}
+}
+
+func TestOCOTakeProfit(t *testing.T) {
+	server, client := newGroupClient(t)
+	server.SetBalance(b.BTC, b.MustDecimal("0.5"))
+	group := newTestOCO(t, client)
+	result := runGroup(context.Background(), group)
+
+	waitGroup(t, group, func(state b.OrderGroupState) bool { return state.TakeProfit.OrderID != "" })
+	server.Trade(b.BTC, b.KRW, b.Bid, b.MustDecimal("0.5"))
+
+	if err := waitResult(t, result); err != nil {
+		t.Fatalf("Run error = %v", err)
+	}
+	state := group.State()
+	if state.Status != b.OrderGroupFilled || !state.TakeProfit.Filled.Equal(b.MustDecimal("0.5")) || state.StopLoss.Filled.Sign() != 0 {
+		t.Errorf("state = %s, take profit %s, stop loss %s", state.Status, state.TakeProfit.Filled, state.StopLoss.Filled)
+	}
+	if len(state.StopLoss.OrderIDs) != 0 {
+		t.Errorf("stop loss orders = %v, want none", state.StopLoss.OrderIDs)
+	}
+}
+
+func TestOCOStopTrigger(t *testing.T) {
+	server, client := newGroupClient(t)
+	server.SetBalance(b.BTC, b.MustDecimal("0.5"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Bid, b.MustDecimal("89000000"), b.MustDecimal("1"))
+	group := newTestOCO(t, client)
+	result := runGroup(context.Background(), group)
+
+	state := waitGroup(t, group, func(state b.OrderGroupState) bool { return state.TakeProfit.OrderID != "" })
+	takeProfitID := state.TakeProfit.OrderID
+	server.SetLastPrice(b.BTC, b.KRW, b.MustDecimal("89500000"))
+
+	if err := waitResult(t, result); err != nil {
+		t.Fatalf("Run error = %v", err)
+	}
+	state = group.State()
+	if state.Status != b.OrderGroupFilled || !state.StopLoss.Triggered || !state.StopLoss.Filled.Equal(b.MustDecimal("0.5")) {
+		t.Errorf("state = %s, triggered %v, stop loss %s", state.Status, state.StopLoss.Triggered, state.StopLoss.Filled)
+	}
+
+	// 익절 주문의 취소가 확인된 뒤에 손절 주문을 넣음
+	detail, err := client.GetOrderDetail(b.BTC, b.KRW, takeProfitID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.OrderStatus != b.OrderCancel {
+		t.Errorf("take profit status = %s, want cancel", detail.OrderStatus)
+	}
+	detail, err = client.GetOrderDetail(b.BTC, b.KRW, state.StopLoss.OrderIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !detail.OrderPrice.Equal(b.MustDecimal("89000000")) || detail.Type != b.Ask {
+		t.Errorf("stop loss order = %s %s, want ask at 89000000", detail.Type, detail.OrderPrice)
+	}
+	if total, _ := server.Balance(b.BTC); total.Sign() != 0 {
+		t.Errorf("btc balance = %s, want 0", total)
+	}
+}
+
+func TestOCOPartialFillResize(t *testing.T) {
+	server, client := newGroupClient(t)
+	server.SetBalance(b.BTC, b.MustDecimal("0.5"))
+	group := newTestOCO(t, client)
+	result := runGroup(context.Background(), group)
+
+	waitGroup(t, group, func(state b.OrderGroupState) bool { return state.TakeProfit.OrderID != "" })
+	server.Trade(b.BTC, b.KRW, b.Bid, b.MustDecimal("0.2"))
+	waitGroup(t, group, func(state b.OrderGroupState) bool { return state.TakeProfit.Filled.Equal(b.MustDecimal("0.2")) })
+
+	// 손절은 남은 수량만큼만 주문
+	server.SetLastPrice(b.BTC, b.KRW, b.MustDecimal("89500000"))
+	state := waitGroup(t, group, func(state b.OrderGroupState) bool { return state.StopLoss.OrderID != "" })
+	if !state.StopLoss.Open.Equal(b.MustDecimal("0.3")) || state.TakeProfit.OrderID != "" {
+		t.Fatalf("stop loss open = %s, take profit order %q, want 0.3 and no take profit order", state.StopLoss.Open, state.TakeProfit.OrderID)
+	}
+	server.AddLiquidity(b.BTC, b.KRW, b.Bid, b.MustDecimal("89000000"), b.MustDecimal("1"))
+
+	if err := waitResult(t, result); err != nil {
+		t.Fatalf("Run error = %v", err)
+	}
+	state = group.State()
+	if state.Status != b.OrderGroupFilled || !state.TakeProfit.Filled.Equal(b.MustDecimal("0.2")) || !state.StopLoss.Filled.Equal(b.MustDecimal("0.3")) {
+		t.Errorf("state = %s, take profit %s, stop loss %s", state.Status, state.TakeProfit.Filled, state.StopLoss.Filled)
+	}
+}
+
+func TestOCOExternalCancel(t *testing.T) {
+	server, client := newGroupClient(t)
+	server.SetBalance(b.BTC, b.MustDecimal("0.5"))
+	group := newTestOCO(t, client)
+	result := runGroup(context.Background(), group)
+
+	state := waitGroup(t, group, func(state b.OrderGroupState) bool { return state.TakeProfit.OrderID != "" })
+	if err := client.CancelOrder(b.BTC, b.KRW, state.TakeProfit.OrderID, b.Ask); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := waitResult(t, result); !errors.Is(err, b.ErrOrderCancelled) {
+		t.Fatalf("Run error = %v, want ErrOrderCancelled", err)
+	}
+	if state := group.State(); state.Status != b.OrderGroupCancelled {
+		t.Errorf("status = %s, want cancelled", state.Status)
+	}
+}
+
+func TestBracketPartialEntry(t *testing.T) {
+	server, client := newGroupClient(t)
+	server.SetBalance(b.KRW, b.MustDecimal("200000000"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Ask, b.MustDecimal("100000000"), b.MustDecimal("0.4"))
+	group, err := b.NewBracket(client, b.BracketOrder{
+		Market:     b.NewMarket(b.BTC, b.KRW),
+		Side:       b.Bid,
+		Units:      b.MustDecimal("1"),
+		EntryPrice: b.MustDecimal("100000000"),
+		TakeProfit: b.MustDecimal("110000000"),
+		StopPrice:  b.MustDecimal("90000000"),
+	}, b.WithGroupInterval(10*time.Millisecond), b.WithGroupLogger(b.NopLogger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := runGroup(context.Background(), group)
+
+	// 진입 주문이 일부 체결된 뒤 취소되면 체결된 수량만 청산
+	state := waitGroup(t, group, func(state b.OrderGroupState) bool {
+		return state.Entry.OrderID != "" && state.Entry.Filled.Equal(b.MustDecimal("0.4"))
+	})
+	if err := client.CancelOrder(b.BTC, b.KRW, state.Entry.OrderID, b.Bid); err != nil {
+		t.Fatal(err)
+	}
+	state = waitGroup(t, group, func(state b.OrderGroupState) bool { return state.TakeProfit.OrderID != "" })
+	if state.Status != b.OrderGroupActive || !state.TakeProfit.Open.Equal(b.MustDecimal("0.4")) || state.TakeProfit.Side != b.Ask {
+		t.Errorf("state = %s, take profit %s %s, want active ask 0.4", state.Status, state.TakeProfit.Side, state.TakeProfit.Open)
+	}
+
+	group.Cancel()
+	if err := waitResult(t, result); !errors.Is(err, b.ErrOrderCancelled) {
+		t.Fatalf("Run error = %v, want ErrOrderCancelled", err)
+	}
+	if _, inUse := server.Balance(b.BTC); inUse.Sign() != 0 {
+		t.Errorf("btc in use = %s, want 0 after cancel", inUse)
+	}
+}
+
+func TestOCOResumeAfterContextCancel(t *testing.T) {
+	server, client := newGroupClient(t)
+	server.SetBalance(b.BTC, b.MustDecimal("0.5"))
+	server.Inject(bithumbtest.Injection{Endpoint: "/trade/place", Delay: 300 * time.Millisecond, Times: 1})
+	group := newTestOCO(t, client)
+
+	// 주문 요청 중에 ctx 가 끝나도 서버는 주문을 받음
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := group.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run error = %v, want context.DeadlineExceeded", err)
+	}
+	if state := group.State(); state.Status != b.OrderGroupActive {
+		t.Fatalf("status = %s, want active", state.Status)
+	}
+	time.Sleep(400 * time.Millisecond)
+
+	// 다시 Run 하면 들어간 주문을 찾아 이어서 사용
+	result := runGroup(context.Background(), group)
+	state := waitGroup(t, group, func(state b.OrderGroupState) bool { return state.TakeProfit.OrderID != "" })
+	if requests := server.Requests("/trade/place"); requests != 1 {
+		t.Errorf("place requests = %d, want 1", requests)
+	}
+	if len(state.TakeProfit.OrderIDs) != 1 {
+		t.Errorf("take profit orders = %v, want 1", state.TakeProfit.OrderIDs)
+	}
+	server.Trade(b.BTC, b.KRW, b.Bid, b.MustDecimal("0.5"))
+	if err := waitResult(t, result); err != nil {
+		t.Fatalf("Run error = %v", err)
+	}
+}
+
+func TestBracketResumeMarketEntryUnknown(t *testing.T) {
+	server, client := newGroupClient(t)
+	server.SetBalance(b.KRW, b.MustDecimal("200000000"))
+	server.AddLiquidity(b.BTC, b.KRW, b.Ask, b.MustDecimal("100000000"), b.MustDecimal("1"))
+	server.Inject(bithumbtest.Injection{Endpoint: "/trade/market_buy", Delay: 300 * time.Millisecond, Times: 1})
+	group, err := b.NewBracket(client, b.BracketOrder{
+		Market:     b.NewMarket(b.BTC, b.KRW),
+		Side:       b.Bid,
+		Units:      b.MustDecimal("0.5"),
+		TakeProfit: b.MustDecimal("110000000"),
+		StopPrice:  b.MustDecimal("90000000"),
+	}, b.WithGroupInterval(10*time.Millisecond), b.WithGroupLogger(b.NopLogger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := group.Run(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Run error = %v, want context.DeadlineExceeded", err)
+	}
+	time.Sleep(400 * time.Millisecond)
+
+	// 시장가 주문은 걸려 있지 않고 체결 내역만 있으므로, 다시 주문하지 않고 실패
+	err = group.Run(context.Background())
+	if !errors.Is(err, b.ErrOrderResultUnknown) {
+		t.Fatalf("Run error = %v, want ErrOrderResultUnknown", err)
+	}
+	if requests := server.Requests("/trade/market_buy"); requests != 1 {
+		t.Errorf("market buy requests = %d, want 1", requests)
+	}
+	if total, _ := server.Balance(b.BTC); !total.Equal(b.MustDecimal("0.5")) {
+		t.Errorf("btc balance = %s, want 0.5", total)
+	}
+	if state := group.State(); state.Status != b.OrderGroupFailed {
+		t.Errorf("status = %s, want failed", state.Status)
+	}
+}
+
+func TestOrderGroupRunning(t *testing.T) {
+	server, client := newGroupClient(t)
+	server.SetBalance(b.BTC, b.MustDecimal("0.5"))
+	group := newTestOCO(t, client)
+	ctx, cancel := context.WithCancel(context.Background())
+	result := runGroup(ctx, group)
+	waitGroup(t, group, func(state b.OrderGroupState) bool { return state.TakeProfit.OrderID != "" })
+
+	if err := group.Run(context.Background()); !errors.Is(err, b.ErrOrderGroupRunning) {
+		t.Errorf("second Run error = %v, want ErrOrderGroupRunning", err)
+	}
+	cancel()
+	if err := waitResult(t, result); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,159 @@
+package gobithumb
+
+import (
+	"fmt"
+	"sort"
+)
+
+// 주문 전에 확인하는 가격, 수량 규칙 오류입니다. 모두 errors.Is(err, ErrInvalidParameter) 로도 확인할 수 있습니다.
+var (
+	ErrInvalidTickSize = fmt.Errorf("%w: price is not on the tick ladder", ErrInvalidParameter)
+	ErrInvalidUnits    = fmt.Errorf("%w: units exceed allowed precision", ErrInvalidParameter)
+	ErrBelowMinOrder   = fmt.Errorf("%w: order value below minimum", ErrInvalidParameter)
+)
+
+// TickBand 는 From 이상의 가격에 적용되는 호가 단위입니다.
+type TickBand struct {
+	From Decimal
+	Tick Decimal
+}
+
+// OrderRules 는 주문 가격의 호가 단위, 수량의 소수점 자릿수, 최소 주문 금액 규칙입니다.
+// TickBands, MinOrderValue 는 payment currency 별로 지정하며, 지정되지 않은 시장은 확인하지 않습니다.
+type OrderRules struct {
+	TickBands     map[Currency][]TickBand
+	MinOrderValue map[Currency]Decimal
+	UnitsPlaces   int32
+}
+
+// DefaultOrderRules 는 Bithumb 의 KRW, BTC 마켓 규칙을 반환합니다. 호출할 때마다 새 값을 만드므로 수정해도 안전합니다.
+func DefaultOrderRules() OrderRules {
+	return OrderRules{
+		TickBands: map[Currency][]TickBand{
+			KRW: {
+				{MustDecimal("0"), MustDecimal("0.0001")},
+				{MustDecimal("1"), MustDecimal("0.001")},
+				{MustDecimal("10"), MustDecimal("0.01")},
+				{MustDecimal("100"), MustDecimal("0.1")},
+				{MustDecimal("1000"), MustDecimal("1")},
+				{MustDecimal("5000"), MustDecimal("5")},
+				{MustDecimal("10000"), MustDecimal("10")},
+				{MustDecimal("50000"), MustDecimal("50")},
+				{MustDecimal("100000"), MustDecimal("100")},
+				{MustDecimal("500000"), MustDecimal("500")},
+				{MustDecimal("1000000"), MustDecimal("1000")},
+			},
+			BTC: {
+				{MustDecimal("0"), MustDecimal("0.00000001")},
+			},
+		},
+		MinOrderValue: map[Currency]Decimal{
+			KRW: MustDecimal("5000"),
+			BTC: MustDecimal("0.0005"),
+		},
+		UnitsPlaces: 4,
+	}
+}
+
+// TickSize 는 price 에 적용되는 호가 단위를 반환합니다. 규칙이 없으면 0 을 반환합니다.
+func (r OrderRules) TickSize(paymentCurrency Currency, price Decimal) Decimal {
+	bands := append([]TickBand(nil), r.TickBands[paymentCurrency]...)
+	sort.Slice(bands, func(i, j int) bool { return bands[i].From.LessThan(bands[j].From) })
+
+	tick := Decimal{}
+	for _, band := range bands {
+		if price.LessThan(band.From) {
+			break
+		}
+		tick = band.Tick
+	}
+	return tick
+}
+
+// RoundPrice 는 price 를 호가 단위에 맞춥니다. 불리한 가격에 체결되지 않도록 매수는 내림, 매도는 올림합니다.
+func (r OrderRules) RoundPrice(paymentCurrency Currency, price Decimal, side OrderSide) Decimal {
+	tick := r.TickSize(paymentCurrency, price)
+	if tick.Sign() <= 0 {
+		return price
+	}
+	rounded := price.Div(tick, 0).Mul(tick)
+	if side == Ask && rounded.LessThan(price) {
+		rounded = rounded.Add(tick)
+	}
+	return rounded
+}
+
+// RoundUnits 는 units 를 허용된 소수점 자릿수로 내림합니다.
+func (r OrderRules) RoundUnits(units Decimal) Decimal {
+	return units.Floor(r.UnitsPlaces)
+}
+
+// Validate 는 지정가 주문의 가격, 수량이 규칙에 맞는지 확인합니다.
+func (r OrderRules) Validate(paymentCurrency Currency, price Decimal, units Decimal) error {
+	if err := r.ValidatePrice(paymentCurrency, price); err != nil {
+		return err
+	}
+	if err := r.ValidateUnits(units); err != nil {
+		return err
+	}
+	if minValue, ok := r.MinOrderValue[paymentCurrency]; ok && price.Mul(units).LessThan(minValue) {
+		return fmt.Errorf("%w: 주문 금액 %s 이 최소 주문 금액 %s 보다 작습니다.", ErrBelowMinOrder, price.Mul(units), minValue)
+	}
+	return nil
+}
+
+// ValidatePrice 는 가격이 0 보다 크고 호가 단위에 맞는지 확인합니다.
+func (r OrderRules) ValidatePrice(paymentCurrency Currency, price Decimal) error {
+	if price.Sign() <= 0 {
+		return fmt.Errorf("%w: 주문 가격은 0 보다 커야 합니다. (%s)", ErrInvalidParameter, price)
+	}
+	if tick := r.TickSize(paymentCurrency, price); tick.Sign() > 0 && !price.Div(tick, 0).Mul(tick).Equal(price) {
+		return fmt.Errorf("%w: 가격 %s 는 호가 단위 %s 에 맞지 않습니다.", ErrInvalidTickSize, price, tick)
+	}
+	return nil
+}
+
+// ValidateUnits 는 수량이 0 보다 크고 허용된 소수점 자릿수 안에 있는지 확인합니다.
+func (r OrderRules) ValidateUnits(units Decimal) error {
+	if units.Sign() <= 0 {
+		return fmt.Errorf("%w: 주문 수량은 0 보다 커야 합니다. (%s)", ErrInvalidParameter, units)
+	}
+	if !r.RoundUnits(units).Equal(units) {
+		return fmt.Errorf("%w: 수량 %s 는 소수점 %d 자리까지만 주문할 수 있습니다.", ErrInvalidUnits, units, r.UnitsPlaces)
+	}
+	return nil
+}
+
+// prepareLimit 은 설정에 따라 지정가 주문의 수량, 가격을 맞춘 뒤 규칙을 확인합니다. 규칙이 없으면 그대로 반환합니다.
+func (b *BithumbRequester) prepareLimit(paymentCurrency Currency, units Decimal, price Decimal, side OrderSide) (Decimal, Decimal, error) {
+	if b.orderRules == nil {
+		return units, price, nil
+	}
+	if b.roundOrders {
+		units = b.orderRules.RoundUnits(units)
+		price = b.orderRules.RoundPrice(paymentCurrency, price, side)
+	}
+	return units, price, b.orderRules.Validate(paymentCurrency, price, units)
+}
+
+// preparePrice 는 stop limit 의 감시가격처럼 수량과 관계없는 가격을 맞추고 확인합니다.
+func (b *BithumbRequester) preparePrice(paymentCurrency Currency, price Decimal, side OrderSide) (Decimal, error) {
+	if b.orderRules == nil {
+		return price, nil
+	}
+	if b.roundOrders {
+		price = b.orderRules.RoundPrice(paymentCurrency, price, side)
+	}
+	return price, b.orderRules.ValidatePrice(paymentCurrency, price)
+}
+
+// prepareUnits 는 시장가 주문의 수량을 맞추고 확인합니다.
+func (b *BithumbRequester) prepareUnits(units Decimal) (Decimal, error) {
+	if b.orderRules == nil {
+		return units, nil
+	}
+	if b.roundOrders {
+		units = b.orderRules.RoundUnits(units)
+	}
+	return units, b.orderRules.ValidateUnits(units)
+}
@@ -0,0 +1,396 @@
+package gobithumb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrOrderCancelled = errors.New("gobithumb: order cancelled")
+
+var ErrTrackerRunning = errors.New("gobithumb: order tracker is already running")
+
+// 완료된 주문은 finishedRetention 이 지나면 OrderTracker 의 목록에서 지워집니다.
+const finishedRetention = 10 * time.Minute
+
+// OrderEventType 은 OrderTracker 가 알려주는 주문 상태 변화입니다.
+type OrderEventType string
+
+const (
+	OrderPlaced          OrderEventType = "placed"           // 처음 조회됨
+	OrderPartiallyFilled OrderEventType = "partially_filled" // 일부 체결
+	OrderFilled          OrderEventType = "filled"           // 전량 체결
+	OrderCancelled       OrderEventType = "cancelled"        // 취소 (일부 체결 포함)
+)
+
+// OrderEvent 는 주문 하나의 상태 변화입니다.
+// Fills 와 FilledDelta 는 직전 이벤트 이후 새로 생긴 체결이며, FilledUnits 는 지금까지의 누적 체결 수량입니다.
+type OrderEvent struct {
+	Type        OrderEventType
+	Market      Market
+	OrderID     string
+	Detail      OrderDetail
+	Fills       []SingleOrderDetail
+	FilledUnits Decimal
+	FilledDelta Decimal
+	Time        time.Time
+}
+
+// OrderTrackerOption 은 NewOrderTracker 에 전달해 OrderTracker 의 동작을 바꿉니다.
+type OrderTrackerOption func(*OrderTracker)
+
+// WithTrackInterval 은 GetOrderDetail 을 조회하는 간격입니다. 변화가 없으면 maxInterval 까지 두 배씩 늘어나고, 변화가 있으면 minInterval 로 돌아옵니다.
+func WithTrackInterval(minInterval time.Duration, maxInterval time.Duration) OrderTrackerOption {
+	return func(o *OrderTracker) {
+		o.minInterval = minInterval
+		o.maxInterval = maxInterval
+	}
+}
+
+func WithTrackerLogger(logger Logger) OrderTrackerOption {
+	return func(o *OrderTracker) {
+		if logger == nil {
+			logger = NopLogger
+		}
+		o.logger = logger
+	}
+}
+
+// WithTrackerBuffer 는 이벤트 channel 의 크기를 지정합니다.
+func WithTrackerBuffer(size int) OrderTrackerOption {
+	return func(o *OrderTracker) {
+		o.bufferSize = size
+	}
+}
+
+// OrderTracker 는 등록된 주문을 GetOrderDetail 로 조회해 체결, 취소될 때까지 따라가고, 상태 변화를 channel 로 전달합니다.
+// Run 을 실행해두고 Events 를 읽거나, WaitFilled 로 주문 하나가 끝날 때까지 기다릴 수 있습니다.
+type OrderTracker struct {
+	requester AccountInfo
+	logger    Logger
+
+	minInterval time.Duration
+	maxInterval time.Duration
+	bufferSize  int
+
+	mutex      sync.Mutex
+	orders     map[string]*trackedOrder
+	subscribed bool
+	running    bool
+
+	events chan OrderEvent
+	wake   chan struct{}
+}
+
+type trackedOrder struct {
+	market     Market
+	id         string
+	placed     bool
+	contracts  int
+	filled     Decimal
+	detail     OrderDetail
+	interval   time.Duration
+	lastPoll   time.Time
+	nextPoll   time.Time
+	finishedAt time.Time
+	done       chan struct{}
+}
+
+func NewOrderTracker(requester AccountInfo, options ...OrderTrackerOption) *OrderTracker {
+
+	orderTracker := OrderTracker{}
+
+	orderTracker.requester = requester
+	orderTracker.logger = NewStdLogger(os.Stdout, LogWarn)
+	orderTracker.minInterval = time.Second
+	orderTracker.maxInterval = 30 * time.Second
+	orderTracker.bufferSize = 64
+
+	for _, option := range options {
+		option(&orderTracker)
+	}
+	if orderTracker.maxInterval < orderTracker.minInterval {
+		orderTracker.maxInterval = orderTracker.minInterval
+	}
+
+	orderTracker.orders = make(map[string]*trackedOrder)
+	orderTracker.events = make(chan OrderEvent, orderTracker.bufferSize)
+	orderTracker.wake = make(chan struct{}, 1)
+
+	return &orderTracker
+}
+
+// Events 는 상태 변화 channel 을 반환합니다. Events 를 한 번이라도 호출하면 이후의 이벤트는 읽을 때까지 기다리므로, 계속 읽어야 합니다.
+func (o *OrderTracker) Events() <-chan OrderEvent {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.subscribed = true
+	return o.events
+}
+
+// Track 은 MarketBuy, PlaceOrder 등이 반환한 주문을 등록합니다. 이미 등록된 주문이면 아무것도 하지 않습니다.
+func (o *OrderTracker) Track(market Market, orderId string) {
+	o.mutex.Lock()
+	o.trackLocked(market, orderId)
+	o.mutex.Unlock()
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *OrderTracker) trackLocked(market Market, orderId string) *trackedOrder {
+	if order, ok := o.orders[orderId]; ok {
+		return order
+	}
+	order := &trackedOrder{market: market, id: orderId, interval: o.minInterval, nextPoll: time.Now(), done: make(chan struct{})}
+	o.orders[orderId] = order
+	return order
+}
+
+// Untrack 은 주문을 더 이상 조회하지 않습니다. 기다리고 있던 WaitFilled 는 ctx 가 끝날 때까지 계속 기다립니다.
+func (o *OrderTracker) Untrack(orderId string) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	delete(o.orders, orderId)
+}
+
+// Run 은 등록된 주문을 조회 간격에 맞춰 조회합니다. ctx 가 끝나면 ctx.Err() 를 반환합니다.
+// stream 을 주면 추적 중인 시장에 체결이 생길 때 바로 (최소 간격을 지켜) 조회합니다. 이 경우 stream 의 Transactions channel 을 읽습니다.
+// 이미 실행 중이면 ErrTrackerRunning 을 반환합니다.
+func (o *OrderTracker) Run(ctx context.Context, stream ...*StreamClient) error {
+	o.mutex.Lock()
+	if o.running {
+		o.mutex.Unlock()
+		return ErrTrackerRunning
+	}
+	o.running = true
+	o.mutex.Unlock()
+	defer func() {
+		o.mutex.Lock()
+		o.running = false
+		o.mutex.Unlock()
+	}()
+
+	var transactions <-chan TransactionEvent
+	if len(stream) > 0 && stream[0] != nil {
+		transactions = stream[0].Transactions()
+	}
+
+	for {
+		for _, order := range o.due() {
+			o.poll(ctx, order)
+		}
+
+		timer := time.NewTimer(o.untilNextPoll())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-o.wake:
+		case <-timer.C:
+		case event := <-transactions:
+			o.hurry(NewMarket(event.OrderCurrency, event.PaymentCurrency))
+		}
+		timer.Stop()
+	}
+}
+
+// due 는 지금 조회할 주문 목록을 반환하고, 오래된 완료 주문을 지웁니다.
+func (o *OrderTracker) due() []*trackedOrder {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	now := time.Now()
+	var result []*trackedOrder
+	for id, order := range o.orders {
+		if !order.finishedAt.IsZero() {
+			if now.Sub(order.finishedAt) > finishedRetention {
+				delete(o.orders, id)
+			}
+			continue
+		}
+		if !order.nextPoll.After(now) {
+			result = append(result, order)
+		}
+	}
+	return result
+}
+
+func (o *OrderTracker) untilNextPoll() time.Duration {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	wait := o.maxInterval
+	now := time.Now()
+	for _, order := range o.orders {
+		if order.finishedAt.IsZero() && order.nextPoll.Sub(now) < wait {
+			wait = order.nextPoll.Sub(now)
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// hurry 는 market 의 주문을 마지막 조회로부터 최소 간격이 지나면 바로 조회하도록 합니다.
+func (o *OrderTracker) hurry(market Market) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for _, order := range o.orders {
+		if order.market != market || !order.finishedAt.IsZero() {
+			continue
+		}
+		order.interval = o.minInterval
+		if next := order.lastPoll.Add(o.minInterval); next.Before(order.nextPoll) {
+			order.nextPoll = next
+		}
+	}
+}
+
+// poll 은 주문을 한 번 조회하고, 상태 변화가 있으면 이벤트를 보냅니다.
+func (o *OrderTracker) poll(ctx context.Context, order *trackedOrder) {
+	detail, err := o.requester.GetOrderDetailCtx(ctx, order.market.Base, order.market.Quote, order.id)
+
+	o.mutex.Lock()
+	order.lastPoll = time.Now()
+	if err != nil {
+		o.mutex.Unlock()
+		if ctx.Err() == nil {
+			o.logger.Log(LogWarn, "order tracking failed", LogField{"order_id", order.id}, LogField{"error", err})
+		}
+		o.backoff(order)
+		return
+	}
+	events := order.update(detail, order.lastPoll)
+	subscribed := o.subscribed
+	o.mutex.Unlock()
+
+	if len(events) == 0 {
+		o.backoff(order)
+	} else {
+		o.mutex.Lock()
+		order.interval = o.minInterval
+		order.nextPoll = order.lastPoll.Add(o.minInterval)
+		o.mutex.Unlock()
+	}
+	if !subscribed {
+		return
+	}
+	for _, event := range events {
+		select {
+		case o.events <- event:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// backoff 는 변화가 없는 주문의 조회 간격을 늘립니다.
+func (o *OrderTracker) backoff(order *trackedOrder) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	order.interval *= 2
+	if order.interval > o.maxInterval {
+		order.interval = o.maxInterval
+	}
+	order.nextPoll = order.lastPoll.Add(order.interval)
+}
+
+// update 는 조회 결과를 반영하고 새로 생긴 이벤트를 반환합니다.
+func (t *trackedOrder) update(detail OrderDetail, now time.Time) []OrderEvent {
+	newEvent := func(eventType OrderEventType, fills []SingleOrderDetail, delta Decimal) OrderEvent {
+		return OrderEvent{Type: eventType, Market: t.market, OrderID: t.id, Detail: detail, Fills: fills, FilledUnits: t.filled, FilledDelta: delta, Time: now}
+	}
+
+	// 다른 조회가 먼저 완료를 반영한 경우
+	if !t.finishedAt.IsZero() {
+		return nil
+	}
+
+	var events []OrderEvent
+	if !t.placed {
+		t.placed = true
+		events = append(events, newEvent(OrderPlaced, nil, Decimal{}))
+	}
+
+	var fills []SingleOrderDetail
+	if len(detail.Contract) > t.contracts {
+		fills = append(fills, detail.Contract[t.contracts:]...)
+		t.contracts = len(detail.Contract)
+	}
+	filled := Decimal{}
+	for _, data := range detail.Contract {
+		filled = filled.Add(data.Units)
+	}
+	delta := filled.Sub(t.filled)
+	t.filled = filled
+	t.detail = detail
+
+	switch detail.OrderStatus {
+	case OrderCompleted:
+		events = append(events, newEvent(OrderFilled, fills, delta))
+	case OrderCancel:
+		events = append(events, newEvent(OrderCancelled, fills, delta))
+	default:
+		if delta.Sign() > 0 {
+			events = append(events, newEvent(OrderPartiallyFilled, fills, delta))
+		}
+	}
+
+	if detail.OrderStatus.Done() {
+		t.finishedAt = now
+		close(t.done)
+	}
+	return events
+}
+
+// WaitFilled 는 주문이 전량 체결될 때까지 기다리고 최종 주문 상태를 반환합니다. 등록되지 않은 주문이면 등록합니다.
+// 주문이 취소되면 ErrOrderCancelled 를 반환합니다. Run 이 실행 중이 아니면 WaitFilled 가 직접 조회합니다.
+func (o *OrderTracker) WaitFilled(ctx context.Context, market Market, orderId string) (OrderDetail, error) {
+	o.mutex.Lock()
+	order := o.trackLocked(market, orderId)
+	o.mutex.Unlock()
+
+	for {
+		o.mutex.Lock()
+		running := o.running
+		due := order.finishedAt.IsZero() && !order.nextPoll.After(time.Now())
+		o.mutex.Unlock()
+
+		if !running && due {
+			o.poll(ctx, order)
+		}
+
+		o.mutex.Lock()
+		wait := order.nextPoll.Sub(time.Now())
+		if running || wait < 0 {
+			wait = o.minInterval
+		}
+		o.mutex.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return OrderDetail{}, ctx.Err()
+		case <-order.done:
+			timer.Stop()
+			o.mutex.Lock()
+			detail := order.detail
+			o.mutex.Unlock()
+			if detail.OrderStatus == OrderCancel {
+				return detail, fmt.Errorf("%w: %s", ErrOrderCancelled, orderId)
+			}
+			return detail, nil
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,804 @@
+package gobithumb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNoLiquidity  = errors.New("gobithumb: no orderbook liquidity")
+	ErrNotSupported = errors.New("gobithumb: not supported")
+)
+
+// PaperTrader 는 실제 주문 대신 가상 잔고로 주문을 체결하는 Exchange 입니다.
+// 시세 조회는 source 로 그대로 보내고, 주문은 source 의 호가창과 체결 내역을 기준으로 체결합니다.
+//   - 시장가 주문과 바로 체결 가능한 지정가 주문은 현재 호가창을 따라가며 호가의 가격으로 체결됩니다.
+//   - 남은 지정가 주문은 이후 GetTransactionHistory 에 주문 가격을 지나는 체결이 나오면, 그 수량만큼 주문 가격으로 체결됩니다.
+//   - stop limit 주문은 체결가가 감시가격에 도달하면 지정가 주문이 됩니다.
+//
+// 대기 중인 주문은 잔고, 주문 조회 메소드를 호출할 때 (또는 Sync 를 호출할 때) 체결됩니다. 수수료는 payment currency 로 냅니다.
+type PaperTrader struct {
+	source MarketData
+
+	created time.Time
+
+	mutex        sync.Mutex
+	tradeFee     Decimal
+	orderRules   *OrderRules
+	balances     map[Currency]*paperBalance
+	orders       map[string]*paperOrder
+	transactions []Transaction
+	cursors      map[Market]*paperCursor
+	nextOrderId  int64
+}
+
+type paperBalance struct {
+	total Decimal
+	inUse Decimal
+}
+
+type paperOrder struct {
+	id         string
+	market     Market
+	side       OrderSide
+	price      Decimal // 시장가 주문이면 0
+	watchPrice Decimal
+	triggered  bool
+	units      Decimal
+	remaining  Decimal
+	locked     Decimal // 아직 묶여있는 잔고 (bid : payment currency, ask : order currency)
+	created    time.Time
+	status     OrderStatus
+	cancelDate time.Time
+	cancelType CancelType
+	contracts  []SingleOrderDetail
+}
+
+// paperCursor 는 market 의 체결 내역을 어디까지 반영했는지 기억합니다.
+// 체결 시각이 초 단위이므로, 마지막 시각 (date) 에 나온 체결 중 앞에서부터 몇 개 (count) 를 반영했는지로 위치를 기억합니다.
+// 같은 초에 가격, 수량이 같은 체결이 여러 번 나와도 모두 반영됩니다. 주문을 넣은 초에 나온 체결은 주문 전일 수 있으므로 반영하지 않습니다.
+type paperCursor struct {
+	date  time.Time
+	count int
+}
+
+// NewPaperTrader 는 source 의 시세로 주문을 체결하고 tradeFee (e.g. 0.0025) 만큼 수수료를 내는 PaperTrader 를 만듭니다.
+// 실제 계정과 같은 수수료를 쓰려면 GetAccount 의 TradeFee 를 넘기세요. 잔고는 SetBalance 로 채웁니다.
+func NewPaperTrader(source MarketData, tradeFee Decimal) *PaperTrader {
+
+	paperTrader := PaperTrader{}
+
+	paperTrader.source = source
+	paperTrader.created = time.Now()
+	paperTrader.tradeFee = tradeFee
+	defaultRules := DefaultOrderRules()
+	paperTrader.orderRules = &defaultRules
+	paperTrader.balances = make(map[Currency]*paperBalance)
+	paperTrader.orders = make(map[string]*paperOrder)
+	paperTrader.cursors = make(map[Market]*paperCursor)
+
+	return &paperTrader
+}
+
+// SetBalance 는 currency 의 가상 잔고를 total 로 바꿉니다. 주문에 묶인 수량은 그대로 유지됩니다.
+func (p *PaperTrader) SetBalance(currency Currency, total Decimal) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.balanceOf(currency).total = total
+}
+
+// SetOrderRules 는 주문 전에 확인할 규칙을 바꿉니다. (기본값 : DefaultOrderRules()) nil 이면 확인하지 않습니다.
+func (p *PaperTrader) SetOrderRules(rules *OrderRules) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.orderRules = rules
+}
+
+func (p *PaperTrader) balanceOf(currency Currency) *paperBalance {
+	currency = Currency(strings.ToLower(string(currency)))
+	if _, ok := p.balances[currency]; !ok {
+		p.balances[currency] = &paperBalance{}
+	}
+	return p.balances[currency]
+}
+
+//==============================MARKET DATA======================================
+
+func (p *PaperTrader) GetTradableCoinListCtx(ctx context.Context) ([]Currency, error) {
+	return p.source.GetTradableCoinListCtx(ctx)
+}
+
+func (p *PaperTrader) GetTradableMarketsCtx(ctx context.Context, paymentCurrency Currency) ([]Market, error) {
+	return p.source.GetTradableMarketsCtx(ctx, paymentCurrency)
+}
+
+func (p *PaperTrader) GetTickerCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (map[Currency]Ticker, time.Time, error) {
+	return p.source.GetTickerCtx(ctx, orderCurrency, paymentCurrency)
+}
+
+func (p *PaperTrader) GetOrderbookCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (map[Currency]Orderbook, time.Time, error) {
+	return p.source.GetOrderbookCtx(ctx, orderCurrency, paymentCurrency)
+}
+
+func (p *PaperTrader) GetTransactionHistoryCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) ([]OneTransaction, error) {
+	return p.source.GetTransactionHistoryCtx(ctx, orderCurrency, paymentCurrency)
+}
+
+func (p *PaperTrader) GetAssetsStatusCtx(ctx context.Context, orderCurrency Currency) (bool, bool, error) {
+	return p.source.GetAssetsStatusCtx(ctx, orderCurrency)
+}
+
+func (p *PaperTrader) GetBTCICtx(ctx context.Context) (BTCI, time.Time, error) {
+	return p.source.GetBTCICtx(ctx)
+}
+
+func (p *PaperTrader) GetCandleStickCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, chartInterval TimeInterval) ([]OneCandleStick, error) {
+	return p.source.GetCandleStickCtx(ctx, orderCurrency, paymentCurrency, chartInterval)
+}
+
+// orderbook 은 market 의 호가창 하나를 가져옵니다.
+func (p *PaperTrader) orderbook(ctx context.Context, market Market) (Orderbook, error) {
+	orderbooks, _, err := p.source.GetOrderbookCtx(ctx, market.Base, market.Quote)
+	if err != nil {
+		return Orderbook{}, err
+	}
+	for currency, data := range orderbooks {
+		if strings.EqualFold(string(currency), string(market.Base)) {
+			return data, nil
+		}
+	}
+	return Orderbook{}, nil
+}
+
+//==============================ACCOUNT INFO======================================
+
+// GetAccountCtx 의 Balance 는 orderCurrency 의 가상 잔고이며, ID 는 "paper" 입니다.
+func (p *PaperTrader) GetAccountCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (Account, error) {
+	if err := p.SyncCtx(ctx, NewMarket(orderCurrency, paymentCurrency)); err != nil {
+		return Account{}, err
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return Account{ID: "paper", Created: p.created, Balance: p.balanceOf(orderCurrency).total, TradeFee: p.tradeFee}, nil
+}
+
+func (p *PaperTrader) GetBalance(orderCurrency Currency) (map[Currency]*Balance, error) {
+	return p.GetBalanceCtx(context.Background(), orderCurrency)
+}
+
+// GetBalanceCtx 는 대기 중인 모든 주문을 체결한 뒤 가상 잔고를 반환합니다. ALL 이 아니면 orderCurrency 와 KRW 만 반환합니다.
+func (p *PaperTrader) GetBalanceCtx(ctx context.Context, orderCurrency Currency) (map[Currency]*Balance, error) {
+	if err := p.syncAll(ctx); err != nil {
+		return nil, err
+	}
+
+	orderCurrency = Currency(strings.ToLower(string(orderCurrency)))
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.balanceOf(KRW)
+	if orderCurrency != ALL {
+		p.balanceOf(orderCurrency)
+	}
+	result := make(map[Currency]*Balance)
+	for currency, data := range p.balances {
+		if orderCurrency == ALL || currency == orderCurrency || currency == KRW {
+			result[currency] = &Balance{Total: data.total, InUse: data.inUse, Available: data.total.Sub(data.inUse)}
+		}
+	}
+	return result, nil
+}
+
+func (p *PaperTrader) GetMarketBalanceCtx(ctx context.Context, market Market) (Balance, Balance, error) {
+	market = NewMarket(market.Base, market.Quote)
+	if !market.Valid() {
+		return Balance{}, Balance{}, fmt.Errorf("%w: 잘못된 시장입니다. (%s)", ErrInvalidParameter, market)
+	}
+	balances, err := p.GetBalanceCtx(ctx, ALL)
+	if err != nil {
+		return Balance{}, Balance{}, err
+	}
+	base, quote := Balance{}, Balance{}
+	if data, ok := balances[market.Base]; ok {
+		base = *data
+	}
+	if data, ok := balances[market.Quote]; ok {
+		quote = *data
+	}
+	return base, quote, nil
+}
+
+// GetUserTickerCtx 는 public ticker 를 UserTicker 형태로 바꿔 반환합니다. Volume7Day 는 제공하지 않습니다.
+func (p *PaperTrader) GetUserTickerCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (UserTicker, error) {
+	tickers, _, err := p.source.GetTickerCtx(ctx, orderCurrency, paymentCurrency)
+	if err != nil {
+		return UserTicker{}, err
+	}
+	for currency, data := range tickers {
+		if !strings.EqualFold(string(currency), string(orderCurrency)) {
+			continue
+		}
+		result := UserTicker{
+			OpeningPrice:    data.OpeningPrice,
+			ClosingPrice:    data.ClosingPrice,
+			MaxPrice:        data.MaxPrice,
+			MinPrice:        data.MinPrice,
+			UnitsTraded:     data.UnitsTraded,
+			Volume1Day:      data.UnitsTraded24H,
+			Fluctate24H:     data.Fluctate24H,
+			FluctateRate24H: data.FluctateRate24H,
+		}
+		if data.UnitsTraded.Sign() > 0 {
+			result.AveragePrice = data.AccTradeValue.Div(data.UnitsTraded, 8)
+		}
+		return result, nil
+	}
+	return UserTicker{}, nil
+}
+
+func (p *PaperTrader) GetOrder(orderCurrency Currency, paymentCurrency Currency, count int, date ...time.Time) ([]Order, error) {
+	return p.GetOrderCtx(context.Background(), orderCurrency, paymentCurrency, count, date...)
+}
+
+// GetOrderCtx 는 대기 중인 주문을 먼저 넣은 순서대로 반환합니다. 실제 API 와 달리 주문이 없으면 오류 없이 빈 slice 를 반환합니다.
+func (p *PaperTrader) GetOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, count int, date ...time.Time) ([]Order, error) {
+
+	// parameter 정상 체크
+	if !(count > 0 && count < 1001) {
+		return nil, fmt.Errorf("%w: 주문의 개수는 1~1000 사이의 정수여야 합니다.", ErrInvalidParameter)
+	}
+
+	market := NewMarket(orderCurrency, paymentCurrency)
+	if err := p.SyncCtx(ctx, market); err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var result []Order
+	for _, o := range p.sortedOrders() {
+		if o.market != market || o.status != OrderPending || (len(date) > 0 && o.created.Before(date[0])) {
+			continue
+		}
+		result = append(result, Order{
+			OrderDate:       o.created,
+			OrderCurrency:   o.market.Base,
+			PaymentCurrency: o.market.Quote,
+			OrderID:         o.id,
+			Price:           o.price,
+			Type:            o.side,
+			Units:           o.units,
+			UnitsRemaining:  o.remaining,
+			WatchPrice:      o.watchPrice,
+		})
+		if len(result) >= count {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (p *PaperTrader) GetOrderDetail(orderCurrency Currency, paymentCurrency Currency, orderId string) (OrderDetail, error) {
+	return p.GetOrderDetailCtx(context.Background(), orderCurrency, paymentCurrency, orderId)
+}
+
+// GetOrderDetailCtx 는 주문의 상태와 체결 내역을 반환합니다. 주문이 없으면 ErrOrderNotFound 를 반환합니다.
+func (p *PaperTrader) GetOrderDetailCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, orderId string) (OrderDetail, error) {
+	market := NewMarket(orderCurrency, paymentCurrency)
+	if err := p.SyncCtx(ctx, market); err != nil {
+		return OrderDetail{}, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	o, ok := p.orders[orderId]
+	if !ok || o.market != market {
+		return OrderDetail{}, fmt.Errorf("%w: %s", ErrOrderNotFound, orderId)
+	}
+	return OrderDetail{
+		OrderDate:       o.created,
+		Type:            o.side,
+		OrderStatus:     o.status,
+		OrderCurrency:   o.market.Base,
+		PaymentCurrency: o.market.Quote,
+		OrderPrice:      o.price,
+		OrderQty:        o.units,
+		CancelDate:      o.cancelDate,
+		CancelType:      o.cancelType,
+		Contract:        append([]SingleOrderDetail(nil), o.contracts...),
+	}, nil
+}
+
+// GetTransactionsCtx 는 가상 체결 내역을 최근 체결부터 반환합니다. search 는 All, BuyComplete, SellComplete 만 의미가 있습니다.
+func (p *PaperTrader) GetTransactionsCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, search SearchType, offsetCount ...int) ([]Transaction, error) {
+	market := NewMarket(orderCurrency, paymentCurrency)
+	if err := p.SyncCtx(ctx, market); err != nil {
+		return nil, err
+	}
+
+	offset, count := 0, 20
+	if len(offsetCount) == 2 {
+		offset, count = offsetCount[0], offsetCount[1]
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var result []Transaction
+	for index := len(p.transactions) - 1; index >= 0 && len(result) < count; index-- {
+		data := p.transactions[index]
+		if data.OrderCurrency != market.Base || data.PaymentCurrency != market.Quote || (search != All && data.Search != search) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+func (p *PaperTrader) sortedOrders() []*paperOrder {
+	result := make([]*paperOrder, 0, len(p.orders))
+	for _, o := range p.orders {
+		result = append(result, o)
+	}
+	// id 는 생성 순서대로 증가함
+	sort.Slice(result, func(i, j int) bool { return result[i].id < result[j].id })
+	return result
+}
+
+//==============================TRADING======================================
+
+func (p *PaperTrader) PlaceOrder(orderCurrency Currency, paymentCurrency Currency, amount Decimal, price Decimal, side OrderSide) (string, error) {
+	return p.PlaceOrderCtx(context.Background(), orderCurrency, paymentCurrency, amount, price, side)
+}
+
+func (p *PaperTrader) PlaceOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal, price Decimal, side OrderSide) (string, error) {
+	return p.placeLimit(ctx, NewMarket(orderCurrency, paymentCurrency), amount, price, Decimal{}, side)
+}
+
+func (p *PaperTrader) StopLimit(orderCurrency Currency, paymentCurrency Currency, watchPrice Decimal, price Decimal, amount Decimal, side OrderSide) (string, error) {
+	return p.StopLimitCtx(context.Background(), orderCurrency, paymentCurrency, watchPrice, price, amount, side)
+}
+
+func (p *PaperTrader) StopLimitCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, watchPrice Decimal, price Decimal, amount Decimal, side OrderSide) (string, error) {
+	if watchPrice.Sign() <= 0 {
+		return "", fmt.Errorf("%w: 감시가격은 0 보다 커야 합니다. (%s)", ErrInvalidParameter, watchPrice)
+	}
+	return p.placeLimit(ctx, NewMarket(orderCurrency, paymentCurrency), amount, price, watchPrice, side)
+}
+
+// placeLimit 은 지정가 주문을 넣습니다. 바로 체결 가능한 수량은 현재 호가창과 체결하고, 남은 수량은 대기시킵니다.
+// watchPrice 가 있으면 현재 가격이 감시가격에 도달했을 때만 바로 체결을 시도합니다.
+func (p *PaperTrader) placeLimit(ctx context.Context, market Market, amount Decimal, price Decimal, watchPrice Decimal, side OrderSide) (string, error) {
+
+	// parameter 정상 체크
+	if !side.Valid() {
+		return "", fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, side)
+	}
+	if err := p.validate(market, amount, price, watchPrice); err != nil {
+		return "", err
+	}
+
+	book, err := p.orderbook(ctx, market)
+	if err != nil {
+		return "", err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	o := p.newOrder(market, side, price, amount, now)
+	o.watchPrice = watchPrice
+	o.triggered = watchPrice.Sign() <= 0
+
+	lockAmount := amount
+	if side == Bid {
+		lockAmount = price.Mul(amount).Mul(p.tradeFee.Add(NewDecimalFromInt(1)))
+	}
+	if err := p.lock(o, lockAmount); err != nil {
+		return "", err
+	}
+	p.orders[o.id] = o
+	p.watch(market, now)
+
+	if !o.triggered {
+		levels := book.Asks
+		if side == Ask {
+			levels = book.Bids
+		}
+		if len(levels) > 0 && p.reached(o, levels[0].Price) {
+			o.triggered = true
+		}
+	}
+	if o.triggered {
+		p.take(o, book, now)
+	}
+	return o.id, nil
+}
+
+func (p *PaperTrader) MarketBuy(orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error) {
+	return p.MarketBuyCtx(context.Background(), orderCurrency, paymentCurrency, amount)
+}
+
+func (p *PaperTrader) MarketBuyCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error) {
+	return p.placeMarket(ctx, NewMarket(orderCurrency, paymentCurrency), amount, Bid)
+}
+
+func (p *PaperTrader) MarketSell(orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error) {
+	return p.MarketSellCtx(context.Background(), orderCurrency, paymentCurrency, amount)
+}
+
+func (p *PaperTrader) MarketSellCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error) {
+	return p.placeMarket(ctx, NewMarket(orderCurrency, paymentCurrency), amount, Ask)
+}
+
+// placeMarket 은 시장가 주문을 현재 호가창과 체결합니다. 호가가 모자라 체결되지 않은 수량은 취소됩니다.
+func (p *PaperTrader) placeMarket(ctx context.Context, market Market, amount Decimal, side OrderSide) (string, error) {
+	p.mutex.Lock()
+	rules := p.orderRules
+	p.mutex.Unlock()
+	if rules != nil {
+		if err := rules.ValidateUnits(amount); err != nil {
+			return "", err
+		}
+	}
+
+	book, err := p.orderbook(ctx, market)
+	if err != nil {
+		return "", err
+	}
+	levels := book.Asks
+	if side == Ask {
+		levels = book.Bids
+	}
+	if len(levels) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrNoLiquidity, market)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	o := p.newOrder(market, side, Decimal{}, amount, now)
+	o.triggered = true
+
+	// 시장가 매수는 호가를 따라가며 필요한 금액을 미리 계산해 묶어둠
+	lockAmount := amount
+	if side == Bid {
+		lockAmount = Decimal{}
+		left := amount
+		for _, data := range levels {
+			filled := MinDecimal(left, data.Quantity)
+			lockAmount = lockAmount.Add(data.Price.Mul(filled).Mul(p.tradeFee.Add(NewDecimalFromInt(1))))
+			left = left.Sub(filled)
+			if left.Sign() <= 0 {
+				break
+			}
+		}
+	}
+	if err := p.lock(o, lockAmount); err != nil {
+		return "", err
+	}
+	p.orders[o.id] = o
+
+	p.take(o, book, now)
+	if o.remaining.Sign() > 0 {
+		p.cancel(o, now)
+	}
+	return o.id, nil
+}
+
+func (p *PaperTrader) CancelOrder(orderCurrency Currency, paymentCurrency Currency, orderId string, side OrderSide) error {
+	return p.CancelOrderCtx(context.Background(), orderCurrency, paymentCurrency, orderId, side)
+}
+
+// CancelOrderCtx 는 대기 중인 주문을 취소합니다. 취소 전에 그때까지의 체결을 먼저 반영합니다.
+func (p *PaperTrader) CancelOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, orderId string, side OrderSide) error {
+	market := NewMarket(orderCurrency, paymentCurrency)
+	if err := p.SyncCtx(ctx, market); err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	o, ok := p.orders[orderId]
+	if !ok || o.market != market || o.side != side || o.status != OrderPending {
+		return fmt.Errorf("%w: %s", ErrOrderNotFound, orderId)
+	}
+	p.cancel(o, time.Now())
+	return nil
+}
+
+func (p *PaperTrader) validate(market Market, amount Decimal, price Decimal, watchPrice Decimal) error {
+	p.mutex.Lock()
+	rules := p.orderRules
+	p.mutex.Unlock()
+	if rules == nil {
+		return nil
+	}
+	if err := rules.Validate(market.Quote, price, amount); err != nil {
+		return err
+	}
+	if watchPrice.Sign() > 0 {
+		return rules.ValidatePrice(market.Quote, watchPrice)
+	}
+	return nil
+}
+
+func (p *PaperTrader) newOrder(market Market, side OrderSide, price Decimal, units Decimal, now time.Time) *paperOrder {
+	p.nextOrderId++
+	return &paperOrder{
+		id:        fmt.Sprintf("P%019d", p.nextOrderId),
+		market:    market,
+		side:      side,
+		price:     price,
+		units:     units,
+		remaining: units,
+		created:   now,
+		status:    OrderPending,
+	}
+}
+
+// lock 은 주문에 필요한 잔고를 묶습니다. 사용 가능한 잔고가 부족하면 ErrInsufficientBalance 를 반환합니다.
+func (p *PaperTrader) lock(o *paperOrder, amount Decimal) error {
+	currency := o.market.Base
+	if o.side == Bid {
+		currency = o.market.Quote
+	}
+	bl := p.balanceOf(currency)
+	if available := bl.total.Sub(bl.inUse); available.LessThan(amount) {
+		return fmt.Errorf("%w: 주문에 %s %s 이 필요하지만 사용 가능한 잔고는 %s 입니다.", ErrInsufficientBalance, amount, strings.ToUpper(string(currency)), available)
+	}
+	bl.inUse = bl.inUse.Add(amount)
+	o.locked = amount
+	return nil
+}
+
+// unlock 은 주문에 남아있는 잔고를 돌려줍니다.
+func (p *PaperTrader) unlock(o *paperOrder) {
+	if o.locked.Sign() <= 0 {
+		return
+	}
+	currency := o.market.Base
+	if o.side == Bid {
+		currency = o.market.Quote
+	}
+	bl := p.balanceOf(currency)
+	bl.inUse = bl.inUse.Sub(o.locked)
+	o.locked = Decimal{}
+}
+
+func (p *PaperTrader) cancel(o *paperOrder, now time.Time) {
+	p.unlock(o)
+	o.status = OrderCancel
+	o.cancelDate = now
+	o.cancelType = CancelByUser
+}
+
+// take 는 주문을 반대편 호가와 체결합니다. 지정가 주문이면 가격 조건을 지킵니다.
+func (p *PaperTrader) take(o *paperOrder, book Orderbook, now time.Time) {
+	levels := book.Asks
+	if o.side == Ask {
+		levels = book.Bids
+	}
+	for _, data := range levels {
+		if o.remaining.Sign() <= 0 {
+			break
+		}
+		if o.price.Sign() > 0 && ((o.side == Bid && data.Price.GreaterThan(o.price)) || (o.side == Ask && data.Price.LessThan(o.price))) {
+			break
+		}
+		p.fill(o, data.Price, MinDecimal(o.remaining, data.Quantity), now)
+	}
+}
+
+// fill 은 주문 하나의 체결을 잔고와 체결 내역에 반영합니다.
+func (p *PaperTrader) fill(o *paperOrder, price Decimal, units Decimal, now time.Time) {
+	if units.Sign() <= 0 {
+		return
+	}
+	total := price.Mul(units)
+	fee := total.Mul(p.tradeFee)
+	coin := p.balanceOf(o.market.Base)
+	payment := p.balanceOf(o.market.Quote)
+
+	search := BuyComplete
+	if o.side == Bid {
+		release := total.Add(fee)
+		if o.price.Sign() > 0 {
+			release = o.price.Mul(units).Mul(p.tradeFee.Add(NewDecimalFromInt(1)))
+		}
+		release = MinDecimal(release, o.locked)
+		o.locked = o.locked.Sub(release)
+		payment.inUse = payment.inUse.Sub(release)
+		payment.total = payment.total.Sub(total).Sub(fee)
+		coin.total = coin.total.Add(units)
+	} else {
+		search = SellComplete
+		release := MinDecimal(units, o.locked)
+		o.locked = o.locked.Sub(release)
+		coin.inUse = coin.inUse.Sub(release)
+		coin.total = coin.total.Sub(units)
+		payment.total = payment.total.Add(total).Sub(fee)
+	}
+
+	o.remaining = o.remaining.Sub(units)
+	o.contracts = append(o.contracts, SingleOrderDetail{
+		TransactionDate: now,
+		Price:           price,
+		Units:           units,
+		FeeCurrency:     o.market.Quote,
+		Fee:             fee,
+		Total:           total,
+	})
+	if o.remaining.Sign() <= 0 {
+		o.status = OrderCompleted
+		p.unlock(o)
+	}
+
+	p.transactions = append(p.transactions, Transaction{
+		Search:          search,
+		TransferDate:    now,
+		OrderCurrency:   o.market.Base,
+		PaymentCurrency: o.market.Quote,
+		Units:           units,
+		Price:           price,
+		Amount:          total,
+		FeeCurrency:     o.market.Quote,
+		Fee:             fee,
+		OrderBalance:    coin.total,
+		PaymentBalance:  payment.total,
+	})
+}
+
+// reached 는 price 가 stop limit 주문의 감시가격에 도달했는지 확인합니다.
+func (p *PaperTrader) reached(o *paperOrder, price Decimal) bool {
+	return (o.side == Bid && price.Cmp(o.watchPrice) >= 0) || (o.side == Ask && price.Cmp(o.watchPrice) <= 0)
+}
+
+//==============================SYNC======================================
+
+// watch 는 market 의 체결 내역을 now 이후부터 반영하도록 준비합니다.
+func (p *PaperTrader) watch(market Market, now time.Time) {
+	if _, ok := p.cursors[market]; !ok {
+		p.cursors[market] = &paperCursor{date: now}
+	}
+}
+
+func (p *PaperTrader) Sync(market Market) error {
+	return p.SyncCtx(context.Background(), market)
+}
+
+// SyncCtx 는 market 의 새 체결 내역으로 대기 중인 주문을 체결합니다. 대기 중인 주문이 없으면 요청을 보내지 않습니다.
+func (p *PaperTrader) SyncCtx(ctx context.Context, market Market) error {
+	market = NewMarket(market.Base, market.Quote)
+	if !p.hasPending(market) {
+		return nil
+	}
+
+	history, err := p.source.GetTransactionHistoryCtx(ctx, market.Base, market.Quote)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].TransactionDate.Before(history[j].TransactionDate) })
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// 같은 초의 체결은 응답 순서대로 뒤에 추가되므로, cursor.date 의 체결 중 앞의 cursor.count 개는 이미 반영한 것
+	cursor := p.cursors[market]
+	pending := p.pendingOf(market)
+	skip := 0
+	for _, data := range history {
+		date := paperTradeTime(data.TransactionDate)
+		if date.Before(cursor.date) {
+			continue
+		}
+		if date.Equal(cursor.date) {
+			if skip < cursor.count {
+				skip++
+				continue
+			}
+		} else {
+			cursor.date = date
+			cursor.count = 0
+			skip = 0
+		}
+		cursor.count++
+		skip++
+
+		// 감시가격에 도달한 stop limit 주문을 먼저 지정가 주문으로 바꾸고, 체결 수량을 먼저 들어온 주문부터 나눠줌
+		left := data.UnitsTraded
+		for _, o := range pending {
+			if o.status != OrderPending || date.Before(o.created) {
+				continue
+			}
+			if !o.triggered {
+				if !p.reached(o, data.Price) {
+					continue
+				}
+				o.triggered = true
+			}
+			crossed := (o.side == Bid && data.Price.Cmp(o.price) <= 0) || (o.side == Ask && data.Price.Cmp(o.price) >= 0)
+			if !crossed || left.Sign() <= 0 {
+				continue
+			}
+			units := MinDecimal(left, o.remaining)
+			p.fill(o, o.price, units, date)
+			left = left.Sub(units)
+		}
+	}
+	return nil
+}
+
+// syncAll 은 대기 중인 주문이 있는 모든 market 을 Sync 합니다.
+func (p *PaperTrader) syncAll(ctx context.Context) error {
+	p.mutex.Lock()
+	markets := make([]Market, 0, len(p.cursors))
+	for market := range p.cursors {
+		markets = append(markets, market)
+	}
+	p.mutex.Unlock()
+
+	for _, market := range markets {
+		if err := p.SyncCtx(ctx, market); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *PaperTrader) hasPending(market Market) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.pendingOf(market)) > 0
+}
+
+// pendingOf 는 market 의 대기 중인 주문을 먼저 넣은 순서대로 반환합니다.
+func (p *PaperTrader) pendingOf(market Market) []*paperOrder {
+	var result []*paperOrder
+	for _, o := range p.sortedOrders() {
+		if o.market == market && o.status == OrderPending {
+			result = append(result, o)
+		}
+	}
+	return result
+}
+
+// paperTradeTime 은 GetTransactionHistory 의 체결 시각을 KST 로 바꿉니다. 응답의 시각은 KST 이지만 timezone 없이 해석됩니다.
+func paperTradeTime(date time.Time) time.Time {
+	if date.Location() != time.UTC {
+		return date
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), kst)
+}
+
+//==============================WALLET======================================
+
+// 가상 계정에는 입출금이 없으므로 Wallet 메소드는 ErrNotSupported 를 반환합니다.
+
+func (p *PaperTrader) GetWalletAddressCtx(ctx context.Context, orderCurrency Currency) (string, error) {
+	return "", ErrNotSupported
+}
+
+func (p *PaperTrader) WithDrawCoinCtx(ctx context.Context, orderCurrency Currency, amount Decimal, address string, destination ...interface{}) error {
+	return ErrNotSupported
+}
+
+func (p *PaperTrader) WithdrawKRWCtx(ctx context.Context, account string, price int) error {
+	return ErrNotSupported
+}
+
+var _ Exchange = (*PaperTrader)(nil)
@@ -0,0 +1,112 @@
+package gobithumb
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Bithumb 이 안내하는 초당 최대 요청 횟수입니다.
+// 초과하면 API 사용이 일시적으로 제한되므로, 기본적으로 이 값에 맞춰 요청을 늦춥니다.
+var (
+	DefaultPublicRateLimit  = RateLimit{PerSecond: 135, Burst: 135}
+	DefaultPrivateRateLimit = RateLimit{PerSecond: 15, Burst: 15}
+)
+
+// RateLimit 은 token bucket 의 초당 충전량과 최대 크기입니다. PerSecond 가 0 이하면 제한하지 않습니다.
+type RateLimit struct {
+	PerSecond float64
+	Burst     int
+}
+
+type RateLimiterStats struct {
+	Requests  int64
+	Waited    int64
+	Rejected  int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+type rateLimiter struct {
+	mutex sync.Mutex
+
+	limit    RateLimit
+	failFast bool
+
+	tokens float64
+	last   time.Time
+
+	stats RateLimiterStats
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &rateLimiter{limit: limit, tokens: float64(limit.Burst), last: time.Now()}
+}
+
+// wait 는 token 하나를 얻을 때까지 기다립니다.
+// failFast 모드에서는 기다리지 않고 ErrRateLimited 를 반환합니다.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	if r == nil || r.limit.PerSecond <= 0 {
+		return nil
+	}
+
+	r.mutex.Lock()
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.limit.PerSecond
+	if r.tokens > float64(r.limit.Burst) {
+		r.tokens = float64(r.limit.Burst)
+	}
+	r.last = now
+	r.stats.Requests++
+
+	if r.tokens >= 1 {
+		r.tokens--
+		r.mutex.Unlock()
+		return nil
+	}
+
+	delay := time.Duration((1 - r.tokens) / r.limit.PerSecond * float64(time.Second))
+	if r.failFast {
+		r.stats.Rejected++
+		r.mutex.Unlock()
+		return ErrRateLimited
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		r.stats.Rejected++
+		r.mutex.Unlock()
+		return context.DeadlineExceeded
+	}
+
+	// token 을 미리 예약하고 기다림
+	r.tokens--
+	r.stats.Waited++
+	r.stats.TotalWait += delay
+	if delay > r.stats.MaxWait {
+		r.stats.MaxWait = delay
+	}
+	r.mutex.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.mutex.Lock()
+		r.tokens++
+		r.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (r *rateLimiter) getStats() RateLimiterStats {
+	if r == nil {
+		return RateLimiterStats{}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.stats
+}
@@ -1,11 +1,8 @@
 package gobithumb
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"reflect"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -41,9 +38,17 @@ type BithumbRequester struct {
 	stopLimit      privateOrder
 	withdrawalCoin privateOrder
 	withdrawalKRW  privateOrder
+
+	orderRules  *OrderRules
+	roundOrders bool
+
+	markets *MarketRegistry
+
+	apiVersion APIVersion
+	v2         *V2Client
 }
 
-func NewBithumb(connectKey string, secretKey string) *BithumbRequester {
+func NewBithumb(connectKey string, secretKey string, options ...Option) *BithumbRequester {
 
 	bithumbRequester := BithumbRequester{}
 
@@ -74,77 +79,119 @@ func NewBithumb(connectKey string, secretKey string) *BithumbRequester {
 	bithumbRequester.withdrawalCoin = "/trade/btc_withdrawal"
 	bithumbRequester.withdrawalKRW = "/trade/krw_withdrawal"
 
+	defaultRules := DefaultOrderRules()
+	bithumbRequester.orderRules = &defaultRules
+	bithumbRequester.markets = NewMarketRegistry(&bithumbRequester, DefaultMarketRefresh)
+	bithumbRequester.apiVersion = APIVersion1
+	bithumbRequester.v2 = newV2Client(bithumbRequester.requester)
+
+	for _, option := range options {
+		option(&bithumbRequester)
+	}
+
 	return &bithumbRequester
 }
 
-func (b *BithumbRequester) publicRequest(reqUrl publicOrder, reqBody string) map[string]interface{} {
-	requestResult := b.requester.requestPublic(reqUrl, reqBody)
+// RateLimitStats 는 public, private API 요청 속도 제한의 누적 통계를 반환합니다.
+func (b *BithumbRequester) RateLimitStats() (public RateLimiterStats, private RateLimiterStats) {
+	return b.requester.publicLimiter.getStats(), b.requester.privateLimiter.getStats()
+}
+
+// Markets 는 상장된 시장 목록을 보관하는 MarketRegistry 를 반환합니다.
+func (b *BithumbRequester) Markets() *MarketRegistry {
+	return b.markets
+}
+
+func (b *BithumbRequester) publicRequest(ctx context.Context, reqUrl publicOrder, reqBody string) (map[string]interface{}, error) {
+	requestResult, err := b.requester.requestPublic(ctx, reqUrl, reqBody)
+	if err != nil {
+		return nil, err
+	}
 	var result map[string]interface{}
-	_ = json.Unmarshal(requestResult, &result)
-	return result
+	_ = decodeJSON(requestResult, &result)
+	return result, nil
+}
+
+func (b *BithumbRequester) GetTradableCoinList() ([]Currency, error) {
+	return b.GetTradableCoinListCtx(context.Background())
+}
+
+// GetTradableCoinListCtx 는 KRW 시장의 상장 목록을 새로 받아와 이름 순으로 반환합니다. 받아온 목록은 Markets() 에도 반영됩니다.
+func (b *BithumbRequester) GetTradableCoinListCtx(ctx context.Context) ([]Currency, error) {
+	if err := b.markets.refreshMarket(ctx, KRW); err != nil {
+		return nil, err
+	}
+	return b.markets.Markets(ctx, KRW)
 }
 
-func (b *BithumbRequester) GetTradableCoinList() []Currency {
-	requestResult := b.requester.requestPublic(b.ticker, "all_krw")
-	var tempResult map[string]interface{}
-	var stringResult []string
-	var result []Currency
-	_ = json.Unmarshal(requestResult, &tempResult)
-	datas := tempResult["data"].(map[string]interface{})
-	for index := range datas {
-		stringResult = append(stringResult, index)
+func (b *BithumbRequester) GetTradableMarkets(paymentCurrency Currency) ([]Market, error) {
+	return b.GetTradableMarketsCtx(context.Background(), paymentCurrency)
+}
+
+// GetTradableMarketsCtx 는 paymentCurrency (KRW, BTC) 시장의 상장 목록을 새로 받아와 반환합니다.
+func (b *BithumbRequester) GetTradableMarketsCtx(ctx context.Context, paymentCurrency Currency) ([]Market, error) {
+	paymentCurrency = Currency(strings.ToLower(string(paymentCurrency)))
+	if err := b.markets.refreshMarket(ctx, paymentCurrency); err != nil {
+		return nil, err
+	}
+	orderCurrencies, err := b.markets.Markets(ctx, paymentCurrency)
+	if err != nil {
+		return nil, err
 	}
-	sort.Strings(stringResult)
-	for _, data := range stringResult {
-		result = append(result, Currency(strings.ToLower(data)))
+	result := make([]Market, len(orderCurrencies))
+	for index, data := range orderCurrencies {
+		result[index] = NewMarket(data, paymentCurrency)
 	}
-	return result[:len(result)-1] // 마지막 하나가 date이므로, date를 제외하고 전달함
+	return result, nil
 }
 
 func (b *BithumbRequester) GetTicker(orderCurrency Currency, paymentCurrency Currency) (map[Currency]Ticker, time.Time, error) {
-	reqResult := b.publicRequest(b.ticker, string(orderCurrency)+"_"+string(paymentCurrency))
-	result := make(map[Currency]Ticker)
+	return b.GetTickerCtx(context.Background(), orderCurrency, paymentCurrency)
+}
 
-	// Error check
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetTicker failed : ", reqResult["message"].(string))
-		return result, time.Now(), errors.New(errNo)
+func (b *BithumbRequester) GetTickerCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (map[Currency]Ticker, time.Time, error) {
+	reqResult, err := b.publicRequest(ctx, b.ticker, NewMarket(orderCurrency, paymentCurrency).path())
+	if err != nil {
+		return nil, time.Time{}, err
 	}
+	result := make(map[Currency]Ticker)
 
 	// Convert data
-	datas := reqResult["data"].(map[string]interface{})
+	datas := toMap(reqResult["data"])
 
 	// Time check
-	reqTime := milliStringToTime(datas["date"].(string))
+	reqTime := milliStringToTime(toString(datas["date"]))
 	delete(datas, "date")
 
 	if orderCurrency != ALL {
 		result[orderCurrency] = newTicker(datas)
 	} else {
+		listed := make([]Currency, 0, len(datas))
 		for index, data := range datas {
-			result[Currency(index)] = newTicker(data.(map[string]interface{}))
+			result[Currency(strings.ToLower(index))] = newTicker(toMap(data))
+			listed = append(listed, Currency(index))
 		}
+		b.markets.update(Currency(strings.ToLower(string(paymentCurrency))), listed)
 	}
 	return result, reqTime, nil
 }
 
 func (b *BithumbRequester) GetOrderbook(orderCurrency Currency, paymentCurrency Currency) (map[Currency]Orderbook, time.Time, error) {
-	reqResult := b.publicRequest(b.orderbook, string(orderCurrency)+"_"+string(paymentCurrency))
-	result := make(map[Currency]Orderbook)
+	return b.GetOrderbookCtx(context.Background(), orderCurrency, paymentCurrency)
+}
 
-	// Error check
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetOrderbook failed : ", reqResult["message"].(string))
-		return result, time.Now(), errors.New(errNo)
+func (b *BithumbRequester) GetOrderbookCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (map[Currency]Orderbook, time.Time, error) {
+	reqResult, err := b.publicRequest(ctx, b.orderbook, NewMarket(orderCurrency, paymentCurrency).path())
+	if err != nil {
+		return nil, time.Time{}, err
 	}
+	result := make(map[Currency]Orderbook)
 
 	// Convert data
-	datas := reqResult["data"].(map[string]interface{})
+	datas := toMap(reqResult["data"])
 
 	// Time check
-	reqTime := milliStringToTime(datas["timestamp"].(string))
+	reqTime := milliStringToTime(toString(datas["timestamp"]))
 	delete(datas, "timestamp")
 
 	if orderCurrency != ALL {
@@ -152,64 +199,63 @@ func (b *BithumbRequester) GetOrderbook(orderCurrency Currency, paymentCurrency
 	} else {
 		delete(datas, "payment_currency")
 		for index, data := range datas {
-			result[Currency(index)] = newOrderbook(data.(map[string]interface{}))
+			result[Currency(strings.ToLower(index))] = newOrderbook(toMap(data))
 		}
 	}
 	return result, reqTime, nil
 }
 
 func (b *BithumbRequester) GetTransactionHistory(orderCurrency Currency, paymentCurrency Currency) ([]OneTransaction, error) {
-	reqResult := b.publicRequest(b.trHistory, string(orderCurrency)+"_"+string(paymentCurrency))
+	return b.GetTransactionHistoryCtx(context.Background(), orderCurrency, paymentCurrency)
+}
 
-	// Error check
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetTransactionHistory failed : ", reqResult["message"].(string))
-		return nil, errors.New(errNo)
+func (b *BithumbRequester) GetTransactionHistoryCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) ([]OneTransaction, error) {
+	reqResult, err := b.publicRequest(ctx, b.trHistory, NewMarket(orderCurrency, paymentCurrency).path())
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert data and return
-	return newTransactionHistory(reqResult["data"].([]interface{})), nil
+	return newTransactionHistory(toSlice(reqResult["data"])), nil
 }
 
 func (b *BithumbRequester) GetAssetsStatus(orderCurrency Currency) (bool, bool, error) {
-	reqResult := b.publicRequest(b.assetsStatus, string(orderCurrency))
+	return b.GetAssetsStatusCtx(context.Background(), orderCurrency)
+}
 
-	// Error check
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetAssetsStatus failed : ", reqResult["message"].(string))
-		return false, false, errors.New(errNo)
+func (b *BithumbRequester) GetAssetsStatusCtx(ctx context.Context, orderCurrency Currency) (bool, bool, error) {
+	reqResult, err := b.publicRequest(ctx, b.assetsStatus, string(orderCurrency))
+	if err != nil {
+		return false, false, err
 	}
 
 	// Convert data and return
-	datas := reqResult["data"].(map[string]interface{})
+	datas := toMap(reqResult["data"])
 	depositStatus, withdrawlStatus := false, false
-	if val, _ := fmt.Print(datas["deposit_status"]); val == 1 {
+	if toFloat(datas["deposit_status"]) == 1 {
 		depositStatus = true
 	}
-	if val2, _ := fmt.Print(datas["withdrawal_status"]); val2 == 1 {
+	if toFloat(datas["withdrawal_status"]) == 1 {
 		withdrawlStatus = true
 	}
 	return depositStatus, withdrawlStatus, nil
 }
 
 func (b *BithumbRequester) GetBTCI() (BTCI, time.Time, error) {
-	reqResult := b.publicRequest(b.btci, "")
+	return b.GetBTCICtx(context.Background())
+}
 
-	// Error check
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetBTCI failed : ", reqResult["message"].(string))
-		var noneBTCI BTCI
-		return noneBTCI, time.Now(), errors.New(errNo)
+func (b *BithumbRequester) GetBTCICtx(ctx context.Context) (BTCI, time.Time, error) {
+	reqResult, err := b.publicRequest(ctx, b.btci, "")
+	if err != nil {
+		return BTCI{}, time.Time{}, err
 	}
 
 	// Convert data
-	datas := reqResult["data"].(map[string]interface{})
+	datas := toMap(reqResult["data"])
 
 	// Time check
-	reqTime := milliStringToTime(datas["date"].(string))
+	reqTime := milliStringToTime(toString(datas["date"]))
 	delete(datas, "date")
 
 	// return result
@@ -217,127 +263,166 @@ func (b *BithumbRequester) GetBTCI() (BTCI, time.Time, error) {
 }
 
 func (b *BithumbRequester) GetCandleStick(orderCurreny Currency, paymentCurrency Currency, chartInterval TimeInterval) ([]OneCandleStick, error) {
-	body := string(orderCurreny) + "_" + string(paymentCurrency) + "/" + string(chartInterval)
-	requestResult := b.requester.requestPublic(b.candlestick, body)
-	var rawResult RawCandleStick
-	_ = json.Unmarshal(requestResult, &rawResult)
-
-	timelog(string(requestResult))
-
-	if rawResult.Status != 0 {
-		timelog("GetCandleStick failed : ", rawResult.Message)
-		var result []OneCandleStick
-		return result, errors.New(strconv.Itoa(rawResult.Status))
+	return b.GetCandleStickCtx(context.Background(), orderCurreny, paymentCurrency, chartInterval)
+}
 
+func (b *BithumbRequester) GetCandleStickCtx(ctx context.Context, orderCurreny Currency, paymentCurrency Currency, chartInterval TimeInterval) ([]OneCandleStick, error) {
+	body := NewMarket(orderCurreny, paymentCurrency).path() + "/" + string(chartInterval)
+	requestResult, err := b.requester.requestPublic(ctx, b.candlestick, body)
+	if err != nil {
+		return nil, err
 	}
+	var rawResult RawCandleStick
+	if err := decodeJSON(requestResult, &rawResult); err != nil {
+		return nil, &RequestError{Endpoint: string(b.candlestick), Err: ErrInvalidResponse}
+	}
+
 	return newCandleStick(rawResult), nil
 }
 
-func (b *BithumbRequester) privateRequest(reqUrl privateOrder, requestVal map[string]string) map[string]interface{} {
+func (b *BithumbRequester) privateRequest(ctx context.Context, reqUrl privateOrder, requestVal map[string]string) (map[string]interface{}, error) {
 	requestVal["endpoint"] = string(reqUrl)
-	reqResult := b.requester.requestPrivate(requestVal)
+	reqResult, err := b.requester.requestPrivate(ctx, requestVal)
+	if err != nil {
+		return nil, err
+	}
 	var result map[string]interface{}
-	_ = json.Unmarshal(reqResult, &result)
-	return result
+	_ = decodeJSON(reqResult, &result)
+	return result, nil
 }
 
 func (b *BithumbRequester) GetAccount(orderCurrency Currency, paymentCurrency Currency) (Account, error) {
+	return b.GetAccountCtx(context.Background(), orderCurrency, paymentCurrency)
+}
+
+func (b *BithumbRequester) GetAccountCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (Account, error) {
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
-	reqResult := b.privateRequest(b.account, passVal)
-	var result Account
-
-	// Error check
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetAccount failed : ", reqResult["message"].(string))
-		return result, errors.New(errNo)
+	reqResult, err := b.privateRequest(ctx, b.account, passVal)
+	if err != nil {
+		return Account{}, err
 	}
 
-	return newAccount(reqResult["data"].(map[string]interface{})), nil
+	return newAccount(toMap(reqResult["data"])), nil
 }
 
 func (b *BithumbRequester) GetBalance(orderCurrency Currency) (map[Currency]*Balance, error) {
-	passVal := make(map[string]string)
-	passVal["currency"] = string(orderCurrency)
-	reqResult := b.privateRequest(b.balance, passVal)
+	return b.GetBalanceCtx(context.Background(), orderCurrency)
+}
 
-	// Error check
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetBalance failed : ", reqResult["message"].(string))
-		return nil, errors.New(errNo)
-	}
+func (b *BithumbRequester) GetBalanceCtx(ctx context.Context, orderCurrency Currency) (map[Currency]*Balance, error) {
+	var result map[Currency]*Balance
+	if b.apiVersion == APIVersion2 {
+		balances, err := b.getBalanceV2(ctx, orderCurrency)
+		if err != nil {
+			return nil, err
+		}
+		result = balances
+	} else {
+		passVal := make(map[string]string)
+		passVal["currency"] = string(orderCurrency)
+		reqResult, err := b.privateRequest(ctx, b.balance, passVal)
+		if err != nil {
+			return nil, err
+		}
 
-	// Convert data
-	result := make(map[Currency]*Balance)
-	datas := reqResult["data"].(map[string]interface{})
+		// Convert data
+		result = newBalances(toMap(reqResult["data"]))
+	}
 
+	// 응답에 없더라도 요청한 currency 와 KRW, (ALL 이면) 상장된 currency 는 항상 포함
+	expected := []Currency{KRW}
 	if orderCurrency != ALL {
-		result[orderCurrency] = newBalance(datas, string(orderCurrency))
-		result[KRW] = newBalance(datas, string(KRW))
+		expected = append(expected, Currency(strings.ToLower(string(orderCurrency))))
 	} else {
-		for _, data := range COIN_ALL() {
-			result[data] = &Balance{}
+		listed, err := b.markets.Currencies(ctx)
+		if err != nil {
+			b.requester.logger.Log(LogWarn, "market registry refresh failed", LogField{"error", err.Error()})
 		}
-		for index, data := range datas {
-			coin, value := rawBalanceStringToBalance(index)
-			if value == 1 {
-				result[Currency(coin)].Total, _ = strconv.ParseFloat(data.(string), 64)
-			}
-			if value == 2 {
-				result[Currency(coin)].InUse, _ = strconv.ParseFloat(data.(string), 64)
-			}
-			if value == 3 {
-				result[Currency(coin)].Available, _ = strconv.ParseFloat(data.(string), 64)
-			}
-			if value == 4 {
-				result[Currency(coin)].XCoinLast, _ = strconv.ParseFloat(data.(string), 64)
-			}
+		expected = append(expected, listed...)
+	}
+	for _, data := range expected {
+		if _, ok := result[data]; !ok {
+			result[data] = &Balance{}
 		}
 	}
 	return result, nil
 }
 
+func (b *BithumbRequester) GetMarketBalance(market Market) (Balance, Balance, error) {
+	return b.GetMarketBalanceCtx(context.Background(), market)
+}
+
+// GetMarketBalanceCtx 는 시장의 base, quote currency 잔고를 반환합니다.
+// quote 가 KRW 가 아니면 (e.g. ETH_BTC) quote 잔고를 위해 요청을 한 번 더 보냅니다.
+func (b *BithumbRequester) GetMarketBalanceCtx(ctx context.Context, market Market) (Balance, Balance, error) {
+	market = NewMarket(market.Base, market.Quote)
+	if !market.Valid() {
+		return Balance{}, Balance{}, fmt.Errorf("%w: 잘못된 시장입니다. (%s)", ErrInvalidParameter, market)
+	}
+
+	balances, err := b.GetBalanceCtx(ctx, market.Base)
+	if err != nil {
+		return Balance{}, Balance{}, err
+	}
+	if _, ok := balances[market.Quote]; !ok {
+		quoteBalances, err := b.GetBalanceCtx(ctx, market.Quote)
+		if err != nil {
+			return Balance{}, Balance{}, err
+		}
+		balances[market.Quote] = quoteBalances[market.Quote]
+	}
+	return *balances[market.Base], *balances[market.Quote], nil
+}
+
 // TODO : Docs 쓸 때, 만약 주소가 없으면 정상 처리는 되나 아무 값도 리턴하지 않는다고 서술해야함.
 func (b *BithumbRequester) GetWalletAddress(orderCurrency Currency) (string, error) {
+	return b.GetWalletAddressCtx(context.Background(), orderCurrency)
+}
+
+func (b *BithumbRequester) GetWalletAddressCtx(ctx context.Context, orderCurrency Currency) (string, error) {
 	passVal := make(map[string]string)
 	passVal["currency"] = string(orderCurrency)
-	reqResult := b.privateRequest(b.walletAddress, passVal)
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetAccount failed : ", reqResult["message"].(string))
-		return "", errors.New(errNo)
+	reqResult, err := b.privateRequest(ctx, b.walletAddress, passVal)
+	if err != nil {
+		return "", err
 	}
 
-	return reqResult["data"].(map[string]interface{})["wallet_address"].(string), nil
+	return toString(toMap(reqResult["data"])["wallet_address"]), nil
 }
 
 func (b *BithumbRequester) GetUserTicker(orderCurrency Currency, paymentCurrency Currency) (UserTicker, error) {
+	return b.GetUserTickerCtx(context.Background(), orderCurrency, paymentCurrency)
+}
+
+func (b *BithumbRequester) GetUserTickerCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency) (UserTicker, error) {
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
-	reqResult := b.privateRequest(b.tickerUser, passVal)
-	var result UserTicker
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetAccount failed : ", reqResult["message"].(string))
-		return result, errors.New(errNo)
+	reqResult, err := b.privateRequest(ctx, b.tickerUser, passVal)
+	if err != nil {
+		return UserTicker{}, err
 	}
+	var result UserTicker
 
-	result = newUserTicker(reqResult["data"].(map[string]interface{}))
+	result = newUserTicker(toMap(reqResult["data"]))
 	return result, nil
 }
 
 // -> date에 값이 들어올 경우, 최측 하나만 사용
 func (b *BithumbRequester) GetOrder(orderCurrency Currency, paymentCurrency Currency, count int, date ...time.Time) ([]Order, error) {
+	return b.GetOrderCtx(context.Background(), orderCurrency, paymentCurrency, count, date...)
+}
+
+func (b *BithumbRequester) GetOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, count int, date ...time.Time) ([]Order, error) {
 
 	// parameter 정상 체크
 	if !(count > 0 && count < 1001) {
-		return nil, errors.New("주문의 개수는 1~1000 사이의 정수여야 합니다.")
+		return nil, fmt.Errorf("%w: 주문의 개수는 1~1000 사이의 정수여야 합니다.", ErrInvalidParameter)
+	}
+	if b.apiVersion == APIVersion2 {
+		return b.getOrderV2(ctx, orderCurrency, paymentCurrency, count, date...)
 	}
 
 	passVal := make(map[string]string)
@@ -347,45 +432,55 @@ func (b *BithumbRequester) GetOrder(orderCurrency Currency, paymentCurrency Curr
 	if len(date) > 0 {
 		passVal["after"] = strconv.FormatInt(date[0].Unix()*1000, 10)
 	}
-	reqResult := b.privateRequest(b.orders, passVal)
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetOrder failed : ", reqResult["message"].(string))
-		return nil, errors.New(errNo)
+	reqResult, err := b.privateRequest(ctx, b.orders, passVal)
+	if err != nil {
+		return nil, err
 	}
 
 	// Data parse and return val create
-	datas := reqResult["data"].([]interface{})
+	datas := toSlice(reqResult["data"])
 	var result []Order
 
 	for _, data := range datas {
-		result = append(result, newOrder(data.(map[string]interface{})))
+		result = append(result, newOrder(toMap(data)))
 	}
 
 	return result, nil
 }
 
 func (b *BithumbRequester) GetOrderDetail(orderCurrency Currency, paymentCurrency Currency, orderId string) (OrderDetail, error) {
+	return b.GetOrderDetailCtx(context.Background(), orderCurrency, paymentCurrency, orderId)
+}
+
+func (b *BithumbRequester) GetOrderDetailCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, orderId string) (OrderDetail, error) {
+	if b.apiVersion == APIVersion2 {
+		order, err := b.v2.GetOrderCtx(ctx, orderId)
+		if err != nil {
+			return OrderDetail{}, err
+		}
+		return order.orderDetail(), nil
+	}
+
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
 	passVal["order_id"] = orderId
-	reqResult := b.privateRequest(b.orderDetail, passVal)
-	var result OrderDetail
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetOrderDetail failed : ", reqResult["message"].(string))
-		return result, errors.New(errNo)
+	reqResult, err := b.privateRequest(ctx, b.orderDetail, passVal)
+	if err != nil {
+		return OrderDetail{}, err
 	}
+	var result OrderDetail
 
-	result = newOrderDetail(result, reqResult["data"].(map[string]interface{}))
+	result = newOrderDetail(result, toMap(reqResult["data"]))
 
-	return result, errors.New(errNo)
+	return result, nil
 }
 
 func (b *BithumbRequester) GetTransactions(orderCurrency Currency, paymentCurrency Currency, search SearchType, offset_count ...int) ([]Transaction, error) {
+	return b.GetTransactionsCtx(context.Background(), orderCurrency, paymentCurrency, search, offset_count...)
+}
+
+func (b *BithumbRequester) GetTransactionsCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, search SearchType, offset_count ...int) ([]Transaction, error) {
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
@@ -395,149 +490,209 @@ func (b *BithumbRequester) GetTransactions(orderCurrency Currency, paymentCurren
 		passVal["offset"] = strconv.Itoa(offset_count[0])
 		passVal["count"] = strconv.Itoa(offset_count[1])
 	}
-	reqResult := b.privateRequest(b.transactions, passVal)
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("GetTransactions failed : ", reqResult["message"].(string))
-		return nil, errors.New(errNo)
+	reqResult, err := b.privateRequest(ctx, b.transactions, passVal)
+	if err != nil {
+		return nil, err
 	}
 
 	// Convert data and input
 	var result []Transaction
-	datas := reqResult["data"].([]interface{})
+	datas := toSlice(reqResult["data"])
 	for _, data := range datas {
-		result = append(result, newTransaction(data.(map[string]interface{})))
+		result = append(result, newTransaction(toMap(data)))
 	}
 	return result, nil
 }
 
-func (b *BithumbRequester) PlaceOrder(orderCurrency Currency, paymentCurrency Currency, amount float64, price float64, order string) (string, error) {
+func (b *BithumbRequester) PlaceOrder(orderCurrency Currency, paymentCurrency Currency, amount Decimal, price Decimal, side OrderSide) (string, error) {
+	return b.PlaceOrderCtx(context.Background(), orderCurrency, paymentCurrency, amount, price, side)
+}
+
+func (b *BithumbRequester) PlaceOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal, price Decimal, side OrderSide) (string, error) {
+
+	// parameter 정상 체크
+	if !side.Valid() {
+		return "", fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, side)
+	}
+
+	amount, price, err := b.prepareLimit(paymentCurrency, amount, price, side)
+	if err != nil {
+		return "", err
+	}
+	if b.apiVersion == APIVersion2 {
+		return b.placeOrderV2(ctx, V2OrderRequest{Market: NewMarket(orderCurrency, paymentCurrency), Side: side, OrdType: V2OrderLimit, Volume: amount, Price: price})
+	}
+
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
-	passVal["units"] = strconv.FormatFloat(amount, 'f', -1, 64)
-	passVal["price"] = strconv.FormatFloat(price, 'f', -1, 64)
-	passVal["type"] = order
-	reqResult := b.privateRequest(b.place, passVal)
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("PlaceOrder failed : ", reqResult["message"].(string))
-		return "", errors.New(errNo)
+	passVal["units"] = amount.String()
+	passVal["price"] = price.String()
+	passVal["type"] = string(side)
+	reqResult, err := b.privateRequest(ctx, b.place, passVal)
+	if err != nil {
+		return "", err
 	}
 
-	return reqResult["order_id"].(string), nil
+	return toString(reqResult["order_id"]), nil
 }
 
-func (b *BithumbRequester) CancelOrder(orderCurrency Currency, paymentCurrency Currency, orderId string, order string) error {
+func (b *BithumbRequester) CancelOrder(orderCurrency Currency, paymentCurrency Currency, orderId string, side OrderSide) error {
+	return b.CancelOrderCtx(context.Background(), orderCurrency, paymentCurrency, orderId, side)
+}
+
+func (b *BithumbRequester) CancelOrderCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, orderId string, side OrderSide) error {
+
+	// parameter 정상 체크
+	if !side.Valid() {
+		return fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, side)
+	}
+	if b.apiVersion == APIVersion2 {
+		_, err := b.v2.CancelOrderCtx(ctx, orderId)
+		return err
+	}
+
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
 	passVal["order_id"] = orderId
-	passVal["type"] = order
-	reqResult := b.privateRequest(b.cancel, passVal)
+	passVal["type"] = string(side)
+	_, err := b.privateRequest(ctx, b.cancel, passVal)
+	return err
+}
 
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("CancelOrder failed : ", reqResult["message"].(string))
-		return nil
-	}
-	return errors.New(errNo)
+func (b *BithumbRequester) MarketBuy(orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error) {
+	return b.MarketBuyCtx(context.Background(), orderCurrency, paymentCurrency, amount)
 }
 
-func (b *BithumbRequester) MarketBuy(orderCurrency Currency, paymentCurrency Currency, amount float64) (string, error) {
+// MarketBuyCtx 는 API 2.0 의 시장가 매수가 수량이 아닌 총액을 받으므로, WithAPIVersion(APIVersion2) 에서도 legacy API 로 요청합니다.
+func (b *BithumbRequester) MarketBuyCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error) {
+	amount, err := b.prepareUnits(amount)
+	if err != nil {
+		return "", err
+	}
+
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
-	passVal["units"] = strconv.FormatFloat(amount, 'f', -1, 64)
-	reqResult := b.privateRequest(b.marketBuy, passVal)
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("MarketBuy failed : ", reqResult["message"].(string))
-		return "", errors.New(errNo)
+	passVal["units"] = amount.String()
+	reqResult, err := b.privateRequest(ctx, b.marketBuy, passVal)
+	if err != nil {
+		return "", err
 	}
 
-	return reqResult["order_id"].(string), nil
+	return toString(reqResult["order_id"]), nil
 }
 
-func (b *BithumbRequester) MarketSell(orderCurrency Currency, paymentCurrency Currency, amount float64) (string, error) {
+func (b *BithumbRequester) MarketSell(orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error) {
+	return b.MarketSellCtx(context.Background(), orderCurrency, paymentCurrency, amount)
+}
+
+func (b *BithumbRequester) MarketSellCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, amount Decimal) (string, error) {
+	amount, err := b.prepareUnits(amount)
+	if err != nil {
+		return "", err
+	}
+
+	if b.apiVersion == APIVersion2 {
+		return b.placeOrderV2(ctx, V2OrderRequest{Market: NewMarket(orderCurrency, paymentCurrency), Side: Ask, OrdType: V2OrderMarket, Volume: amount})
+	}
+
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
-	passVal["units"] = strconv.FormatFloat(amount, 'f', -1, 64)
-	reqResult := b.privateRequest(b.marketSell, passVal)
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("MarketSell failed : ", reqResult["message"].(string))
-		return "", errors.New(errNo)
+	passVal["units"] = amount.String()
+	reqResult, err := b.privateRequest(ctx, b.marketSell, passVal)
+	if err != nil {
+		return "", err
 	}
 
-	return reqResult["order_id"].(string), nil
+	return toString(reqResult["order_id"]), nil
+}
+
+func (b *BithumbRequester) StopLimit(orderCurrency Currency, paymentCurrency Currency, watchPrice Decimal, price Decimal, amount Decimal, side OrderSide) (string, error) {
+	return b.StopLimitCtx(context.Background(), orderCurrency, paymentCurrency, watchPrice, price, amount, side)
 }
 
-func (b *BithumbRequester) StopLimit(orderCurrency Currency, paymentCurrency Currency, watchPrice float64, price float64, amount float64, order string) (string, error) {
+func (b *BithumbRequester) StopLimitCtx(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, watchPrice Decimal, price Decimal, amount Decimal, side OrderSide) (string, error) {
+
+	// parameter 정상 체크
+	if !side.Valid() {
+		return "", fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, side)
+	}
+
+	amount, price, err := b.prepareLimit(paymentCurrency, amount, price, side)
+	if err != nil {
+		return "", err
+	}
+	watchPrice, err = b.preparePrice(paymentCurrency, watchPrice, side)
+	if err != nil {
+		return "", err
+	}
+
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
 	passVal["payment_currency"] = string(paymentCurrency)
-	passVal["watch_price"] = strconv.FormatFloat(watchPrice, 'f', -1, 64)
-	passVal["price"] = strconv.FormatFloat(price, 'f', -1, 64)
-	passVal["units"] = strconv.FormatFloat(amount, 'f', -1, 64)
-	passVal["type"] = order
-	reqResult := b.privateRequest(b.stopLimit, passVal)
-
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("StopLimit failed : ", reqResult["message"].(string))
-		return "", errors.New(errNo)
+	passVal["watch_price"] = watchPrice.String()
+	passVal["price"] = price.String()
+	passVal["units"] = amount.String()
+	passVal["type"] = string(side)
+	reqResult, err := b.privateRequest(ctx, b.stopLimit, passVal)
+	if err != nil {
+		return "", err
 	}
 
-	return reqResult["order_id"].(string), nil
+	return toString(reqResult["order_id"]), nil
 }
 
-func (b *BithumbRequester) WithDrawCoin(orderCurrency Currency, amount float64, address string, destination ...interface{}) error {
+func (b *BithumbRequester) WithDrawCoin(orderCurrency Currency, amount Decimal, address string, destination ...interface{}) error {
+	return b.WithDrawCoinCtx(context.Background(), orderCurrency, amount, address, destination...)
+}
+
+func (b *BithumbRequester) WithDrawCoinCtx(ctx context.Context, orderCurrency Currency, amount Decimal, address string, destination ...interface{}) error {
 	passVal := make(map[string]string)
 	passVal["order_currency"] = string(orderCurrency)
-	passVal["units"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	passVal["units"] = amount.String()
 	passVal["address"] = address
 
 	//destination tag 설정
 	if orderCurrency == XRP || orderCurrency == STEEM {
-		if len(destination) == 1 && reflect.TypeOf(destination) == reflect.TypeOf(1) {
-			passVal["destination"] = strconv.Itoa(destination[0].(int))
-		} else {
-			return errors.New("XRP 출금 시 destination tag(int) 를 지정해주지 않음, 또는 STEEM 출금 시 입금 메모를 지정해주지 않음")
+		if len(destination) != 1 {
+			return fmt.Errorf("%w: XRP 출금 시 destination tag(int) 를 지정해주지 않음, 또는 STEEM 출금 시 입금 메모를 지정해주지 않음", ErrInvalidParameter)
+		}
+		switch tag := destination[0].(type) {
+		case int:
+			passVal["destination"] = strconv.Itoa(tag)
+		case string:
+			passVal["destination"] = tag
+		default:
+			return fmt.Errorf("%w: XRP 출금 시 destination tag(int) 를 지정해주지 않음, 또는 STEEM 출금 시 입금 메모를 지정해주지 않음", ErrInvalidParameter)
 		}
 	}
 	if orderCurrency == XMR {
-		if len(destination) == 1 && reflect.TypeOf(destination) == reflect.TypeOf("1") {
-			passVal["destination"] = destination[0].(string)
-		} else {
-			return errors.New("XMR 출금 시 Payment ID를 지정해주지 않음")
+		paymentId, ok := "", len(destination) == 1
+		if ok {
+			paymentId, ok = destination[0].(string)
+		}
+		if !ok {
+			return fmt.Errorf("%w: XMR 출금 시 Payment ID를 지정해주지 않음", ErrInvalidParameter)
 		}
+		passVal["destination"] = paymentId
 	}
 
-	reqResult := b.privateRequest(b.withdrawalCoin, passVal)
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("WithdrawCoin failed : ", reqResult["message"].(string))
-		return errors.New(errNo)
-	}
-	return nil
+	_, err := b.privateRequest(ctx, b.withdrawalCoin, passVal)
+	return err
 }
 
 func (b *BithumbRequester) WithdrawKRW(account string, price int) error {
+	return b.WithdrawKRWCtx(context.Background(), account, price)
+}
+
+func (b *BithumbRequester) WithdrawKRWCtx(ctx context.Context, account string, price int) error {
 	passVal := make(map[string]string)
 	passVal["bank"] = "011_농협은행"
 	passVal["account"] = account
 	passVal["price"] = strconv.Itoa(price)
-	reqResult := b.privateRequest(b.withdrawalKRW, passVal)
-	errNo := reqResult["status"].(string)
-	if errNo != "0000" {
-		timelog("WithdrawKRW failed : ", reqResult["message"].(string))
-		return errors.New(errNo)
-	}
-	return nil
+	_, err := b.privateRequest(ctx, b.withdrawalKRW, passVal)
+	return err
 }
@@ -2,15 +2,19 @@ package gobithumb
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,10 +22,29 @@ type httpRequester struct {
 	connectKey string
 	secretKey  string
 
+	// API 2.0 (/v1/*) JWT 인증에 사용하는 key. 기본값은 connectKey, secretKey 와 같음
+	jwtAccessKey string
+	jwtSecretKey string
+
 	basicUrl string
 
 	publicClient  *http.Client
 	privateClient *http.Client
+
+	timeout time.Duration
+	headers http.Header
+
+	publicLimiter  *rateLimiter
+	privateLimiter *rateLimiter
+
+	retryPolicy RetryPolicy
+
+	logger Logger
+}
+
+type responseStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
 }
 
 func newHttpRequester(connectKey string, secretKey string) *httpRequester {
@@ -30,72 +53,304 @@ func newHttpRequester(connectKey string, secretKey string) *httpRequester {
 
 	httpRequester.connectKey = connectKey
 	httpRequester.secretKey = secretKey
+	httpRequester.jwtAccessKey = connectKey
+	httpRequester.jwtSecretKey = secretKey
 	httpRequester.basicUrl = "https://api.bithumb.com"
 
 	httpRequester.publicClient = &http.Client{}
 	httpRequester.privateClient = &http.Client{}
+	httpRequester.headers = http.Header{}
+	httpRequester.publicLimiter = newRateLimiter(DefaultPublicRateLimit)
+	httpRequester.privateLimiter = newRateLimiter(DefaultPrivateRateLimit)
+
+	httpRequester.retryPolicy = DefaultRetryPolicy
+
+	httpRequester.logger = NewStdLogger(os.Stdout, LogWarn)
 
 	return &httpRequester
 }
 
-func (h *httpRequester) requestPublic(order publicOrder, data string) []byte {
+func (h *httpRequester) requestPublic(ctx context.Context, order publicOrder, data string) ([]byte, error) {
 
-	request, err := http.NewRequest("GET", h.basicUrl+string(order)+"/"+data, nil)
-	if err != nil {
-		panic("Failed to create Request")
+	return h.retry(ctx, string(order), true, nil, func(ctx context.Context) ([]byte, error) {
+
+		if err := h.publicLimiter.wait(ctx); err != nil {
+			return nil, &RequestError{Endpoint: string(order), Err: err}
+		}
+
+		request, err := http.NewRequestWithContext(ctx, "GET", h.basicUrl+string(order)+"/"+data, nil)
+		if err != nil {
+			return nil, &RequestError{Endpoint: string(order), Err: err}
+		}
+		h.setHeaders(request)
+
+		return h.do(h.publicClient, request, string(order))
+	})
+}
+
+func (h *httpRequester) requestPrivate(ctx context.Context, passVal map[string]string) ([]byte, error) {
+
+	endpoint := passVal["endpoint"]
+
+	// /info/* 조회 API 만 자동으로 재시도하고, /trade/* 는 WithTradeRetry 로 허용된 경우에만 재시도함
+	var beforeResend func(ctx context.Context) (bool, error)
+	retryable := strings.HasPrefix(endpoint, "/info/")
+	if guard := tradeRetryGuard(ctx); guard != nil && strings.HasPrefix(endpoint, "/trade/") {
+		retryable = true
+		beforeResend = func(ctx context.Context) (bool, error) {
+			params := make(map[string]string, len(passVal))
+			for key, value := range passVal {
+				params[key] = value
+			}
+			return guard(ctx, endpoint, params)
+		}
 	}
 
-	response, err := h.publicClient.Do(request)
-	if err != nil {
-		panic("Failed to receive Data, check server status")
+	return h.retry(ctx, endpoint, retryable, beforeResend, func(ctx context.Context) ([]byte, error) {
+
+		if err := h.privateLimiter.wait(ctx); err != nil {
+			return nil, &RequestError{Endpoint: endpoint, Err: err}
+		}
+
+		// request body 설정
+		requestBody := url.Values{}
+		for index, data := range passVal {
+			requestBody.Set(index, data)
+		}
+		requestBodyString := requestBody.Encode()
+
+		// nonce 및 api-sign 가져오기
+		nonce := fmt.Sprint(time.Now().UnixNano() / int64(time.Millisecond))
+		apiSignVal := h.encryptData(endpoint, requestBodyString, nonce)
+
+		// request 객체 생성
+		request, err := http.NewRequestWithContext(ctx, "POST", h.basicUrl+endpoint, bytes.NewBufferString(requestBodyString))
+		if err != nil {
+			return nil, &RequestError{Endpoint: endpoint, Err: err}
+		}
+
+		h.setHeaders(request)
+		request.Header.Set("Api-Key", h.connectKey)
+		request.Header.Set("Api-Sign", apiSignVal)
+		request.Header.Set("Api-Nonce", nonce)
+		request.Header.Set("Content-type", "application/x-www-form-urlencoded")
+		request.Header.Set("Content-Length", strconv.Itoa(len(requestBodyString)))
+
+		return h.do(h.privateClient, request, endpoint)
+	})
+}
+
+// requestV2 는 API 2.0 (/v1/*) 요청을 보냅니다.
+// GET, DELETE 는 params 를 query string 으로, POST 는 같은 params 를 JSON body 로 보내며, private 이면 JWT 로 서명합니다.
+// GET 만 자동으로 재시도하고, POST, DELETE 는 WithTradeRetry 로 허용된 경우에만 재시도함
+func (h *httpRequester) requestV2(ctx context.Context, method string, endpoint string, params url.Values, private bool) ([]byte, error) {
+
+	var beforeResend func(ctx context.Context) (bool, error)
+	retryable := method == http.MethodGet
+	if guard := tradeRetryGuard(ctx); guard != nil && private && !retryable {
+		retryable = true
+		beforeResend = func(ctx context.Context) (bool, error) {
+			passVal := make(map[string]string, len(params))
+			for key := range params {
+				passVal[key] = params.Get(key)
+			}
+			return guard(ctx, endpoint, passVal)
+		}
 	}
 
-	byteResponse, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		timelog("Failed to receive Data")
+	limiter := h.publicLimiter
+	client := h.publicClient
+	if private {
+		limiter = h.privateLimiter
+		client = h.privateClient
 	}
-	return byteResponse
+
+	return h.retry(ctx, endpoint, retryable, beforeResend, func(ctx context.Context) ([]byte, error) {
+
+		if err := limiter.wait(ctx); err != nil {
+			return nil, &RequestError{Endpoint: endpoint, Err: err}
+		}
+
+		// request 객체 생성
+		query := params.Encode()
+		var request *http.Request
+		var err error
+		if method == http.MethodPost {
+			requestBody := make(map[string]string, len(params))
+			for key := range params {
+				requestBody[key] = params.Get(key)
+			}
+			rawBody, _ := json.Marshal(requestBody)
+			request, err = http.NewRequestWithContext(ctx, method, h.basicUrl+endpoint, bytes.NewReader(rawBody))
+			if err == nil {
+				request.Header.Set("Content-Type", "application/json")
+			}
+		} else {
+			requestUrl := h.basicUrl + endpoint
+			if query != "" {
+				requestUrl += "?" + query
+			}
+			request, err = http.NewRequestWithContext(ctx, method, requestUrl, nil)
+		}
+		if err != nil {
+			return nil, &RequestError{Endpoint: endpoint, Err: err}
+		}
+		h.setHeaders(request)
+		request.Header.Set("Accept", "application/json")
+
+		if private {
+			token, err := jwtToken(h.jwtAccessKey, h.jwtSecretKey, query, time.Now())
+			if err != nil {
+				return nil, &RequestError{Endpoint: endpoint, Err: err}
+			}
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		return h.doV2(client, request, endpoint)
+	})
 }
 
-func (h *httpRequester) requestPrivate(passVal map[string]string) []byte {
+// retry 는 attempt 를 retryPolicy 에 따라 반복 실행합니다.
+// retryable 이 false 이거나 일시적인 오류가 아니면 바로 결과를 반환합니다.
+// beforeResend 가 있으면 다시 보내기 전에 호출하고, false 를 반환하면 재시도를 멈춥니다.
+func (h *httpRequester) retry(ctx context.Context, endpoint string, retryable bool, beforeResend func(ctx context.Context) (bool, error), attempt func(ctx context.Context) ([]byte, error)) ([]byte, error) {
 
-	// request body 설정
-	requestBody := url.Values{}
-	for index, data := range passVal {
-		requestBody.Set(index, data)
+	maxAttempts := h.retryPolicy.MaxAttempts
+	if !retryable || maxAttempts < 1 {
+		maxAttempts = 1
 	}
-	requestBodyString := requestBody.Encode()
 
-	// nonce 및 api-sign 가져오기
-	nonce := fmt.Sprint(time.Now().UnixNano() / int64(time.Millisecond))
-	apiSignVal := h.encryptData(passVal["endpoint"], requestBodyString, nonce)
+	var lastErr error
+	for tried := 1; ; tried++ {
+		attemptCtx, cancel := h.withTimeout(ctx)
+		result, err := attempt(attemptCtx)
+		cancel()
+		if err == nil || tried >= maxAttempts || ctx.Err() != nil || !isTemporary(err) {
+			return result, err
+		}
+		lastErr = err
+
+		delay := h.retryPolicy.backoff(tried)
+		h.logger.Log(LogInfo, "retrying request", LogField{"endpoint", endpoint}, LogField{"attempt", tried}, LogField{"delay", delay}, LogField{"error", err})
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			// 기다리는 중에 ctx 가 끝나면 errors.Is 로 확인할 수 있도록 ctx.Err() 를 감싸고, 마지막 오류는 메시지로 남김
+			timer.Stop()
+			return nil, &RequestError{Endpoint: endpoint, Err: fmt.Errorf("%w (마지막 오류: %v)", ctx.Err(), lastErr)}
+		}
+
+		if beforeResend != nil {
+			resend, guardErr := beforeResend(ctx)
+			if guardErr != nil || !resend {
+				return nil, lastErr
+			}
+		}
+	}
+}
+
+func (h *httpRequester) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if h.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, h.timeout)
+}
+
+func (h *httpRequester) setHeaders(request *http.Request) {
+	for key, values := range h.headers {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+}
 
-	// request 객체 생성
-	request, err := http.NewRequest("POST", h.basicUrl+passVal["endpoint"], bytes.NewBufferString(requestBodyString))
+// do 는 요청을 보내고 응답의 status 를 확인합니다.
+// status 가 "0000" 이 아니면 *APIError 를, 응답을 받지 못하면 *RequestError 를 반환합니다.
+func (h *httpRequester) do(client *http.Client, request *http.Request, endpoint string) ([]byte, error) {
+
+	start := time.Now()
+	response, err := client.Do(request)
 	if err != nil {
-		panic("Failed to create Request")
+		h.logger.Log(LogError, "request failed", LogField{"endpoint", endpoint}, LogField{"latency", time.Since(start)}, LogField{"error", err})
+		return nil, &RequestError{Endpoint: endpoint, Err: err}
+	}
+	defer response.Body.Close()
+
+	byteResponse, err := ioutil.ReadAll(response.Body)
+	latency := time.Since(start)
+	if err != nil {
+		h.logger.Log(LogError, "failed to read response", LogField{"endpoint", endpoint}, LogField{"latency", latency}, LogField{"error", err})
+		return nil, &RequestError{Endpoint: endpoint, Err: err}
+	}
+
+	// Error check
+	var status responseStatus
+	if err := json.Unmarshal(byteResponse, &status); err != nil || status.Status == "" {
+		if response.StatusCode != http.StatusOK {
+			h.logger.Log(LogWarn, "request rejected", LogField{"endpoint", endpoint}, LogField{"http_status", response.StatusCode}, LogField{"latency", latency})
+			return nil, &APIError{HTTPStatus: response.StatusCode, Message: http.StatusText(response.StatusCode), Endpoint: endpoint}
+		}
+		h.logger.Log(LogError, "invalid response", LogField{"endpoint", endpoint}, LogField{"http_status", response.StatusCode}, LogField{"latency", latency})
+		return nil, &RequestError{Endpoint: endpoint, Err: ErrInvalidResponse}
 	}
+	if status.Status != statusOK || response.StatusCode != http.StatusOK {
+		h.logger.Log(LogWarn, "request rejected", LogField{"endpoint", endpoint}, LogField{"http_status", response.StatusCode},
+			LogField{"status", status.Status}, LogField{"message", status.Message}, LogField{"latency", latency})
+		return nil, &APIError{HTTPStatus: response.StatusCode, Status: status.Status, Message: status.Message, Endpoint: endpoint}
+	}
+
+	h.logger.Log(LogDebug, "request done", LogField{"endpoint", endpoint}, LogField{"http_status", response.StatusCode}, LogField{"latency", latency})
+	return byteResponse, nil
+}
 
-	request.Header.Add("Api-Key", h.connectKey)
-	request.Header.Add("Api-Sign", apiSignVal)
-	request.Header.Add("Api-Nonce", nonce)
-	request.Header.Add("Content-type", "application/x-www-form-urlencoded")
-	request.Header.Add("Content-Length", strconv.Itoa(len(requestBodyString)))
+// doV2 는 API 2.0 요청을 보내고 HTTP status 를 확인합니다.
+// 2xx 가 아니면 {"error":{"name":..., "message":...}} 응답을 *APIError 로 바꿔 반환합니다. (Status 에 name 이 들어감)
+func (h *httpRequester) doV2(client *http.Client, request *http.Request, endpoint string) ([]byte, error) {
 
-	response, err := h.privateClient.Do(request)
+	start := time.Now()
+	response, err := client.Do(request)
 	if err != nil {
-		panic("Failed to receive Data, check server stauts")
+		h.logger.Log(LogError, "request failed", LogField{"endpoint", endpoint}, LogField{"latency", time.Since(start)}, LogField{"error", err})
+		return nil, &RequestError{Endpoint: endpoint, Err: err}
 	}
+	defer response.Body.Close()
 
 	byteResponse, err := ioutil.ReadAll(response.Body)
+	latency := time.Since(start)
 	if err != nil {
-		panic("Failed to receive Data")
+		h.logger.Log(LogError, "failed to read response", LogField{"endpoint", endpoint}, LogField{"latency", latency}, LogField{"error", err})
+		return nil, &RequestError{Endpoint: endpoint, Err: err}
 	}
-	return byteResponse
+
+	// Error check
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		var rawError struct {
+			Error struct {
+				Name    interface{} `json:"name"`
+				Message string      `json:"message"`
+			} `json:"error"`
+		}
+		apiError := &APIError{HTTPStatus: response.StatusCode, Message: http.StatusText(response.StatusCode), Endpoint: endpoint}
+		if err := json.Unmarshal(byteResponse, &rawError); err == nil && rawError.Error.Name != nil {
+			apiError.Status = toString(rawError.Error.Name)
+			apiError.Message = rawError.Error.Message
+		}
+		h.logger.Log(LogWarn, "request rejected", LogField{"endpoint", endpoint}, LogField{"http_status", response.StatusCode},
+			LogField{"status", apiError.Status}, LogField{"message", apiError.Message}, LogField{"latency", latency})
+		return nil, apiError
+	}
+	if !json.Valid(byteResponse) {
+		h.logger.Log(LogError, "invalid response", LogField{"endpoint", endpoint}, LogField{"http_status", response.StatusCode}, LogField{"latency", latency})
+		return nil, &RequestError{Endpoint: endpoint, Err: ErrInvalidResponse}
+	}
+
+	h.logger.Log(LogDebug, "request done", LogField{"endpoint", endpoint}, LogField{"http_status", response.StatusCode}, LogField{"latency", latency})
+	return byteResponse, nil
 }
 
 func (h *httpRequester) encryptData(endpoint string, body string, nonce string) string {
-	reqRawString := endpoint + string(0) + body + string(0) + nonce
+	reqRawString := endpoint + "\x00" + body + "\x00" + nonce
 
 	hmacParsed := hmac.New(sha512.New, []byte(h.secretKey))
 	hmacParsed.Write([]byte(reqRawString))
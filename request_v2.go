@@ -0,0 +1,310 @@
+package gobithumb
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIVersion 은 BithumbRequester 의 계정, 주문 메소드가 사용할 API 입니다.
+// APIVersion2 를 사용하면 Bithumb API 2.0 (JWT 인증, /v1/*) 으로 요청합니다.
+type APIVersion int
+
+const (
+	APIVersion1 APIVersion = 1
+	APIVersion2 APIVersion = 2
+)
+
+type v2Order string
+
+// V2Client 는 Bithumb API 2.0 (/v1/*) 을 직접 사용합니다. BithumbRequester.V2() 로 얻으며, 요청 제한, 재시도, 로그 설정을 공유합니다.
+type V2Client struct {
+	requester *httpRequester
+
+	marketAll v2Order
+	candles   v2Order
+
+	accounts v2Order
+	orders   v2Order
+	order    v2Order
+}
+
+func newV2Client(requester *httpRequester) *V2Client {
+
+	v2Client := V2Client{}
+
+	v2Client.requester = requester
+
+	// init public API address
+	v2Client.marketAll = "/v1/market/all"
+	v2Client.candles = "/v1/candles/"
+
+	// init private API address
+	v2Client.accounts = "/v1/accounts"
+	v2Client.orders = "/v1/orders"
+	v2Client.order = "/v1/order"
+
+	return &v2Client
+}
+
+// V2 는 같은 설정을 사용하는 API 2.0 client 를 반환합니다.
+func (b *BithumbRequester) V2() *V2Client {
+	return b.v2
+}
+
+func (v *V2Client) request(ctx context.Context, method string, reqUrl v2Order, params url.Values, private bool) (interface{}, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	reqResult, err := v.requester.requestV2(ctx, method, string(reqUrl), params, private)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	_ = decodeJSON(reqResult, &result)
+	return result, nil
+}
+
+//==============================V2 MARKET DATA======================================
+
+func (v *V2Client) GetMarkets() ([]V2Market, error) {
+	return v.GetMarketsCtx(context.Background())
+}
+
+// GetMarketsCtx 는 상장된 모든 시장과 투자유의 여부를 반환합니다.
+func (v *V2Client) GetMarketsCtx(ctx context.Context) ([]V2Market, error) {
+	params := url.Values{}
+	params.Set("isDetails", "true")
+	reqResult, err := v.request(ctx, http.MethodGet, v.marketAll, params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []V2Market
+	for _, data := range toSlice(reqResult) {
+		result = append(result, newV2Market(toMap(data)))
+	}
+	return result, nil
+}
+
+func (v *V2Client) GetCandles(market Market, unit V2CandleUnit, count int, to ...time.Time) ([]V2Candle, error) {
+	return v.GetCandlesCtx(context.Background(), market, unit, count, to...)
+}
+
+// GetCandlesCtx 는 최근 캔들부터 count 개를 반환합니다. to 를 지정하면 그 시각 이전의 캔들만 반환합니다.
+func (v *V2Client) GetCandlesCtx(ctx context.Context, market Market, unit V2CandleUnit, count int, to ...time.Time) ([]V2Candle, error) {
+
+	// parameter 정상 체크
+	if !unit.Valid() {
+		return nil, fmt.Errorf("%w: 지원하지 않는 캔들 단위입니다. (%q)", ErrInvalidParameter, unit)
+	}
+	if !(count > 0 && count < 201) {
+		return nil, fmt.Errorf("%w: 캔들의 개수는 1~200 사이의 정수여야 합니다.", ErrInvalidParameter)
+	}
+
+	params := url.Values{}
+	params.Set("market", market.v2ID())
+	params.Set("count", strconv.Itoa(count))
+	if len(to) > 0 {
+		params.Set("to", to[0].UTC().Format("2006-01-02T15:04:05Z"))
+	}
+	reqResult, err := v.request(ctx, http.MethodGet, v.candles+v2Order(unit), params, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []V2Candle
+	for _, data := range toSlice(reqResult) {
+		result = append(result, newV2Candle(toMap(data)))
+	}
+	return result, nil
+}
+
+//==============================V2 ACCOUNT======================================
+
+func (v *V2Client) GetAccounts() ([]V2Account, error) {
+	return v.GetAccountsCtx(context.Background())
+}
+
+// GetAccountsCtx 는 잔고가 있는 모든 currency 의 잔고를 반환합니다.
+func (v *V2Client) GetAccountsCtx(ctx context.Context) ([]V2Account, error) {
+	reqResult, err := v.request(ctx, http.MethodGet, v.accounts, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []V2Account
+	for _, data := range toSlice(reqResult) {
+		result = append(result, newV2Account(toMap(data)))
+	}
+	return result, nil
+}
+
+//==============================V2 ORDER======================================
+
+func (v *V2Client) GetOrders(market Market, state V2OrderState, page int, limit int) ([]V2Order, error) {
+	return v.GetOrdersCtx(context.Background(), market, state, page, limit)
+}
+
+// GetOrdersCtx 는 market 의 state 상태인 주문을 최근 주문부터 반환합니다. page 는 1 부터 시작합니다.
+func (v *V2Client) GetOrdersCtx(ctx context.Context, market Market, state V2OrderState, page int, limit int) ([]V2Order, error) {
+
+	// parameter 정상 체크
+	if page < 1 {
+		return nil, fmt.Errorf("%w: page 는 1 이상이어야 합니다.", ErrInvalidParameter)
+	}
+	if !(limit > 0 && limit < 101) {
+		return nil, fmt.Errorf("%w: 주문의 개수는 1~100 사이의 정수여야 합니다.", ErrInvalidParameter)
+	}
+
+	params := url.Values{}
+	params.Set("market", market.v2ID())
+	params.Set("state", string(state))
+	params.Set("page", strconv.Itoa(page))
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("order_by", "desc")
+	reqResult, err := v.request(ctx, http.MethodGet, v.orders, params, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []V2Order
+	for _, data := range toSlice(reqResult) {
+		result = append(result, newV2Order(toMap(data)))
+	}
+	return result, nil
+}
+
+func (v *V2Client) GetOrder(uuid string) (V2Order, error) {
+	return v.GetOrderCtx(context.Background(), uuid)
+}
+
+// GetOrderCtx 는 주문 하나와 체결 내역을 반환합니다.
+func (v *V2Client) GetOrderCtx(ctx context.Context, uuid string) (V2Order, error) {
+	params := url.Values{}
+	params.Set("uuid", uuid)
+	reqResult, err := v.request(ctx, http.MethodGet, v.order, params, true)
+	if err != nil {
+		return V2Order{}, err
+	}
+	return newV2Order(toMap(reqResult)), nil
+}
+
+func (v *V2Client) PlaceOrder(order V2OrderRequest) (V2Order, error) {
+	return v.PlaceOrderCtx(context.Background(), order)
+}
+
+// PlaceOrderCtx 는 주문을 넣습니다. 호가 단위, 최소 주문 금액은 확인하지 않으므로 필요하면 OrderRules 로 먼저 확인하세요.
+func (v *V2Client) PlaceOrderCtx(ctx context.Context, order V2OrderRequest) (V2Order, error) {
+
+	// parameter 정상 체크
+	if !order.Side.Valid() {
+		return V2Order{}, fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, order.Side)
+	}
+	params := url.Values{}
+	params.Set("market", order.Market.v2ID())
+	params.Set("side", string(order.Side))
+	params.Set("ord_type", string(order.OrdType))
+	switch order.OrdType {
+	case V2OrderLimit:
+		if order.Volume.Sign() <= 0 || order.Price.Sign() <= 0 {
+			return V2Order{}, fmt.Errorf("%w: 지정가 주문은 수량과 가격이 필요합니다.", ErrInvalidParameter)
+		}
+		params.Set("volume", order.Volume.String())
+		params.Set("price", order.Price.String())
+	case V2OrderPrice:
+		if order.Side != Bid || order.Price.Sign() <= 0 {
+			return V2Order{}, fmt.Errorf("%w: 시장가 매수 주문은 bid 방향과 총액이 필요합니다.", ErrInvalidParameter)
+		}
+		params.Set("price", order.Price.String())
+	case V2OrderMarket:
+		if order.Side != Ask || order.Volume.Sign() <= 0 {
+			return V2Order{}, fmt.Errorf("%w: 시장가 매도 주문은 ask 방향과 수량이 필요합니다.", ErrInvalidParameter)
+		}
+		params.Set("volume", order.Volume.String())
+	default:
+		return V2Order{}, fmt.Errorf("%w: 지원하지 않는 주문 방식입니다. (%q)", ErrInvalidParameter, order.OrdType)
+	}
+
+	reqResult, err := v.request(ctx, http.MethodPost, v.orders, params, true)
+	if err != nil {
+		return V2Order{}, err
+	}
+	return newV2Order(toMap(reqResult)), nil
+}
+
+func (v *V2Client) CancelOrder(uuid string) (V2Order, error) {
+	return v.CancelOrderCtx(context.Background(), uuid)
+}
+
+// CancelOrderCtx 는 주문을 취소하고, 취소 요청이 접수된 주문을 반환합니다.
+func (v *V2Client) CancelOrderCtx(ctx context.Context, uuid string) (V2Order, error) {
+	params := url.Values{}
+	params.Set("uuid", uuid)
+	reqResult, err := v.request(ctx, http.MethodDelete, v.order, params, true)
+	if err != nil {
+		return V2Order{}, err
+	}
+	return newV2Order(toMap(reqResult)), nil
+}
+
+//==============================LEGACY ROUTING======================================
+
+// 아래 메소드는 WithAPIVersion(APIVersion2) 일 때 BithumbRequester 의 메소드가 API 2.0 으로 요청하도록 결과를 legacy 타입으로 바꿉니다.
+
+func (b *BithumbRequester) getBalanceV2(ctx context.Context, orderCurrency Currency) (map[Currency]*Balance, error) {
+	accounts, err := b.v2.GetAccountsCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// legacy API 처럼 ALL 이 아니면 요청한 currency 와 KRW 만 반환
+	orderCurrency = Currency(strings.ToLower(string(orderCurrency)))
+	result := make(map[Currency]*Balance)
+	for _, data := range accounts {
+		if orderCurrency == ALL || data.Currency == orderCurrency || data.Currency == KRW {
+			result[data.Currency] = data.balance()
+		}
+	}
+	return result, nil
+}
+
+// getOrderV2 는 /v1/orders 가 한 번에 100개까지만 반환하므로 count 를 채울 때까지 다음 page 를 요청합니다.
+// page 의 위치는 limit 으로 정해지므로, 모든 page 를 같은 크기로 요청하고 마지막에 count 개로 자릅니다.
+func (b *BithumbRequester) getOrderV2(ctx context.Context, orderCurrency Currency, paymentCurrency Currency, count int, date ...time.Time) ([]Order, error) {
+	const pageLimit = 100
+	market := NewMarket(orderCurrency, paymentCurrency)
+
+	var result []Order
+	for page := 1; len(result) < count; page++ {
+		orders, err := b.v2.GetOrdersCtx(ctx, market, V2Wait, page, pageLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, data := range orders {
+			if len(date) > 0 && data.CreatedAt.Before(date[0]) {
+				continue
+			}
+			result = append(result, data.order())
+		}
+		if len(orders) < pageLimit {
+			break
+		}
+	}
+	if len(result) > count {
+		result = result[:count]
+	}
+	return result, nil
+}
+
+func (b *BithumbRequester) placeOrderV2(ctx context.Context, order V2OrderRequest) (string, error) {
+	result, err := b.v2.PlaceOrderCtx(ctx, order)
+	if err != nil {
+		return "", err
+	}
+	return result.UUID, nil
+}
@@ -0,0 +1,146 @@
+package resample
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+type unit int
+
+const (
+	minute unit = iota
+	hour
+	day
+	week
+	month
+)
+
+// Interval 은 캔들 하나의 길이입니다. Minutes, Hours, Days, Weeks, Months 또는 ParseInterval 로 만듭니다.
+type Interval struct {
+	unit  unit
+	count int
+}
+
+func Minutes(count int) Interval {
+	return Interval{unit: minute, count: count}
+}
+
+func Hours(count int) Interval {
+	return Interval{unit: hour, count: count}
+}
+
+func Days(count int) Interval {
+	return Interval{unit: day, count: count}
+}
+
+// Weeks 의 한 주는 월요일 0시에 시작합니다.
+func Weeks(count int) Interval {
+	return Interval{unit: week, count: count}
+}
+
+// Months 는 달력 기준 한 달이며, 매달 1일 0시에 시작합니다.
+func Months(count int) Interval {
+	return Interval{unit: month, count: count}
+}
+
+var unitSuffix = map[unit]string{minute: "m", hour: "h", day: "d", week: "w", month: "M"}
+
+// ParseInterval 은 "30m", "4h", "1d", "1w", "1M" 형태의 문자열을 Interval 로 바꿉니다. 분은 m, 달은 M 입니다.
+// Bithumb 의 "24h" 도 그대로 사용할 수 있습니다.
+func ParseInterval(raw string) (Interval, error) {
+	raw = strings.TrimSpace(raw)
+	if len(raw) >= 2 {
+		count, err := strconv.Atoi(raw[:len(raw)-1])
+		for key, suffix := range unitSuffix {
+			if err == nil && count > 0 && raw[len(raw)-1:] == suffix {
+				return Interval{unit: key, count: count}, nil
+			}
+		}
+	}
+	return Interval{}, fmt.Errorf("%w: 잘못된 캔들 간격입니다. (%q)", b.ErrInvalidParameter, raw)
+}
+
+// FromTimeInterval 은 GetCandleStick 의 TimeInterval 을 Interval 로 바꿉니다.
+func FromTimeInterval(interval b.TimeInterval) (Interval, error) {
+	return ParseInterval(string(interval))
+}
+
+func (i Interval) String() string {
+	return strconv.Itoa(i.count) + unitSuffix[i.unit]
+}
+
+// Valid 는 길이가 1 이상인지 확인합니다.
+func (i Interval) Valid() bool {
+	_, ok := unitSuffix[i.unit]
+	return ok && i.count > 0
+}
+
+// fixed 는 달력과 상관없이 길이가 고정된 간격의 길이입니다. 달 단위는 0 입니다.
+func (i Interval) fixed() time.Duration {
+	switch i.unit {
+	case minute:
+		return time.Duration(i.count) * time.Minute
+	case hour:
+		return time.Duration(i.count) * time.Hour
+	case day:
+		return time.Duration(i.count) * 24 * time.Hour
+	case week:
+		return time.Duration(i.count) * 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// mondayEpoch 는 주 단위 간격의 기준이 되는 월요일입니다.
+var mondayEpoch = time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC)
+
+// Start 는 date 가 속한 캔들의 시작 시각을 location 기준으로 반환합니다.
+// 달력 기준인 1일, 1주, 1달이 아니면 1970년 1월 1일 (주 단위는 1970년 1월 5일 월요일) 부터 간격을 셉니다.
+// 따라서 하루를 나누어떨어지게 하는 간격 (e.g. 2h, 4h) 은 항상 location 의 0시에 맞춰집니다.
+func (i Interval) Start(date time.Time, location *time.Location) time.Time {
+	local := date.In(location)
+	if i.unit == month {
+		months := (local.Year()-1970)*12 + int(local.Month()) - 1
+		months -= floorMod(months, i.count)
+		return time.Date(1970+months/12, time.Month(months%12+1), 1, 0, 0, 0, 0, location)
+	}
+
+	// 고정 길이 간격은 location 의 wall clock 을 UTC 로 놓고 계산한 뒤 되돌림
+	wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(), local.Nanosecond(), time.UTC)
+	epoch := time.Unix(0, 0).UTC()
+	if i.unit == week {
+		epoch = mondayEpoch
+	}
+	size := i.fixed()
+	offset := wall.Sub(epoch)
+	start := epoch.Add(offset - time.Duration(floorMod64(int64(offset), int64(size))))
+	return time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, location)
+}
+
+// Next 는 start 에서 시작하는 캔들 다음 캔들의 시작 시각입니다.
+func (i Interval) Next(start time.Time) time.Time {
+	switch i.unit {
+	case day:
+		return start.AddDate(0, 0, i.count)
+	case week:
+		return start.AddDate(0, 0, 7*i.count)
+	case month:
+		return start.AddDate(0, i.count, 0)
+	}
+	return start.Add(i.fixed())
+}
+
+func floorMod(value int, size int) int {
+	return int(floorMod64(int64(value), int64(size)))
+}
+
+func floorMod64(value int64, size int64) int64 {
+	result := value % size
+	if result < 0 {
+		result += size
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package resample
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Interval
+	}{
+		{"30m", Minutes(30)},
+		{"4h", Hours(4)},
+		{"24h", Hours(24)},
+		{"1d", Days(1)},
+		{"1w", Weeks(1)},
+		{"1M", Months(1)},
+	}
+	for _, test := range tests {
+		result, err := ParseInterval(test.raw)
+		if err != nil || result != test.want {
+			t.Errorf("ParseInterval(%q) = %s, %v, want %s", test.raw, result, err, test.want)
+		}
+	}
+	for _, raw := range []string{"", "m", "0h", "-1d", "1y", "1.5h"} {
+		if _, err := ParseInterval(raw); !errors.Is(err, b.ErrInvalidParameter) {
+			t.Errorf("ParseInterval(%q) error = %v, want ErrInvalidParameter", raw, err)
+		}
+	}
+}
+
+func TestIntervalStart(t *testing.T) {
+	utc := func(month time.Month, day, hour, minute, second int) time.Time {
+		return time.Date(2026, month, day, hour, minute, second, 0, time.UTC)
+	}
+	kst := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, KST)
+	}
+	tests := []struct {
+		interval Interval
+		date     time.Time
+		location *time.Location
+		want     time.Time
+	}{
+		// KST 0시는 UTC 15시
+		{Days(1), utc(1, 31, 14, 59, 59), KST, kst(2026, 1, 31, 0)},
+		{Days(1), utc(1, 31, 15, 0, 0), KST, kst(2026, 2, 1, 0)},
+		{Days(1), utc(1, 31, 15, 0, 0), time.UTC, utc(1, 31, 0, 0, 0)},
+		{Hours(4), utc(1, 31, 14, 30, 0), KST, kst(2026, 1, 31, 20)},
+		{Hours(4), utc(1, 31, 15, 30, 0), KST, kst(2026, 2, 1, 0)},
+		{Hours(4), utc(1, 31, 23, 59, 59), KST, kst(2026, 2, 1, 8)},
+		{Minutes(30), utc(1, 31, 15, 29, 59), KST, kst(2026, 2, 1, 0)},
+		// 2026년 1월 5일이 월요일
+		{Weeks(1), utc(1, 4, 14, 59, 59), KST, kst(2025, 12, 29, 0)},
+		{Weeks(1), utc(1, 4, 15, 0, 0), KST, kst(2026, 1, 5, 0)},
+		{Weeks(1), utc(1, 11, 14, 59, 59), KST, kst(2026, 1, 5, 0)},
+		{Weeks(1), utc(1, 5, 0, 0, 0), time.UTC, utc(1, 5, 0, 0, 0)},
+		// 달은 길이와 상관없이 KST 1일 0시에 시작
+		{Months(1), utc(2, 28, 14, 59, 59), KST, kst(2026, 2, 1, 0)},
+		{Months(1), utc(2, 28, 15, 0, 0), KST, kst(2026, 3, 1, 0)},
+		{Months(1), time.Date(2025, 12, 31, 15, 0, 0, 0, time.UTC), KST, kst(2026, 1, 1, 0)},
+		{Months(1), time.Date(2025, 12, 31, 14, 59, 59, 0, time.UTC), KST, kst(2025, 12, 1, 0)},
+		// 1970년 1월부터 세므로 3M 은 1, 4, 7, 10월에 시작
+		{Months(3), utc(5, 10, 0, 0, 0), KST, kst(2026, 4, 1, 0)},
+		{Months(3), utc(3, 31, 15, 0, 0), KST, kst(2026, 4, 1, 0)},
+		{Months(3), utc(3, 31, 14, 59, 59), KST, kst(2026, 1, 1, 0)},
+	}
+	for _, test := range tests {
+		result := test.interval.Start(test.date, test.location)
+		if !result.Equal(test.want) || result.Location() != test.location {
+			t.Errorf("%s start of %s in %s = %s, want %s", test.interval, test.date, test.location, result, test.want.In(test.location))
+		}
+	}
+}
+
+func TestIntervalNext(t *testing.T) {
+	tests := []struct {
+		interval Interval
+		start    time.Time
+		want     time.Time
+	}{
+		{Hours(4), time.Date(2026, 1, 31, 20, 0, 0, 0, KST), time.Date(2026, 2, 1, 0, 0, 0, 0, KST)},
+		{Weeks(1), time.Date(2025, 12, 29, 0, 0, 0, 0, KST), time.Date(2026, 1, 5, 0, 0, 0, 0, KST)},
+		{Months(1), time.Date(2026, 1, 1, 0, 0, 0, 0, KST), time.Date(2026, 2, 1, 0, 0, 0, 0, KST)},
+		{Months(1), time.Date(2026, 2, 1, 0, 0, 0, 0, KST), time.Date(2026, 3, 1, 0, 0, 0, 0, KST)},
+		{Months(3), time.Date(2025, 10, 1, 0, 0, 0, 0, KST), time.Date(2026, 1, 1, 0, 0, 0, 0, KST)},
+	}
+	for _, test := range tests {
+		if result := test.interval.Next(test.start); !result.Equal(test.want) {
+			t.Errorf("%s next of %s = %s, want %s", test.interval, test.start, result, test.want)
+		}
+	}
+}
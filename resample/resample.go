@@ -0,0 +1,141 @@
+// Package resample 은 캔들을 다른 간격 (e.g. 2h, 4h, 1w, 1M) 으로 다시 묶고, 빠진 봉을 채우고, 체결 내역으로 캔들을 만듭니다.
+//
+// GetCandleStick 은 정해진 TimeInterval (1m ~ 24h) 만 지원하므로, 더 작은 간격의 캔들을 받아 원하는 간격으로 묶어 사용합니다.
+// 캔들의 시작 시각은 기본적으로 KST 기준으로 맞추며, WithLocation 으로 바꿀 수 있습니다.
+//
+//	candles, _ := client.GetCandleStick(gobithumb.BTC, gobithumb.KRW, gobithumb.Hour1)
+//	fourHours, _ := resample.Resample(candles, resample.Hours(4), resample.WithFillGaps())
+package resample
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+// KST 는 기본 기준 시간대입니다.
+var KST = time.FixedZone("KST", 9*60*60)
+
+type config struct {
+	location *time.Location
+	fillGaps bool
+}
+
+// Option 은 Resample, FillGaps, FromTrades 의 설정을 바꿉니다.
+type Option func(*config)
+
+// WithLocation 은 캔들의 시작 시각을 맞출 시간대를 정합니다. (기본값 KST)
+func WithLocation(location *time.Location) Option {
+	return func(c *config) {
+		c.location = location
+	}
+}
+
+// WithFillGaps 는 거래가 없어 빠진 봉을 직전 종가로 된 거래량 0 의 봉으로 채웁니다.
+func WithFillGaps() Option {
+	return func(c *config) {
+		c.fillGaps = true
+	}
+}
+
+func newConfig(interval Interval, options []Option) (*config, error) {
+	if !interval.Valid() {
+		return nil, fmt.Errorf("%w: 잘못된 캔들 간격입니다. (%s)", b.ErrInvalidParameter, interval)
+	}
+	c := config{}
+	c.location = KST
+	for _, option := range options {
+		option(&c)
+	}
+	if c.location == nil {
+		c.location = KST
+	}
+	return &c, nil
+}
+
+// Resample 은 candles 를 interval 간격으로 묶습니다. 결과 캔들의 Time 은 그 간격의 시작 시각입니다.
+// candles 의 간격은 interval 보다 작거나 같아야 하며, 순서는 상관없습니다.
+func Resample(candles []b.OneCandleStick, interval Interval, options ...Option) ([]b.OneCandleStick, error) {
+	c, err := newConfig(interval, options)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]b.OneCandleStick, len(candles))
+	copy(sorted, candles)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	var result []b.OneCandleStick
+	for _, data := range sorted {
+		start := interval.Start(data.Time, c.location)
+		if len(result) > 0 && result[len(result)-1].Time.Equal(start) {
+			last := &result[len(result)-1]
+			last.ClosingPrice = data.ClosingPrice
+			last.HighPrice = b.MaxDecimal(last.HighPrice, data.HighPrice)
+			last.LowPrice = b.MinDecimal(last.LowPrice, data.LowPrice)
+			last.UnitsTraded = last.UnitsTraded.Add(data.UnitsTraded)
+			continue
+		}
+		data.Time = start
+		result = append(result, data)
+	}
+
+	if c.fillGaps {
+		result = fillGaps(result, interval)
+	}
+	return result, nil
+}
+
+// FillGaps 는 candles 사이의 빠진 봉을 직전 종가로 된 거래량 0 의 봉으로 채웁니다.
+// candles 는 이미 interval 간격이어야 합니다. 간격이 맞지 않으면 Resample 에 WithFillGaps 를 사용하세요.
+func FillGaps(candles []b.OneCandleStick, interval Interval, options ...Option) ([]b.OneCandleStick, error) {
+	return Resample(candles, interval, append(options, WithFillGaps())...)
+}
+
+func fillGaps(candles []b.OneCandleStick, interval Interval) []b.OneCandleStick {
+	if len(candles) == 0 {
+		return candles
+	}
+
+	result := []b.OneCandleStick{candles[0]}
+	for _, data := range candles[1:] {
+		previous := result[len(result)-1]
+		for next := interval.Next(previous.Time); next.Before(data.Time); next = interval.Next(next) {
+			price := previous.ClosingPrice
+			result = append(result, b.OneCandleStick{Time: next, OpeningPrice: price, ClosingPrice: price, HighPrice: price, LowPrice: price})
+		}
+		result = append(result, data)
+	}
+	return result
+}
+
+// FromTrades 는 GetTransactionHistory 의 체결 내역으로 interval 간격의 캔들을 만듭니다.
+// 체결 시각에 시간대가 없으면 (API 응답) KST 로 봅니다. 같은 시각의 체결은 주어진 순서대로 처리합니다.
+func FromTrades(trades []b.OneTransaction, interval Interval, options ...Option) ([]b.OneCandleStick, error) {
+	if _, err := newConfig(interval, options); err != nil {
+		return nil, err
+	}
+
+	candles := make([]b.OneCandleStick, len(trades))
+	for index, data := range trades {
+		candles[index] = b.OneCandleStick{
+			Time:         tradeTime(data.TransactionDate),
+			OpeningPrice: data.Price,
+			ClosingPrice: data.Price,
+			HighPrice:    data.Price,
+			LowPrice:     data.Price,
+			UnitsTraded:  data.UnitsTraded,
+		}
+	}
+	return Resample(candles, interval, options...)
+}
+
+// tradeTime 은 timezone 없이 UTC 로 해석된 체결 시각을 KST 로 바꿉니다.
+func tradeTime(date time.Time) time.Time {
+	if date.Location() != time.UTC {
+		return date
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), KST)
+}
@@ -0,0 +1,107 @@
+package resample
+
+import (
+	"testing"
+	"time"
+
+	b "github.com/lutergs/gobithumb"
+)
+
+func candle(date time.Time, open, high, low, close, units string) b.OneCandleStick {
+	return b.OneCandleStick{
+		Time:         date,
+		OpeningPrice: b.MustDecimal(open),
+		HighPrice:    b.MustDecimal(high),
+		LowPrice:     b.MustDecimal(low),
+		ClosingPrice: b.MustDecimal(close),
+		UnitsTraded:  b.MustDecimal(units),
+	}
+}
+
+func checkCandles(t *testing.T, result []b.OneCandleStick, want []b.OneCandleStick) {
+	t.Helper()
+	if len(result) != len(want) {
+		t.Fatalf("candles = %+v, want %d", result, len(want))
+	}
+	for index, data := range want {
+		actual := result[index]
+		if !actual.Time.Equal(data.Time) || !actual.OpeningPrice.Equal(data.OpeningPrice) || !actual.HighPrice.Equal(data.HighPrice) ||
+			!actual.LowPrice.Equal(data.LowPrice) || !actual.ClosingPrice.Equal(data.ClosingPrice) || !actual.UnitsTraded.Equal(data.UnitsTraded) {
+			t.Errorf("candle[%d] = %+v, want %+v", index, actual, data)
+		}
+	}
+}
+
+func TestResample(t *testing.T) {
+	// KST 1월 31일 22시, 23시, 2월 1일 2시의 1시간 봉 (순서 섞음)
+	candles := []b.OneCandleStick{
+		candle(time.Date(2026, 1, 31, 17, 0, 0, 0, time.UTC), "103", "106", "101", "105", "3"),
+		candle(time.Date(2026, 1, 31, 13, 0, 0, 0, time.UTC), "100", "104", "99", "102", "1"),
+		candle(time.Date(2026, 1, 31, 14, 0, 0, 0, time.UTC), "102", "103", "98", "101", "2"),
+	}
+
+	result, err := Resample(candles, Days(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCandles(t, result, []b.OneCandleStick{
+		candle(time.Date(2026, 1, 31, 0, 0, 0, 0, KST), "100", "104", "98", "101", "3"),
+		candle(time.Date(2026, 2, 1, 0, 0, 0, 0, KST), "103", "106", "101", "105", "3"),
+	})
+
+	// KST 0시, 1시는 직전 종가 101 로 채움
+	result, err = Resample(candles, Hours(1), WithFillGaps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCandles(t, result, []b.OneCandleStick{
+		candle(time.Date(2026, 1, 31, 22, 0, 0, 0, KST), "100", "104", "99", "102", "1"),
+		candle(time.Date(2026, 1, 31, 23, 0, 0, 0, KST), "102", "103", "98", "101", "2"),
+		candle(time.Date(2026, 2, 1, 0, 0, 0, 0, KST), "101", "101", "101", "101", "0"),
+		candle(time.Date(2026, 2, 1, 1, 0, 0, 0, KST), "101", "101", "101", "101", "0"),
+		candle(time.Date(2026, 2, 1, 2, 0, 0, 0, KST), "103", "106", "101", "105", "3"),
+	})
+}
+
+func TestFillGapsMonths(t *testing.T) {
+	candles := []b.OneCandleStick{
+		candle(time.Date(2026, 1, 1, 0, 0, 0, 0, KST), "100", "120", "90", "110", "5"),
+		candle(time.Date(2026, 4, 1, 0, 0, 0, 0, KST), "115", "130", "105", "125", "7"),
+	}
+	result, err := FillGaps(candles, Months(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCandles(t, result, []b.OneCandleStick{
+		candles[0],
+		candle(time.Date(2026, 2, 1, 0, 0, 0, 0, KST), "110", "110", "110", "110", "0"),
+		candle(time.Date(2026, 3, 1, 0, 0, 0, 0, KST), "110", "110", "110", "110", "0"),
+		candles[1],
+	})
+}
+
+func TestFromTrades(t *testing.T) {
+	trade := func(date time.Time, price, units string) b.OneTransaction {
+		return b.OneTransaction{TransactionDate: date, Price: b.MustDecimal(price), UnitsTraded: b.MustDecimal(units)}
+	}
+	// API 응답의 체결 시각은 KST 이지만 UTC 로 해석되어 있음
+	trades := []b.OneTransaction{
+		trade(time.Date(2026, 1, 31, 23, 59, 58, 0, time.UTC), "100", "1"),
+		trade(time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC), "102", "2"),
+		trade(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), "101", "1"),
+		// 시간대가 있는 시각은 그대로 사용 (KST 2월 1일 0시 30분)
+		trade(time.Date(2026, 1, 31, 15, 30, 0, 0, time.FixedZone("", 0)), "99", "4"),
+	}
+	result, err := FromTrades(trades, Days(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCandles(t, result, []b.OneCandleStick{
+		candle(time.Date(2026, 1, 31, 0, 0, 0, 0, KST), "100", "102", "100", "102", "3"),
+		candle(time.Date(2026, 2, 1, 0, 0, 0, 0, KST), "101", "101", "99", "99", "5"),
+	})
+
+	if result := tradeTime(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)); !result.Equal(time.Date(2026, 1, 31, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("tradeTime = %s, want KST 2026-02-01 00:00", result)
+	}
+}
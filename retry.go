@@ -0,0 +1,81 @@
+package gobithumb
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRetryPolicy 는 public API 와 /info/* 조회 API 에 기본으로 적용됩니다.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 3 * time.Second, Jitter: 0.5}
+
+// RetryPolicy 는 일시적인 오류가 났을 때 요청을 다시 보내는 방식입니다.
+// n 번째 재시도는 BaseDelay * 2^(n-1) 만큼 (최대 MaxDelay) 기다리며, Jitter 비율만큼 무작위로 줄어듭니다.
+// MaxAttempts 가 1 이하면 재시도하지 않습니다.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	Jitter      float64
+}
+
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// IdempotencyGuard 는 /trade/* 요청을 다시 보내기 전에 호출됩니다.
+// 이전 요청이 서버에 반영되지 않았음을 확인했을 때만 true 를 반환해야 합니다. (e.g. GetOrder 로 같은 주문이 없는지 확인)
+type IdempotencyGuard func(ctx context.Context, endpoint string, params map[string]string) (resend bool, err error)
+
+type tradeRetryKey struct{}
+
+// WithTradeRetry 는 주문 생성과 같은 /trade/* 요청도 재시도하도록 허용한 context 를 반환합니다.
+// 중복 주문을 막기 위해 guard 는 반드시 지정해야 합니다.
+func WithTradeRetry(ctx context.Context, guard IdempotencyGuard) context.Context {
+	if guard == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, tradeRetryKey{}, guard)
+}
+
+func tradeRetryGuard(ctx context.Context) IdempotencyGuard {
+	guard, _ := ctx.Value(tradeRetryKey{}).(IdempotencyGuard)
+	return guard
+}
+
+// isTemporary 는 다시 요청하면 성공할 수 있는 오류인지 확인합니다.
+func isTemporary(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return errors.Is(apiErr, ErrTemporary)
+	}
+
+	// 연결 실패, 응답 읽기 실패 등은 재시도 대상
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		return !errors.Is(err, ErrRateLimited)
+	}
+	return false
+}
+
+func isTemporaryStatus(httpStatus int, status string, message string) bool {
+	return httpStatus >= http.StatusInternalServerError || httpStatus == http.StatusTooManyRequests ||
+		status == "5900" || (status == "5600" && strings.Contains(message, "일시적"))
+}
@@ -0,0 +1,575 @@
+package gobithumb
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const DefaultStreamURL = "wss://pubwss.bithumb.com/pub/ws"
+
+type StreamType string
+type TickType string
+
+const (
+	StreamTicker         StreamType = "ticker"
+	StreamTransaction    StreamType = "transaction"
+	StreamOrderbookDepth StreamType = "orderbookdepth"
+
+	Tick30M TickType = "30M"
+	Tick1H  TickType = "1H"
+	Tick12H TickType = "12H"
+	Tick24H TickType = "24H"
+	TickMID TickType = "MID"
+)
+
+var kst = time.FixedZone("KST", 9*60*60)
+
+var ErrStreamRunning = errors.New("gobithumb: stream is already running")
+
+// ErrStreamDropped 는 이벤트 channel 이 가득 차서 메시지를 버렸을 때 StreamStatus 의 Err 로 전달됩니다.
+var ErrStreamDropped = errors.New("gobithumb: stream events dropped")
+
+//==============================STREAM EVENT======================================
+
+type TickerEvent struct {
+	OrderCurrency   Currency
+	PaymentCurrency Currency
+	TickType        TickType
+	Time            time.Time
+	OpenPrice       Decimal
+	ClosePrice      Decimal
+	LowPrice        Decimal
+	HighPrice       Decimal
+	Value           Decimal
+	Volume          Decimal
+	SellVolume      Decimal
+	BuyVolume       Decimal
+	PrevClosePrice  Decimal
+	ChangeRate      Decimal
+	ChangeAmount    Decimal
+	VolumePower     Decimal
+}
+
+type TransactionEvent struct {
+	OrderCurrency   Currency
+	PaymentCurrency Currency
+	Type            OrderSide // Bid : 매수 체결, Ask : 매도 체결
+	Time            time.Time
+	Price           Decimal
+	Quantity        Decimal
+	Amount          Decimal
+	UpDown          string
+}
+
+type OrderbookDepthEntry struct {
+	OrderCurrency   Currency
+	PaymentCurrency Currency
+	Type            OrderSide
+	Price           Decimal
+	Quantity        Decimal // 해당 가격의 잔량, 0 이면 가격대가 사라진 것
+	Total           int
+}
+
+type OrderbookDepthEvent struct {
+	Time    time.Time
+	Entries []OrderbookDepthEntry
+}
+
+// StreamStatus 는 연결 상태가 바뀔 때마다 전달됩니다.
+// 재연결 사이에 놓친 메시지가 있을 수 있으므로, 연결될 때마다 상태를 다시 맞춰야 합니다.
+// 읽지 않는 channel 이 가득 차서 메시지를 버리기 시작하면 Connected 가 true 이고 Err 가 ErrStreamDropped 인 상태가 전달되며, 이때도 상태를 다시 맞춰야 합니다.
+type StreamStatus struct {
+	Connected bool
+	Err       error
+	Time      time.Time
+}
+
+func splitSymbol(symbol string) (Currency, Currency) {
+	index := strings.LastIndex(symbol, "_")
+	if index < 0 {
+		return Currency(strings.ToLower(symbol)), ""
+	}
+	return Currency(strings.ToLower(symbol[:index])), Currency(strings.ToLower(symbol[index+1:]))
+}
+
+func newTickerEvent(rawTicker map[string]interface{}) TickerEvent {
+	newTickerEvent := TickerEvent{}
+	newTickerEvent.OrderCurrency, newTickerEvent.PaymentCurrency = splitSymbol(toString(rawTicker["symbol"]))
+	newTickerEvent.TickType = TickType(toString(rawTicker["tickType"]))
+	newTickerEvent.Time, _ = time.ParseInLocation("20060102150405", toString(rawTicker["date"])+toString(rawTicker["time"]), kst)
+	newTickerEvent.OpenPrice = toDecimal(rawTicker["openPrice"])
+	newTickerEvent.ClosePrice = toDecimal(rawTicker["closePrice"])
+	newTickerEvent.LowPrice = toDecimal(rawTicker["lowPrice"])
+	newTickerEvent.HighPrice = toDecimal(rawTicker["highPrice"])
+	newTickerEvent.Value = toDecimal(rawTicker["value"])
+	newTickerEvent.Volume = toDecimal(rawTicker["volume"])
+	newTickerEvent.SellVolume = toDecimal(rawTicker["sellVolume"])
+	newTickerEvent.BuyVolume = toDecimal(rawTicker["buyVolume"])
+	newTickerEvent.PrevClosePrice = toDecimal(rawTicker["prevClosePrice"])
+	newTickerEvent.ChangeRate = toDecimal(rawTicker["chgRate"])
+	newTickerEvent.ChangeAmount = toDecimal(rawTicker["chgAmt"])
+	newTickerEvent.VolumePower = toDecimal(rawTicker["volumePower"])
+	return newTickerEvent
+}
+
+func newTransactionEvent(rawTransaction map[string]interface{}) TransactionEvent {
+	newTransactionEvent := TransactionEvent{}
+	newTransactionEvent.OrderCurrency, newTransactionEvent.PaymentCurrency = splitSymbol(toString(rawTransaction["symbol"]))
+	if toString(rawTransaction["buySellGb"]) == "1" {
+		newTransactionEvent.Type = Ask
+	} else {
+		newTransactionEvent.Type = Bid
+	}
+	newTransactionEvent.Time, _ = time.ParseInLocation("2006-01-02 15:04:05.999999", toString(rawTransaction["contDtm"]), kst)
+	newTransactionEvent.Price = toDecimal(rawTransaction["contPrice"])
+	newTransactionEvent.Quantity = toDecimal(rawTransaction["contQty"])
+	newTransactionEvent.Amount = toDecimal(rawTransaction["contAmt"])
+	newTransactionEvent.UpDown = toString(rawTransaction["updn"])
+	return newTransactionEvent
+}
+
+func newOrderbookDepthEvent(rawDepth map[string]interface{}) OrderbookDepthEvent {
+	newOrderbookDepthEvent := OrderbookDepthEvent{}
+	newOrderbookDepthEvent.Time = microStringToTime(toString(rawDepth["datetime"]))
+
+	entries := toSlice(rawDepth["list"])
+	newOrderbookDepthEvent.Entries = make([]OrderbookDepthEntry, len(entries))
+	for index, data := range entries {
+		oneEntry := toMap(data)
+		newOrderbookDepthEvent.Entries[index].OrderCurrency, newOrderbookDepthEvent.Entries[index].PaymentCurrency = splitSymbol(toString(oneEntry["symbol"]))
+		newOrderbookDepthEvent.Entries[index].Type = parseSide(toString(oneEntry["orderType"]))
+		newOrderbookDepthEvent.Entries[index].Price = toDecimal(oneEntry["price"])
+		newOrderbookDepthEvent.Entries[index].Quantity = toDecimal(oneEntry["quantity"])
+		newOrderbookDepthEvent.Entries[index].Total = int(toFloat(oneEntry["total"]))
+	}
+	return newOrderbookDepthEvent
+}
+
+//==============================STREAM CLIENT======================================
+
+// StreamOption 은 NewStreamClient 에 전달해 StreamClient 의 동작을 바꿉니다.
+type StreamOption func(*StreamClient)
+
+// WithStreamURL 은 접속할 WebSocket 주소를 바꿉니다. 로컬 테스트 서버에는 ws:// 주소를 사용할 수 있습니다.
+func WithStreamURL(streamUrl string) StreamOption {
+	return func(s *StreamClient) {
+		s.streamUrl = streamUrl
+	}
+}
+
+func WithStreamTLSConfig(tlsConfig *tls.Config) StreamOption {
+	return func(s *StreamClient) {
+		s.tlsConfig = tlsConfig
+	}
+}
+
+func WithStreamLogger(logger Logger) StreamOption {
+	return func(s *StreamClient) {
+		if logger == nil {
+			logger = NopLogger
+		}
+		s.logger = logger
+	}
+}
+
+const (
+	defaultMinReconnectDelay = 500 * time.Millisecond
+	defaultMaxReconnectDelay = 30 * time.Second
+	defaultStreamBuffer      = 256
+)
+
+// WithReconnectDelay 는 연결이 끊겼을 때 다시 연결하기까지 기다리는 시간입니다. (기본값 500ms, 30초)
+// 연속으로 실패하면 maxDelay 까지 두 배씩 늘어납니다. 0 이하의 값은 기본값을 사용하며, maxDelay 는 minDelay 보다 작을 수 없습니다.
+func WithReconnectDelay(minDelay time.Duration, maxDelay time.Duration) StreamOption {
+	return func(s *StreamClient) {
+		if minDelay <= 0 {
+			minDelay = defaultMinReconnectDelay
+		}
+		if maxDelay <= 0 {
+			maxDelay = defaultMaxReconnectDelay
+		}
+		if maxDelay < minDelay {
+			maxDelay = minDelay
+		}
+		s.minReconnectDelay = minDelay
+		s.maxReconnectDelay = maxDelay
+	}
+}
+
+// WithStreamKeepAlive 는 pingInterval 마다 ping 을 보내고, readTimeout 동안 아무것도 받지 못하면 연결을 끊고 다시 연결합니다.
+// (기본값 20초, 60초) 0 을 주면 해당 기능을 끕니다.
+func WithStreamKeepAlive(pingInterval time.Duration, readTimeout time.Duration) StreamOption {
+	return func(s *StreamClient) {
+		s.pingInterval = pingInterval
+		s.readTimeout = readTimeout
+	}
+}
+
+// WithStreamBuffer 는 이벤트 channel 의 크기를 지정합니다. channel 이 가득 차면 새 메시지는 버려집니다. (기본값 256)
+// 0 이하의 값은 기본값을 사용합니다.
+func WithStreamBuffer(size int) StreamOption {
+	return func(s *StreamClient) {
+		if size <= 0 {
+			size = defaultStreamBuffer
+		}
+		s.bufferSize = size
+	}
+}
+
+type subscription struct {
+	Type      StreamType `json:"type"`
+	Symbols   []string   `json:"symbols"`
+	TickTypes []TickType `json:"tickTypes,omitempty"`
+}
+
+// StreamClient 는 Bithumb 의 public WebSocket 에 연결해 ticker, transaction, orderbookdepth 이벤트를 channel 로 전달합니다.
+// 연결이 끊기면 자동으로 다시 연결하고, 등록된 구독을 다시 요청합니다.
+// channel 은 서로 독립적이므로, 일부 channel 만 읽어도 됩니다. 읽지 않는 channel 의 메시지는 가득 차면 버려집니다.
+type StreamClient struct {
+	streamUrl string
+	tlsConfig *tls.Config
+	logger    Logger
+
+	minReconnectDelay time.Duration
+	maxReconnectDelay time.Duration
+	bufferSize        int
+	pingInterval      time.Duration
+	readTimeout       time.Duration
+
+	dropped  uint64
+	dropping map[StreamType]bool // dispatch 에서만 사용
+
+	mutex         sync.Mutex
+	subscriptions []subscription
+	conn          *wsConn
+	running       bool
+
+	tickers      chan TickerEvent
+	transactions chan TransactionEvent
+	depths       chan OrderbookDepthEvent
+	status       chan StreamStatus
+}
+
+func NewStreamClient(options ...StreamOption) *StreamClient {
+
+	streamClient := StreamClient{}
+
+	streamClient.streamUrl = DefaultStreamURL
+	streamClient.logger = NewStdLogger(os.Stdout, LogWarn)
+	streamClient.minReconnectDelay = defaultMinReconnectDelay
+	streamClient.maxReconnectDelay = defaultMaxReconnectDelay
+	streamClient.bufferSize = defaultStreamBuffer
+	streamClient.pingInterval = 20 * time.Second
+	streamClient.readTimeout = 60 * time.Second
+	streamClient.dropping = make(map[StreamType]bool)
+
+	for _, option := range options {
+		option(&streamClient)
+	}
+
+	streamClient.tickers = make(chan TickerEvent, streamClient.bufferSize)
+	streamClient.transactions = make(chan TransactionEvent, streamClient.bufferSize)
+	streamClient.depths = make(chan OrderbookDepthEvent, streamClient.bufferSize)
+	streamClient.status = make(chan StreamStatus, 16)
+
+	return &streamClient
+}
+
+func (s *StreamClient) Tickers() <-chan TickerEvent {
+	return s.tickers
+}
+
+func (s *StreamClient) Transactions() <-chan TransactionEvent {
+	return s.transactions
+}
+
+func (s *StreamClient) OrderbookDepths() <-chan OrderbookDepthEvent {
+	return s.depths
+}
+
+// Dropped 는 channel 이 가득 차서 버린 이벤트의 개수입니다.
+func (s *StreamClient) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// Status 는 연결, 연결 끊김 이벤트를 전달합니다. channel 이 가득 차면 오래된 이벤트를 받지 못할 수 있습니다.
+func (s *StreamClient) Status() <-chan StreamStatus {
+	return s.status
+}
+
+func (s *StreamClient) SubscribeTicker(orderCurrency Currency, paymentCurrency Currency, tickTypes ...TickType) error {
+	if len(tickTypes) == 0 {
+		tickTypes = []TickType{Tick24H}
+	}
+	return s.subscribe(subscription{Type: StreamTicker, Symbols: []string{streamSymbol(orderCurrency, paymentCurrency)}, TickTypes: tickTypes})
+}
+
+func (s *StreamClient) SubscribeTransaction(orderCurrency Currency, paymentCurrency Currency) error {
+	return s.subscribe(subscription{Type: StreamTransaction, Symbols: []string{streamSymbol(orderCurrency, paymentCurrency)}})
+}
+
+func (s *StreamClient) SubscribeOrderbookDepth(orderCurrency Currency, paymentCurrency Currency) error {
+	return s.subscribe(subscription{Type: StreamOrderbookDepth, Symbols: []string{streamSymbol(orderCurrency, paymentCurrency)}})
+}
+
+func streamSymbol(orderCurrency Currency, paymentCurrency Currency) string {
+	return NewMarket(orderCurrency, paymentCurrency).String()
+}
+
+// subscribe 는 구독을 등록하고, 이미 연결되어 있으면 바로 요청합니다.
+// Bithumb 은 같은 type 의 구독을 새로 요청하면 이전 것을 덮어쓰므로, 같은 type 의 symbol 들을 합쳐서 보냅니다.
+func (s *StreamClient) subscribe(newSubscription subscription) error {
+	s.mutex.Lock()
+	var merged *subscription
+	for index := range s.subscriptions {
+		if s.subscriptions[index].Type == newSubscription.Type {
+			merged = &s.subscriptions[index]
+		}
+	}
+	if merged == nil {
+		s.subscriptions = append(s.subscriptions, newSubscription)
+		merged = &s.subscriptions[len(s.subscriptions)-1]
+	} else {
+		merged.Symbols = appendUnique(merged.Symbols, newSubscription.Symbols...)
+		merged.TickTypes = appendUniqueTick(merged.TickTypes, newSubscription.TickTypes...)
+	}
+	request := *merged
+	conn := s.conn
+	s.mutex.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	return s.sendSubscription(conn, request)
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, data := range list {
+			if data == value {
+				found = true
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func appendUniqueTick(list []TickType, values ...TickType) []TickType {
+	for _, value := range values {
+		found := false
+		for _, data := range list {
+			if data == value {
+				found = true
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+func (s *StreamClient) sendSubscription(conn *wsConn, request subscription) error {
+	message, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	return conn.writeMessage(message)
+}
+
+// Run 은 ctx 가 끝날 때까지 연결을 유지하며 이벤트를 전달합니다. ctx 가 끝나면 ctx.Err() 를 반환합니다.
+// Run 은 동시에 하나만 실행할 수 있습니다.
+func (s *StreamClient) Run(ctx context.Context) error {
+
+	s.mutex.Lock()
+	if s.running {
+		s.mutex.Unlock()
+		return ErrStreamRunning
+	}
+	s.running = true
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		s.running = false
+		s.mutex.Unlock()
+	}()
+
+	delay := s.minReconnectDelay
+	for {
+		connected, err := s.runOnce(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.logger.Log(LogWarn, "stream disconnected", LogField{"url", s.streamUrl}, LogField{"error", err}, LogField{"reconnect_delay", delay})
+		s.notify(StreamStatus{Connected: false, Err: err, Time: time.Now()})
+
+		if connected {
+			delay = s.minReconnectDelay
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		if !connected {
+			delay *= 2
+			if delay > s.maxReconnectDelay {
+				delay = s.maxReconnectDelay
+			}
+		}
+	}
+}
+
+func (s *StreamClient) runOnce(ctx context.Context) (bool, error) {
+
+	conn, err := dialWebSocket(ctx, s.streamUrl, s.tlsConfig)
+	if err != nil {
+		return false, err
+	}
+
+	// ctx 가 끝나면 연결을 닫아 readMessage 를 멈춤
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.close()
+		case <-done:
+		}
+	}()
+	defer conn.close()
+
+	// 끊긴 줄 모르는 (half-open) 연결을 찾기 위해 ping 을 보내고, 응답이 없으면 readMessage 가 timeout 으로 실패함
+	conn.readTimeout = s.readTimeout
+	conn.writeTimeout = s.readTimeout
+	if s.pingInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(s.pingInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					if err := conn.writeFrame(wsOpPing, nil); err != nil {
+						_ = conn.conn.Close()
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	s.mutex.Lock()
+	s.conn = conn
+	requests := make([]subscription, len(s.subscriptions))
+	copy(requests, s.subscriptions)
+	s.mutex.Unlock()
+	defer func() {
+		s.mutex.Lock()
+		s.conn = nil
+		s.mutex.Unlock()
+	}()
+
+	for _, request := range requests {
+		if err := s.sendSubscription(conn, request); err != nil {
+			return false, err
+		}
+	}
+	s.logger.Log(LogInfo, "stream connected", LogField{"url", s.streamUrl}, LogField{"subscriptions", len(requests)})
+	s.notify(StreamStatus{Connected: true, Time: time.Now()})
+
+	for {
+		message, err := conn.readMessage()
+		if err != nil {
+			return true, err
+		}
+		if err := s.dispatch(ctx, message); err != nil {
+			return true, err
+		}
+	}
+}
+
+func (s *StreamClient) dispatch(ctx context.Context, message []byte) error {
+	var rawMessage map[string]interface{}
+	if err := decodeJSON(message, &rawMessage); err != nil {
+		s.logger.Log(LogWarn, "invalid stream message", LogField{"message", string(message)})
+		return nil
+	}
+
+	// 연결, 구독 응답 : {"status":"0000","resmsg":"Connected Successfully"}
+	if status, ok := rawMessage["status"]; ok {
+		if toString(status) != statusOK {
+			s.logger.Log(LogWarn, "stream request rejected", LogField{"status", toString(status)}, LogField{"message", toString(rawMessage["resmsg"])})
+		} else {
+			s.logger.Log(LogDebug, "stream response", LogField{"message", toString(rawMessage["resmsg"])})
+		}
+		return nil
+	}
+
+	// channel 마다 기다리지 않고 보내므로, 하나의 channel 이 가득 차도 다른 channel 은 계속 전달됨
+	content := toMap(rawMessage["content"])
+	streamType := StreamType(toString(rawMessage["type"]))
+	switch streamType {
+	case StreamTicker:
+		select {
+		case s.tickers <- newTickerEvent(content):
+			s.delivered(streamType)
+		default:
+			s.drop(streamType)
+		}
+	case StreamTransaction:
+		for _, data := range toSlice(content["list"]) {
+			select {
+			case s.transactions <- newTransactionEvent(toMap(data)):
+				s.delivered(streamType)
+			default:
+				s.drop(streamType)
+			}
+		}
+	case StreamOrderbookDepth:
+		select {
+		case s.depths <- newOrderbookDepthEvent(content):
+			s.delivered(streamType)
+		default:
+			s.drop(streamType)
+		}
+	}
+	return ctx.Err()
+}
+
+func (s *StreamClient) delivered(streamType StreamType) {
+	s.dropping[streamType] = false
+}
+
+// drop 은 버린 이벤트를 세고, 버리기 시작할 때 한 번 로그와 상태를 전달합니다.
+func (s *StreamClient) drop(streamType StreamType) {
+	atomic.AddUint64(&s.dropped, 1)
+	if s.dropping[streamType] {
+		return
+	}
+	s.dropping[streamType] = true
+	s.logger.Log(LogWarn, "stream channel full, dropping events", LogField{"type", streamType})
+	s.notify(StreamStatus{Connected: true, Err: fmt.Errorf("%w: %s", ErrStreamDropped, streamType), Time: time.Now()})
+}
+
+func (s *StreamClient) notify(status StreamStatus) {
+	select {
+	case s.status <- status:
+	default:
+	}
+}
@@ -0,0 +1,29 @@
+package gobithumb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreamOptionDefaults(t *testing.T) {
+	tests := []struct {
+		minDelay, maxDelay time.Duration
+		buffer             int
+		wantMin, wantMax   time.Duration
+		wantBuffer         int
+	}{
+		{0, 0, 0, 500 * time.Millisecond, 30 * time.Second, 256},
+		{-time.Second, -time.Second, -1, 500 * time.Millisecond, 30 * time.Second, 256},
+		{2 * time.Second, time.Second, 16, 2 * time.Second, 2 * time.Second, 16},
+		{time.Second, time.Minute, 1, time.Second, time.Minute, 1},
+	}
+	for _, test := range tests {
+		client := NewStreamClient(WithReconnectDelay(test.minDelay, test.maxDelay), WithStreamBuffer(test.buffer))
+		if client.minReconnectDelay != test.wantMin || client.maxReconnectDelay != test.wantMax {
+			t.Errorf("WithReconnectDelay(%s, %s) = %s, %s, want %s, %s", test.minDelay, test.maxDelay, client.minReconnectDelay, client.maxReconnectDelay, test.wantMin, test.wantMax)
+		}
+		if cap(client.tickers) != test.wantBuffer {
+			t.Errorf("WithStreamBuffer(%d) = %d, want %d", test.buffer, cap(client.tickers), test.wantBuffer)
+		}
+	}
+}
@@ -0,0 +1,499 @@
+package gobithumb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TrailingStopStatus 는 트레일링 스탑의 상태입니다.
+type TrailingStopStatus string
+
+const (
+	TrailingStopActive    TrailingStopStatus = "active"    // 가격을 따라가는 중
+	TrailingStopTriggered TrailingStopStatus = "triggered" // 발동되어 주문을 넣음
+	TrailingStopFailed    TrailingStopStatus = "failed"    // 발동되었지만 주문이 실패함
+)
+
+// TrailingStop 은 가격을 따라 발동 가격을 조정하는 스탑 주문입니다. Bithumb 에는 없으므로 TrailingStopManager 가 대신 주문을 넣습니다.
+//   - Ask (매도) : 최고가 (Extreme) 에서 TrailAmount 또는 TrailPercent 만큼 내려간 가격이 발동 가격이며, 가격이 그 아래로 내려가면 매도합니다.
+//   - Bid (매수) : 최저가에서 그만큼 올라간 가격이 발동 가격이며, 가격이 그 위로 올라가면 매수합니다.
+//
+// 발동 가격은 유리한 방향으로만 움직이며, 최고가 (최저가) 에서 멀어지는 쪽으로 호가 단위에 맞춥니다. (Ask 는 내림, Bid 는 올림)
+// 따라서 트레일이 호가 단위보다 작아도 발동 가격이 최고가와 같아지지 않습니다.
+// LimitOffset 이 0 이면 시장가로, 아니면 발동 가격에서 LimitOffset 만큼 불리한 가격의 지정가로 주문합니다.
+type TrailingStop struct {
+	ID           string             `json:"id"`
+	Market       Market             `json:"market"`
+	Side         OrderSide          `json:"side"`
+	Units        Decimal            `json:"units"`
+	TrailAmount  Decimal            `json:"trail_amount"`
+	TrailPercent Decimal            `json:"trail_percent"` // 5 = 5%
+	LimitOffset  Decimal            `json:"limit_offset"`
+	Extreme      Decimal            `json:"extreme"` // 지금까지의 최고가 (Bid 는 최저가), 0 이면 처음 받은 가격부터 시작
+	Trigger      Decimal            `json:"trigger"`
+	Status       TrailingStopStatus `json:"status"`
+	OrderID      string             `json:"order_id,omitempty"`
+	Error        string             `json:"error,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	TriggeredAt  time.Time          `json:"triggered_at"`
+}
+
+// triggerOf 는 extreme 에 대한 발동 가격을 rules 의 호가 단위에 맞춘 값입니다.
+func (t *TrailingStop) triggerOf(extreme Decimal, rules OrderRules) Decimal {
+	trail := t.TrailAmount
+	if t.TrailPercent.Sign() > 0 {
+		trail = extreme.Mul(t.TrailPercent).Mul(NewDecimal(1, 2))
+	}
+	// RoundPrice 는 Bid 를 내림, Ask 를 올림하므로 반대 방향을 넘김
+	if t.Side == Ask {
+		return rules.RoundPrice(t.Market.Quote, extreme.Sub(trail), Bid)
+	}
+	return rules.RoundPrice(t.Market.Quote, extreme.Add(trail), Ask)
+}
+
+// observe 는 price 로 최고가 (최저가) 와 발동 가격을 조정하고, 바뀌었는지와 발동해야 하는지를 반환합니다.
+func (t *TrailingStop) observe(price Decimal, rules OrderRules) (bool, bool) {
+	changed := false
+	if t.Extreme.Sign() <= 0 || (t.Side == Ask && price.GreaterThan(t.Extreme)) || (t.Side == Bid && price.LessThan(t.Extreme)) {
+		t.Extreme = price
+		t.Trigger = t.triggerOf(price, rules)
+		changed = true
+	}
+	if t.Side == Ask {
+		return changed, price.Cmp(t.Trigger) <= 0
+	}
+	return changed, price.Cmp(t.Trigger) >= 0
+}
+
+//==============================STORE======================================
+
+// TrailingStopStore 는 프로세스가 다시 시작되어도 트레일링 스탑을 이어갈 수 있도록 상태를 저장합니다.
+type TrailingStopStore interface {
+	Load() ([]TrailingStop, error)
+	Save(stops []TrailingStop) error
+}
+
+// FileTrailingStopStore 는 상태를 JSON 파일 하나에 저장합니다. 임시 파일에 쓴 뒤 이름을 바꾸므로 저장 중에 종료되어도 파일이 깨지지 않습니다.
+type FileTrailingStopStore struct {
+	path string
+}
+
+func NewFileTrailingStopStore(path string) *FileTrailingStopStore {
+	return &FileTrailingStopStore{path: path}
+}
+
+// Load 는 저장된 상태를 읽습니다. 파일이 없으면 빈 목록을 반환합니다.
+func (f *FileTrailingStopStore) Load() ([]TrailingStop, error) {
+	data, err := ioutil.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result []TrailingStop
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (f *FileTrailingStopStore) Save(stops []TrailingStop) error {
+	data, err := json.MarshalIndent(stops, "", "  ")
+	if err != nil {
+		return err
+	}
+	file, err := ioutil.TempFile(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), f.path)
+}
+
+//==============================MANAGER======================================
+
+// TrailingStopOption 은 NewTrailingStopManager 에 전달해 TrailingStopManager 의 동작을 바꿉니다.
+type TrailingStopOption func(*TrailingStopManager)
+
+func WithTrailingStopLogger(logger Logger) TrailingStopOption {
+	return func(t *TrailingStopManager) {
+		if logger == nil {
+			logger = NopLogger
+		}
+		t.logger = logger
+	}
+}
+
+// WithTrailingStopOrderRules 는 발동 가격과 주문에 적용할 규칙입니다. (기본값 DefaultOrderRules())
+// 지정가 주문의 가격은 RoundPrice 로, 수량은 RoundUnits 로 맞춘 뒤 주문합니다.
+func WithTrailingStopOrderRules(rules OrderRules) TrailingStopOption {
+	return func(t *TrailingStopManager) {
+		t.rules = rules
+	}
+}
+
+// TrailingStopManager 는 가격을 받아 트레일링 스탑의 발동 가격을 조정하고, 발동되면 trader 로 주문을 넣습니다.
+// 가격은 Run (WebSocket), RunPolling (GetTicker) 으로 받거나 Update 로 직접 넣을 수 있습니다.
+// 상태가 바뀔 때마다 store 에 저장하며, 주문은 발동 상태를 먼저 저장한 뒤에 넣으므로 다시 시작해도 두 번 주문하지 않습니다.
+type TrailingStopManager struct {
+	trader Trading
+	store  TrailingStopStore
+	logger Logger
+	rules  OrderRules
+
+	mutex      sync.Mutex
+	stops      []*TrailingStop
+	stream     *StreamClient
+	subscribed bool
+
+	events chan TrailingStop
+}
+
+// NewTrailingStopManager 는 store 에 저장된 트레일링 스탑을 불러와 manager 를 만듭니다. store 가 nil 이면 상태를 저장하지 않습니다.
+// 발동 상태로 저장되었지만 주문 결과가 없는 스탑 (주문 중에 종료됨) 은 주문 여부를 알 수 없으므로 실패로 표시합니다.
+func NewTrailingStopManager(trader Trading, store TrailingStopStore, options ...TrailingStopOption) (*TrailingStopManager, error) {
+
+	trailingStopManager := TrailingStopManager{}
+
+	trailingStopManager.trader = trader
+	trailingStopManager.store = store
+	trailingStopManager.logger = NewStdLogger(os.Stdout, LogWarn)
+	trailingStopManager.rules = DefaultOrderRules()
+	trailingStopManager.events = make(chan TrailingStop, 64)
+
+	for _, option := range options {
+		option(&trailingStopManager)
+	}
+
+	if store != nil {
+		stops, err := store.Load()
+		if err != nil {
+			return nil, err
+		}
+		for index := range stops {
+			stop := stops[index]
+			if stop.Status == TrailingStopTriggered && stop.OrderID == "" {
+				stop.Status = TrailingStopFailed
+				stop.Error = "주문 중에 종료되어 주문 여부를 알 수 없습니다. 주문 내역을 확인하세요."
+				trailingStopManager.logger.Log(LogWarn, "trailing stop order result unknown", LogField{"id", stop.ID}, LogField{"market", stop.Market})
+			}
+			trailingStopManager.stops = append(trailingStopManager.stops, &stop)
+		}
+	}
+	return &trailingStopManager, nil
+}
+
+// Events 는 발동된 (주문에 성공하거나 실패한) 트레일링 스탑을 전달합니다. Events 를 한 번이라도 호출하면 계속 읽어야 합니다.
+func (t *TrailingStopManager) Events() <-chan TrailingStop {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.subscribed = true
+	return t.events
+}
+
+// Add 는 트레일링 스탑을 등록하고 ID 가 채워진 값을 반환합니다.
+// TrailAmount, TrailPercent 중 하나만 지정해야 하며, Extreme 을 지정하면 그 가격을 기준으로 시작합니다.
+func (t *TrailingStopManager) Add(stop TrailingStop) (TrailingStop, error) {
+
+	// parameter 정상 체크
+	if !stop.Market.Valid() {
+		return TrailingStop{}, fmt.Errorf("%w: 시장이 올바르지 않습니다. (%q)", ErrInvalidParameter, stop.Market)
+	}
+	if !stop.Side.Valid() {
+		return TrailingStop{}, fmt.Errorf("%w: 주문 방향은 bid 또는 ask 이어야 합니다. (%q)", ErrInvalidParameter, stop.Side)
+	}
+	stop.Units = t.rules.RoundUnits(stop.Units)
+	if stop.Units.Sign() <= 0 {
+		return TrailingStop{}, fmt.Errorf("%w: 주문 수량은 소수점 %d 자리에서 내림한 뒤에도 0보다 커야 합니다.", ErrInvalidUnits, t.rules.UnitsPlaces)
+	}
+	if (stop.TrailAmount.Sign() > 0) == (stop.TrailPercent.Sign() > 0) || stop.TrailAmount.Sign() < 0 || stop.TrailPercent.Sign() < 0 {
+		return TrailingStop{}, fmt.Errorf("%w: TrailAmount, TrailPercent 중 하나만 0보다 커야 합니다.", ErrInvalidParameter)
+	}
+	if stop.TrailPercent.Cmp(NewDecimalFromInt(100)) >= 0 {
+		return TrailingStop{}, fmt.Errorf("%w: TrailPercent 는 100보다 작아야 합니다.", ErrInvalidParameter)
+	}
+	if stop.LimitOffset.Sign() < 0 {
+		return TrailingStop{}, fmt.Errorf("%w: LimitOffset 은 0보다 작을 수 없습니다.", ErrInvalidParameter)
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return TrailingStop{}, err
+	}
+	stop.ID = id
+	stop.Status = TrailingStopActive
+	stop.OrderID = ""
+	stop.Error = ""
+	stop.CreatedAt = time.Now()
+	stop.TriggeredAt = time.Time{}
+	stop.Trigger = Decimal{}
+	if stop.Extreme.Sign() > 0 {
+		stop.Trigger = stop.triggerOf(stop.Extreme, t.rules)
+	}
+
+	t.mutex.Lock()
+	t.stops = append(t.stops, &stop)
+	err = t.saveLocked()
+	stream := t.stream
+	t.mutex.Unlock()
+
+	if stream != nil {
+		if err := stream.SubscribeTransaction(stop.Market.Base, stop.Market.Quote); err != nil {
+			t.logger.Log(LogWarn, "trailing stop subscribe failed", LogField{"market", stop.Market}, LogField{"error", err})
+		}
+	}
+	return stop, err
+}
+
+// Remove 는 트레일링 스탑을 지웁니다. 이미 넣은 주문은 취소하지 않습니다.
+func (t *TrailingStopManager) Remove(id string) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for index, data := range t.stops {
+		if data.ID == id {
+			t.stops = append(t.stops[:index], t.stops[index+1:]...)
+			return t.saveLocked()
+		}
+	}
+	return fmt.Errorf("%w: trailing stop %s", ErrOrderNotFound, id)
+}
+
+// Stops 는 등록된 트레일링 스탑의 복사본을 반환합니다.
+func (t *TrailingStopManager) Stops() []TrailingStop {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	result := make([]TrailingStop, len(t.stops))
+	for index, data := range t.stops {
+		result[index] = *data
+	}
+	return result
+}
+
+func (t *TrailingStopManager) saveLocked() error {
+	if t.store == nil {
+		return nil
+	}
+	if err := t.store.Save(t.snapshotLocked()); err != nil {
+		t.logger.Log(LogError, "trailing stop save failed", LogField{"error", err})
+		return err
+	}
+	return nil
+}
+
+func (t *TrailingStopManager) snapshotLocked() []TrailingStop {
+	result := make([]TrailingStop, len(t.stops))
+	for index, data := range t.stops {
+		result[index] = *data
+	}
+	return result
+}
+
+// markets 는 대기 중인 트레일링 스탑이 있는 시장 목록입니다.
+func (t *TrailingStopManager) markets() []Market {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	seen := make(map[Market]bool)
+	var result []Market
+	for _, data := range t.stops {
+		if data.Status == TrailingStopActive && !seen[data.Market] {
+			seen[data.Market] = true
+			result = append(result, data.Market)
+		}
+	}
+	return result
+}
+
+func (t *TrailingStopManager) Update(market Market, price Decimal) error {
+	return t.UpdateCtx(context.Background(), market, price)
+}
+
+// UpdateCtx 는 market 의 현재 가격으로 트레일링 스탑을 조정하고, 발동된 스탑의 주문을 넣습니다.
+// 주문이나 저장에 실패하면 첫 번째 오류를 반환합니다.
+func (t *TrailingStopManager) UpdateCtx(ctx context.Context, market Market, price Decimal) error {
+	if price.Sign() <= 0 {
+		return nil
+	}
+
+	t.mutex.Lock()
+	changed := false
+	var fired []*TrailingStop
+	for _, data := range t.stops {
+		if data.Status != TrailingStopActive || data.Market != market {
+			continue
+		}
+		moved, fire := data.observe(price, t.rules)
+		changed = changed || moved
+		if fire {
+			data.Status = TrailingStopTriggered
+			data.TriggeredAt = time.Now()
+			fired = append(fired, data)
+			changed = true
+		}
+	}
+	var firstErr error
+	if changed {
+		firstErr = t.saveLocked()
+	}
+	t.mutex.Unlock()
+
+	// 발동 상태를 저장하지 못하면 다시 시작했을 때 두 번 주문할 수 있으므로 주문하지 않음
+	if firstErr != nil && len(fired) > 0 {
+		t.mutex.Lock()
+		for _, data := range fired {
+			data.Status = TrailingStopActive
+			data.TriggeredAt = time.Time{}
+		}
+		t.mutex.Unlock()
+		return firstErr
+	}
+
+	for _, data := range fired {
+		if err := t.fire(ctx, data); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// fire 는 발동된 스탑의 주문을 넣고 결과를 저장합니다.
+func (t *TrailingStopManager) fire(ctx context.Context, stop *TrailingStop) error {
+	t.mutex.Lock()
+	order := *stop
+	t.mutex.Unlock()
+
+	// 저장된 스탑은 규칙이 바뀌기 전에 만들어졌을 수 있으므로 주문 직전에 다시 맞춤
+	market := order.Market
+	units := t.rules.RoundUnits(order.Units)
+	var orderId string
+	var err error
+	switch {
+	case units.Sign() <= 0:
+		err = fmt.Errorf("%w: 주문 수량 %s 를 소수점 %d 자리에서 내리면 0 입니다.", ErrInvalidUnits, order.Units, t.rules.UnitsPlaces)
+	case order.Side == Ask && order.LimitOffset.IsZero():
+		orderId, err = t.trader.MarketSellCtx(ctx, market.Base, market.Quote, units)
+	case order.Side == Ask:
+		price := t.rules.RoundPrice(market.Quote, order.Trigger.Sub(order.LimitOffset), Ask)
+		orderId, err = t.trader.PlaceOrderCtx(ctx, market.Base, market.Quote, units, price, Ask)
+	case order.LimitOffset.IsZero():
+		orderId, err = t.trader.MarketBuyCtx(ctx, market.Base, market.Quote, units)
+	default:
+		price := t.rules.RoundPrice(market.Quote, order.Trigger.Add(order.LimitOffset), Bid)
+		orderId, err = t.trader.PlaceOrderCtx(ctx, market.Base, market.Quote, units, price, Bid)
+	}
+
+	t.mutex.Lock()
+	if err != nil {
+		stop.Status = TrailingStopFailed
+		stop.Error = err.Error()
+		t.logger.Log(LogError, "trailing stop order failed", LogField{"id", stop.ID}, LogField{"market", stop.Market}, LogField{"error", err})
+	} else {
+		stop.OrderID = orderId
+		t.logger.Log(LogInfo, "trailing stop triggered", LogField{"id", stop.ID}, LogField{"market", stop.Market}, LogField{"order_id", orderId})
+	}
+	saveErr := t.saveLocked()
+	result := *stop
+	subscribed := t.subscribed
+	t.mutex.Unlock()
+
+	if subscribed {
+		select {
+		case t.events <- result:
+		case <-ctx.Done():
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return saveErr
+}
+
+// Run 은 stream 의 체결 (transaction) 과 ticker 가격으로 트레일링 스탑을 조정합니다. ctx 가 끝나면 ctx.Err() 를 반환합니다.
+// 대기 중인 스탑의 시장과 이후 Add 되는 시장의 transaction 을 자동으로 구독합니다. 이 경우 stream 의 Transactions, Tickers channel 을 읽습니다.
+func (t *TrailingStopManager) Run(ctx context.Context, stream *StreamClient) error {
+
+	// parameter 정상 체크
+	if stream == nil {
+		return fmt.Errorf("%w: stream 이 nil 입니다.", ErrInvalidParameter)
+	}
+
+	t.mutex.Lock()
+	t.stream = stream
+	t.mutex.Unlock()
+	defer func() {
+		t.mutex.Lock()
+		t.stream = nil
+		t.mutex.Unlock()
+	}()
+
+	for _, market := range t.markets() {
+		if err := stream.SubscribeTransaction(market.Base, market.Quote); err != nil {
+			return err
+		}
+	}
+
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-stream.Transactions():
+			err = t.UpdateCtx(ctx, NewMarket(event.OrderCurrency, event.PaymentCurrency), event.Price)
+		case event := <-stream.Tickers():
+			err = t.UpdateCtx(ctx, NewMarket(event.OrderCurrency, event.PaymentCurrency), event.ClosePrice)
+		}
+		if err != nil && ctx.Err() == nil {
+			t.logger.Log(LogWarn, "trailing stop update failed", LogField{"error", err})
+		}
+	}
+}
+
+// RunPolling 은 interval 마다 source 의 GetTicker 종가로 트레일링 스탑을 조정합니다. ctx 가 끝나면 ctx.Err() 를 반환합니다.
+func (t *TrailingStopManager) RunPolling(ctx context.Context, source MarketData, interval time.Duration) error {
+
+	// parameter 정상 체크
+	if source == nil {
+		return fmt.Errorf("%w: source 가 nil 입니다.", ErrInvalidParameter)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("%w: 조회 간격은 0보다 커야 합니다. (%s)", ErrInvalidParameter, interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, market := range t.markets() {
+			tickers, _, err := source.GetTickerCtx(ctx, market.Base, market.Quote)
+			if err == nil {
+				err = t.UpdateCtx(ctx, market, tickers[market.Base].ClosingPrice)
+			}
+			if err != nil && ctx.Err() == nil {
+				t.logger.Log(LogWarn, "trailing stop update failed", LogField{"market", market}, LogField{"error", err})
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package gobithumb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrailingStopTrigger(t *testing.T) {
+	tests := []struct {
+		side         OrderSide
+		extreme      string
+		trailAmount  string
+		trailPercent string
+		want         string
+	}{
+		// 트레일이 호가 단위보다 작아도 발동 가격은 최고가 (최저가) 에서 떨어짐
+		{Ask, "3000", "", "0.01", "2999"},
+		{Bid, "3000", "", "0.01", "3001"},
+		{Ask, "1500", "0.5", "", "1499"},
+		{Bid, "1500", "0.5", "", "1501"},
+		// 트레일이 호가 단위에 맞지 않으면 최고가 (최저가) 에서 멀어지는 쪽으로 맞춤
+		{Ask, "100000000", "", "5", "95000000"},
+		{Ask, "12345000", "1500", "", "12343000"},
+		{Bid, "12345000", "1500", "", "12347000"},
+	}
+	rules := DefaultOrderRules()
+	for _, test := range tests {
+		stop := TrailingStop{Market: NewMarket(BTC, KRW), Side: test.side}
+		if test.trailAmount != "" {
+			stop.TrailAmount = MustDecimal(test.trailAmount)
+		}
+		if test.trailPercent != "" {
+			stop.TrailPercent = MustDecimal(test.trailPercent)
+		}
+		if result := stop.triggerOf(MustDecimal(test.extreme), rules); result.String() != test.want {
+			t.Errorf("%s trigger of %s = %s, want %s", test.side, test.extreme, result, test.want)
+		}
+	}
+}
+
+func TestTrailingStopObserve(t *testing.T) {
+	rules := DefaultOrderRules()
+	stop := TrailingStop{Market: NewMarket(BTC, KRW), Side: Ask, TrailPercent: MustDecimal("0.01")}
+
+	// 처음 받은 가격에서는 발동하지 않음
+	if changed, fire := stop.observe(MustDecimal("3000"), rules); !changed || fire {
+		t.Fatalf("observe(3000) = %v, %v, want changed without fire", changed, fire)
+	}
+	if changed, fire := stop.observe(MustDecimal("3005"), rules); !changed || fire || stop.Trigger.String() != "3004" {
+		t.Fatalf("observe(3005) = %v, %v, trigger %s, want trigger 3004 without fire", changed, fire, stop.Trigger)
+	}
+	if changed, fire := stop.observe(MustDecimal("3004"), rules); changed || !fire {
+		t.Errorf("observe(3004) = %v, %v, want fire", changed, fire)
+	}
+}
+
+func TestTrailingStopRunInvalid(t *testing.T) {
+	manager, err := NewTrailingStopManager(nil, nil, WithTrailingStopLogger(NopLogger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Run(context.Background(), nil); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Run(nil) error = %v, want ErrInvalidParameter", err)
+	}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := manager.RunPolling(context.Background(), NewBithumb("", ""), interval); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("RunPolling(%s) error = %v, want ErrInvalidParameter", interval, err)
+		}
+	}
+}
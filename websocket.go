@@ -0,0 +1,288 @@
+package gobithumb
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 스트리밍에 필요한 최소한의 WebSocket(RFC 6455) client 구현입니다.
+
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+
+	wsAcceptGUID     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	wsMaxMessageSize = 16 << 20
+)
+
+var errWebSocketClosed = errors.New("gobithumb: websocket closed")
+
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	// readTimeout 안에 아무 frame (ping, pong 포함) 도 받지 못하면 읽기가 실패합니다. 0 이면 제한이 없습니다.
+	readTimeout  time.Duration
+	writeTimeout time.Duration
+
+	writeMutex sync.Mutex
+}
+
+func dialWebSocket(ctx context.Context, rawUrl string, tlsConfig *tls.Config) (*wsConn, error) {
+
+	wsUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	host := wsUrl.Host
+	if wsUrl.Port() == "" {
+		if wsUrl.Scheme == "wss" {
+			host = net.JoinHostPort(wsUrl.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(wsUrl.Hostname(), "80")
+		}
+	}
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	// handshake 가 끝날 때까지 context 의 deadline 을 적용
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			_ = conn.SetDeadline(time.Unix(1, 0))
+		case <-stop:
+		}
+	}()
+	// 반환하기 전에 goroutine 이 끝난 것을 확인해야, 연결을 반환한 뒤에 지난 deadline 이 설정되지 않음
+	var stopOnce sync.Once
+	stopWatch := func() {
+		stopOnce.Do(func() {
+			close(stop)
+			<-stopped
+		})
+	}
+	defer stopWatch()
+
+	if wsUrl.Scheme == "wss" {
+		config := &tls.Config{}
+		if tlsConfig != nil {
+			config = tlsConfig.Clone()
+		}
+		if config.ServerName == "" {
+			config.ServerName = wsUrl.Hostname()
+		}
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(keyBytes)
+
+	request := &http.Request{
+		Method:     "GET",
+		URL:        &url.URL{Path: wsUrl.Path, RawQuery: wsUrl.RawQuery},
+		Host:       wsUrl.Host,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+	}
+	if request.URL.Path == "" {
+		request.URL.Path = "/"
+	}
+	request.Header.Set("Upgrade", "websocket")
+	request.Header.Set("Connection", "Upgrade")
+	request.Header.Set("Sec-WebSocket-Key", key)
+	request.Header.Set("Sec-WebSocket-Version", "13")
+	if err := request.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	response.Body.Close()
+
+	acceptHash := sha1.Sum([]byte(key + wsAcceptGUID))
+	if response.StatusCode != http.StatusSwitchingProtocols ||
+		!strings.EqualFold(response.Header.Get("Upgrade"), "websocket") ||
+		response.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(acceptHash[:]) {
+		conn.Close()
+		return nil, fmt.Errorf("gobithumb: websocket handshake failed (http %d)", response.StatusCode)
+	}
+
+	stopWatch()
+	if err := ctx.Err(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return &wsConn{conn: conn, reader: reader}, nil
+}
+
+// readMessage 는 text, binary 메시지 하나를 읽습니다. ping 에는 자동으로 pong 을 보냅니다.
+func (w *wsConn) readMessage() ([]byte, error) {
+	var message []byte
+	for {
+		fin, opcode, payload, err := w.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch opcode {
+		case wsOpPing:
+			if err := w.writeFrame(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			_ = w.writeFrame(wsOpClose, payload)
+			return nil, errWebSocketClosed
+		case wsOpText, wsOpBinary, wsOpContinuation:
+			message = append(message, payload...)
+			if len(message) > wsMaxMessageSize {
+				return nil, errors.New("gobithumb: websocket message too large")
+			}
+		default:
+			return nil, fmt.Errorf("gobithumb: unknown websocket opcode %d", opcode)
+		}
+
+		if fin {
+			return message, nil
+		}
+	}
+}
+
+func (w *wsConn) readFrame() (bool, byte, []byte, error) {
+	if w.readTimeout > 0 {
+		_ = w.conn.SetReadDeadline(time.Now().Add(w.readTimeout))
+	}
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(w.reader, header); err != nil {
+		return false, 0, nil, err
+	}
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(w.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(w.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended)
+	}
+	if length > wsMaxMessageSize {
+		return false, 0, nil, errors.New("gobithumb: websocket frame too large")
+	}
+
+	var maskKey [4]byte
+	if masked {
+		if _, err := io.ReadFull(w.reader, maskKey[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(w.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= maskKey[i%4]
+		}
+	}
+	return fin, opcode, payload, nil
+}
+
+func (w *wsConn) writeMessage(payload []byte) error {
+	return w.writeFrame(wsOpText, payload)
+}
+
+// writeFrame 은 client 규약에 따라 항상 mask 를 씌워 frame 을 보냅니다.
+func (w *wsConn) writeFrame(opcode byte, payload []byte) error {
+	frame := []byte{0x80 | opcode}
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, 0x80|byte(len(payload)))
+	case len(payload) <= 0xffff:
+		frame = append(frame, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	default:
+		frame = append(frame, 0x80|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(frame[2:], uint64(len(payload)))
+	}
+
+	var maskKey [4]byte
+	if _, err := rand.Read(maskKey[:]); err != nil {
+		return err
+	}
+	frame = append(frame, maskKey[:]...)
+	for i, data := range payload {
+		frame = append(frame, data^maskKey[i%4])
+	}
+
+	w.writeMutex.Lock()
+	defer w.writeMutex.Unlock()
+	if w.writeTimeout > 0 {
+		_ = w.conn.SetWriteDeadline(time.Now().Add(w.writeTimeout))
+	}
+	_, err := w.conn.Write(frame)
+	return err
+}
+
+func (w *wsConn) close() error {
+	_ = w.writeFrame(wsOpClose, []byte{0x03, 0xe8}) // 1000 : normal closure
+	return w.conn.Close()
+}